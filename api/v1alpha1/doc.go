@@ -0,0 +1,7 @@
+// Package v1alpha1 contains the v1alpha1 API of the autoscaling.storage.k8s.io
+// group: VolumeScaler and VolumeScalerLimit.
+//
+// +k8s:deepcopy-gen=package
+// +groupName=autoscaling.storage.k8s.io
+// +groupGoName=Autoscaling
+package v1alpha1
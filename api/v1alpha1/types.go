@@ -0,0 +1,444 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApprovalPolicy lists the expansions that must be approved by a person
+// through a VolumeScaleRequest before the PVC is patched.
+type ApprovalPolicy struct {
+	SizeAbove      string `json:"sizeAbove,omitempty"`      // expansions to a size above this, e.g. "1Ti"
+	IncrementAbove string `json:"incrementAbove,omitempty"` // expansions growing the PVC by more than this, e.g. "500Gi"
+	Expiry         string `json:"expiry,omitempty"`         // how long a request waits for approval, e.g. "24h"
+}
+
+// ScaleSchedule raises the PVC to MinSize whenever Schedule fires.
+type ScaleSchedule struct {
+	Name     string `json:"name,omitempty"`
+	Schedule string `json:"schedule"` // cron expression, e.g. "0 0 28 * *"
+	MinSize  string `json:"minSize"`  // e.g. "800Gi"
+}
+
+// BlackoutWindow defers threshold-triggered expansions for Duration after
+// each time Start fires.
+type BlackoutWindow struct {
+	Name     string `json:"name,omitempty"`
+	Start    string `json:"start"`    // cron expression, e.g. "0 8 * * 1"
+	Duration string `json:"duration"` // e.g. "4h"
+}
+
+// GrowthAnomalyPolicy holds threshold-triggered expansions while usage grows
+// unusually fast, e.g. because an application writes runaway logs.
+type GrowthAnomalyPolicy struct {
+	MaxGrowthPerHour string `json:"maxGrowthPerHour,omitempty"` // e.g. "20Gi"; absolute rate limit
+	BaselineMultiple int32  `json:"baselineMultiple,omitempty"` // e.g. 5; times the usual growth rate
+}
+
+// GrowthBudget caps how often and how much volumes may grow in any 24 hours.
+type GrowthBudget struct {
+	MaxExpansionsPerDay int32  `json:"maxExpansionsPerDay,omitempty"` // e.g. 4
+	MaxGrowthPerDay     string `json:"maxGrowthPerDay,omitempty"`     // e.g. "200Gi"
+}
+
+// SpendBudget caps the projected monthly cost of the PVCs managed by
+// VolumeScaler, priced with the controller's price table.
+type SpendBudget struct {
+	NamespaceMonthlyLimit string `json:"namespaceMonthlyLimit,omitempty"` // per matching namespace, e.g. "500"
+	ClusterMonthlyLimit   string `json:"clusterMonthlyLimit,omitempty"`   // across the cluster, e.g. "20000"
+	Action                string `json:"action,omitempty"`                // "Block" (default) or "RequireApproval"
+}
+
+// SnapshotPolicy takes a VolumeSnapshot of the PVC before every expansion.
+type SnapshotPolicy struct {
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName"`
+	ReadyTimeout            string `json:"readyTimeout,omitempty"` // e.g. "10m"; how long to wait for readyToUse
+	MaxAge                  string `json:"maxAge,omitempty"`       // e.g. "30m"; a ready snapshot older than this is retaken
+	Retain                  int32  `json:"retain,omitempty"`       // snapshots kept per PVC, default 3
+}
+
+// WorkloadReference names the Deployment or StatefulSet that mounts a PVC.
+type WorkloadReference struct {
+	Kind string `json:"kind"` // "Deployment" or "StatefulSet"
+	Name string `json:"name"`
+}
+
+// ShrinkPolicy migrates a PVC that stays mostly empty to a smaller one,
+// copying the data while the workload is scaled to zero.
+type ShrinkPolicy struct {
+	BelowUsage   string            `json:"belowUsage"`            // e.g. "20%"
+	SustainedFor string            `json:"sustainedFor"`          // e.g. "72h"
+	TargetUsage  string            `json:"targetUsage,omitempty"` // usage after the shrink, default "60%"
+	MinSize      string            `json:"minSize,omitempty"`     // e.g. "50Gi"
+	Workload     WorkloadReference `json:"workload"`
+	// MaintenanceWindow lets the controller scale the workload to zero when
+	// it opens; without one the shrink waits for the workload to be scaled
+	// to zero by someone else
+	MaintenanceWindow *BlackoutWindow `json:"maintenanceWindow,omitempty"`
+	CopyImage         string          `json:"copyImage,omitempty"` // image with a POSIX cp, default "busybox:1.36"
+}
+
+// ShrinkStatus tracks a shrink migration through its phases.
+type ShrinkStatus struct {
+	Phase            string `json:"phase,omitempty"` // Pending, ScalingDown, Copying, Swapping, Verifying, Completed or Failed
+	Message          string `json:"message,omitempty"`
+	PhaseSince       string `json:"phaseSince,omitempty"`
+	LowUsageSince    string `json:"lowUsageSince,omitempty"` // set while usage is below belowUsage
+	Node             string `json:"node,omitempty"`          // node whose controller drives the migration
+	SourcePVC        string `json:"sourcePVC,omitempty"`
+	TargetPVC        string `json:"targetPVC,omitempty"`
+	TargetSize       string `json:"targetSize,omitempty"`
+	CopyJob          string `json:"copyJob,omitempty"`
+	OriginalReplicas int32  `json:"originalReplicas,omitempty"` // restored after the swap when the controller scaled the workload down
+}
+
+// ExpansionRecord is one entry of the expansion ledger kept in the status.
+type ExpansionRecord struct {
+	Time      string `json:"time"`                // when the PVC was patched
+	Increase  string `json:"increase"`            // e.g. "2Gi"
+	CostAdded string `json:"costAdded,omitempty"` // monthly cost of the increase, e.g. "0.16"
+}
+
+// CostStatus estimates what the PVC costs under the controller's price table.
+// Amounts are in the table's currency; monthly amounts assume 730 hours.
+type CostStatus struct {
+	Currency          string `json:"currency,omitempty"`          // e.g. "USD"
+	PricePerGiBMonth  string `json:"pricePerGiBMonth,omitempty"`  // e.g. "0.08"
+	MonthlyCost       string `json:"monthlyCost,omitempty"`       // at the current size
+	MonthlySavings    string `json:"monthlySavings,omitempty"`    // against the static baseline
+	LastExpansionCost string `json:"lastExpansionCost,omitempty"` // monthly cost added by the latest expansion
+	CumulativeCost    string `json:"cumulativeCost,omitempty"`    // accrued since Since
+	CumulativeSavings string `json:"cumulativeSavings,omitempty"` // accrued since Since
+	Since             string `json:"since,omitempty"`
+	LastAccrued       string `json:"lastAccrued,omitempty"`
+}
+
+// VolumeScalerSpec defines the desired state of VolumeScaler
+type VolumeScalerSpec struct {
+	PVCName        string `json:"pvcName"`
+	Threshold      string `json:"threshold"`          // e.g., "70%"
+	Scale          string `json:"scale"`              // e.g., "2Gi" or "30%"
+	ScaleType      string `json:"scaleType"`          // "fixed" or "percentage" ("VolumeScaler" is a legacy alias)
+	CooldownPeriod string `json:"cooldownPeriod"`     // e.g. "10m"
+	MaxSize        string `json:"maxSize"`            // e.g., "15Gi"
+	Priority       int32  `json:"priority,omitempty"` // breaks ties when several VolumeScalers target one PVC
+	Mode           string `json:"mode,omitempty"`     // "Observe", "Recommend" or "Enforce" (default)
+	Suspend        bool   `json:"suspend,omitempty"`  // pauses scaling; usage is still reported
+
+	ApprovalPolicy *ApprovalPolicy `json:"approvalPolicy,omitempty"`
+
+	Schedules         []ScaleSchedule  `json:"schedules,omitempty"`
+	BlackoutWindows   []BlackoutWindow `json:"blackoutWindows,omitempty"`
+	CriticalThreshold string           `json:"criticalThreshold,omitempty"` // e.g., "95%"; skips the cooldown and blackout windows
+	CriticalScale     string           `json:"criticalScale,omitempty"`     // e.g., "50%" or "20Gi"; increment above criticalThreshold
+
+	BreachDuration string `json:"breachDuration,omitempty"` // e.g., "10m"; how long usage must stay above threshold
+	ResetThreshold string `json:"resetThreshold,omitempty"` // e.g., "65%"; usage must drop below this to end a breach
+
+	GrowthAnomaly *GrowthAnomalyPolicy `json:"growthAnomaly,omitempty"`
+	GrowthBudget  *GrowthBudget        `json:"growthBudget,omitempty"`
+
+	SnapshotBeforeResize *SnapshotPolicy `json:"snapshotBeforeResize,omitempty"`
+
+	Shrink *ShrinkPolicy `json:"shrink,omitempty"`
+}
+
+// VolumeScalerStatus defines the observed state of VolumeScaler
+type VolumeScalerStatus struct {
+	ScaledAt            string `json:"scaledAt,omitempty"`
+	ReachedMaxSize      bool   `json:"reachedMaxSize,omitempty"`
+	ResizeInProgress    bool   `json:"resizeInProgress,omitempty"`
+	LastRequestedSize   string `json:"lastRequestedSize,omitempty"`
+	CurrentUsagePercent int    `json:"currentUsagePercent,omitempty"`
+	CurrentUsedGi       string `json:"currentUsedGi,omitempty"`
+	CurrentSizeGi       string `json:"currentSizeGi,omitempty"`
+	LimitClamp          string `json:"limitClamp,omitempty"` // guardrails applied to the last expansion
+
+	// Expansion proposed in Recommend mode
+	RecommendedSize      string `json:"recommendedSize,omitempty"`
+	RecommendationReason string `json:"recommendationReason,omitempty"`
+	RecommendedAt        string `json:"recommendedAt,omitempty"`
+
+	// Schedules and blackout windows
+	LastScheduleTime string `json:"lastScheduleTime,omitempty"`
+	NextScheduleTime string `json:"nextScheduleTime,omitempty"`
+	NextScheduleSize string `json:"nextScheduleSize,omitempty"`
+	BlackoutUntil    string `json:"blackoutUntil,omitempty"`    // set while a blackout window is open
+	NextBlackoutTime string `json:"nextBlackoutTime,omitempty"` // start of the next blackout window
+
+	BreachingSince string `json:"breachingSince,omitempty"` // set while usage is above threshold
+
+	// Growth rate between the last two samples and its long-running average
+	GrowthRatePerHour     string `json:"growthRatePerHour,omitempty"`
+	BaselineGrowthPerHour string `json:"baselineGrowthPerHour,omitempty"`
+
+	// Expansions of the last 24 hours, oldest first, for growth budgets
+	ExpansionLedger []ExpansionRecord `json:"expansionLedger,omitempty"`
+
+	// Set while an expansion waits for a cluster-wide resize slot
+	QueuePosition  int32  `json:"queuePosition,omitempty"`
+	QueuedSince    string `json:"queuedSince,omitempty"`
+	QueueHeartbeat string `json:"queueHeartbeat,omitempty"` // refreshed every loop the volume waits
+
+	// Pre-expansion VolumeSnapshots: the one being taken, and those kept, oldest first
+	PendingSnapshot string   `json:"pendingSnapshot,omitempty"`
+	Snapshots       []string `json:"snapshots,omitempty"`
+
+	Shrink *ShrinkStatus `json:"shrink,omitempty"`
+
+	Cost *CostStatus `json:"cost,omitempty"`
+
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VolumeScaler is the Schema for the volumescalers API
+type VolumeScaler struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VolumeScalerSpec   `json:"spec"`
+	Status VolumeScalerStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VolumeScalerList contains a list of VolumeScaler
+type VolumeScalerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []VolumeScaler `json:"items"`
+}
+
+// VolumeScalerLimitSpec defines the guardrails an administrator places on the
+// VolumeScalers of matching namespaces and StorageClasses.
+type VolumeScalerLimitSpec struct {
+	NamespaceSelector   *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	StorageClassNames   []string              `json:"storageClassNames,omitempty"`
+	MaxSize             string                `json:"maxSize,omitempty"`             // e.g., "500Gi"
+	MinCooldownPeriod   string                `json:"minCooldownPeriod,omitempty"`   // e.g., "30m"
+	MaxIncrement        string                `json:"maxIncrement,omitempty"`        // e.g., "50Gi"
+	MaxNamespaceStorage string                `json:"maxNamespaceStorage,omitempty"` // e.g., "2Ti"
+	ApprovalPolicy      *ApprovalPolicy       `json:"approvalPolicy,omitempty"`
+
+	// NamespaceGrowthBudget caps the combined growth of all VolumeScalers in
+	// each matching namespace
+	NamespaceGrowthBudget *GrowthBudget `json:"namespaceGrowthBudget,omitempty"`
+
+	// SpendBudget holds back expansions that would push projected monthly
+	// storage spend over a ceiling
+	SpendBudget *SpendBudget `json:"spendBudget,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VolumeScalerLimit is the Schema for the cluster-scoped volumescalerlimits API
+type VolumeScalerLimit struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VolumeScalerLimitSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VolumeScalerLimitList contains a list of VolumeScalerLimit
+type VolumeScalerLimitList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []VolumeScalerLimit `json:"items"`
+}
+
+// VolumeScaleRequestSpec describes a single expansion. The controller creates
+// requests with RequestedSize when an expansion needs approval; workloads
+// create them with MinFreeSpace to ask for room ahead of time.
+type VolumeScaleRequestSpec struct {
+	VolumeScalerName string `json:"volumeScalerName"`
+	PVCName          string `json:"pvcName,omitempty"`
+	CurrentSize      string `json:"currentSize,omitempty"`   // e.g., "800Gi"
+	RequestedSize    string `json:"requestedSize,omitempty"` // e.g., "1200Gi"
+	Reason           string `json:"reason,omitempty"`        // why approval is required
+
+	// On-demand requests
+	MinFreeSpace string `json:"minFreeSpace,omitempty"` // free space to ensure, e.g., "400Gi"
+	Deadline     string `json:"deadline,omitempty"`     // RFC 3339 time by which it must be free
+}
+
+// VolumeScaleRequestStatus defines the observed state of VolumeScaleRequest
+type VolumeScaleRequestStatus struct {
+	Phase       string `json:"phase,omitempty"` // "Pending", "Approved", "Denied", "Applied", "Expired", "Fulfilled" or "Failed"
+	Message     string `json:"message,omitempty"`
+	ApprovedBy  string `json:"approvedBy,omitempty"`
+	ExpiresAt   string `json:"expiresAt,omitempty"`
+	AppliedAt   string `json:"appliedAt,omitempty"`
+	TargetSize  string `json:"targetSize,omitempty"`  // size chosen for an on-demand request
+	FulfilledAt string `json:"fulfilledAt,omitempty"` // when the requested free space became available
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VolumeScaleRequest is the Schema for the volumescalerequests API
+type VolumeScaleRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VolumeScaleRequestSpec   `json:"spec"`
+	Status VolumeScaleRequestStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VolumeScaleRequestList contains a list of VolumeScaleRequest
+type VolumeScaleRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []VolumeScaleRequest `json:"items"`
+}
+
+// VolumeRecommendationSpec names the PVC a recommendation is for. The
+// controller creates one VolumeRecommendation per mounted PVC, named after it.
+type VolumeRecommendationSpec struct {
+	PVCName string `json:"pvcName"`
+}
+
+// UsagePeak is the highest usage of a PVC observed on one day.
+type UsagePeak struct {
+	Date      string `json:"date"` // UTC day, e.g. "2024-05-01"
+	UsedBytes int64  `json:"usedBytes"`
+}
+
+// VolumeRecommendationStatus holds the peak usage of the PVC over the
+// recommendation window and the size that peak needs.
+type VolumeRecommendationStatus struct {
+	VolumeScalerName string      `json:"volumeScalerName,omitempty"` // set when a VolumeScaler manages the PVC
+	CurrentSize      string      `json:"currentSize,omitempty"`      // e.g. "500Gi"
+	PeakUsage        string      `json:"peakUsage,omitempty"`        // e.g. "14.2Gi"
+	RecommendedSize  string      `json:"recommendedSize,omitempty"`  // e.g. "24Gi"; set once enough usage was observed
+	Savings          string      `json:"savings,omitempty"`          // CurrentSize - RecommendedSize when over-provisioned
+	ObservedSince    string      `json:"observedSince,omitempty"`
+	Message          string      `json:"message,omitempty"`
+	DailyPeaks       []UsagePeak `json:"dailyPeaks,omitempty"` // oldest first, covering the window
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VolumeRecommendation is the Schema for the volumerecommendations API
+type VolumeRecommendation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VolumeRecommendationSpec   `json:"spec"`
+	Status VolumeRecommendationStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VolumeRecommendationList contains a list of VolumeRecommendation
+type VolumeRecommendationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []VolumeRecommendation `json:"items"`
+}
+
+// VolumeUsageHistorySpec names the PVC a history is for. The controller
+// creates one VolumeUsageHistory per managed PVC, named after it.
+type VolumeUsageHistorySpec struct {
+	PVCName string `json:"pvcName"`
+}
+
+// UsageRollup summarizes the usage samples of a PVC within one bucket.
+type UsageRollup struct {
+	Time          string `json:"time"`          // start of the bucket, RFC 3339
+	UsedBytes     int64  `json:"usedBytes"`     // average over the samples
+	MaxUsedBytes  int64  `json:"maxUsedBytes"`  // highest sample
+	CapacityBytes int64  `json:"capacityBytes"` // capacity at the last sample
+	Samples       int32  `json:"samples"`
+}
+
+// VolumeUsageHistoryStatus holds ring buffers of usage rollups, oldest first.
+type VolumeUsageHistoryStatus struct {
+	Minutes     []UsageRollup `json:"minutes,omitempty"` // per minute, last 2 hours
+	Hours       []UsageRollup `json:"hours,omitempty"`   // per hour, last 7 days
+	Days        []UsageRollup `json:"days,omitempty"`    // per UTC day, last 90 days
+	LastSampled string        `json:"lastSampled,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VolumeUsageHistory is the Schema for the volumeusagehistories API
+type VolumeUsageHistory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VolumeUsageHistorySpec   `json:"spec"`
+	Status VolumeUsageHistoryStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VolumeUsageHistoryList contains a list of VolumeUsageHistory
+type VolumeUsageHistoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []VolumeUsageHistory `json:"items"`
+}
+
+// ScaleEventUsage is the usage of the PVC when a decision was made.
+type ScaleEventUsage struct {
+	UsedGi       string `json:"usedGi,omitempty"` // e.g. "41.3Gi"
+	SizeGi       string `json:"sizeGi,omitempty"` // requested size, e.g. "50Gi"
+	UsagePercent int    `json:"usagePercent"`
+}
+
+// VolumeScaleEventSpec records one scaling decision. The controller creates
+// VolumeScaleEvents and never changes them.
+type VolumeScaleEventSpec struct {
+	VolumeScalerName string `json:"volumeScalerName"`
+	PVCName          string `json:"pvcName"`
+	Time             string `json:"time"`
+	Decision         string `json:"decision"`          // "Expanded", "Completed", "SkippedCooldown", "AtMaxSize", "Blocked", "Held", "Recommended", "Failed" or "RolledBack"
+	Trigger          string `json:"trigger,omitempty"` // "Threshold", "Critical", "Schedule", "ScaleRequest" or "Shrink"
+
+	Usage        *ScaleEventUsage  `json:"usage,omitempty"`
+	Policy       *VolumeScalerSpec `json:"policy,omitempty"`       // the VolumeScaler's spec at the time
+	CurrentSize  string            `json:"currentSize,omitempty"`  // e.g. "100Gi"
+	ComputedSize string            `json:"computedSize,omitempty"` // size the controller chose, after clamps
+	Clamps       string            `json:"clamps,omitempty"`       // guardrails applied to the decision
+
+	Actor      string `json:"actor"`                // node whose controller decided, e.g. "node/ip-10-0-1-12"
+	Controller string `json:"controller,omitempty"` // controller pod
+	Message    string `json:"message,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VolumeScaleEvent is the Schema for the volumescaleevents API, an
+// append-only audit trail of scaling decisions
+type VolumeScaleEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VolumeScaleEventSpec `json:"spec"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VolumeScaleEventList contains a list of VolumeScaleEvent
+type VolumeScaleEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []VolumeScaleEvent `json:"items"`
+}
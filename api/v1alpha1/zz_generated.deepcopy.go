@@ -0,0 +1,898 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+// Code generated by deepcopy-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApprovalPolicy) DeepCopyInto(out *ApprovalPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalPolicy.
+func (in *ApprovalPolicy) DeepCopy() *ApprovalPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ApprovalPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackoutWindow) DeepCopyInto(out *BlackoutWindow) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackoutWindow.
+func (in *BlackoutWindow) DeepCopy() *BlackoutWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackoutWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CostStatus) DeepCopyInto(out *CostStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CostStatus.
+func (in *CostStatus) DeepCopy() *CostStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CostStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExpansionRecord) DeepCopyInto(out *ExpansionRecord) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExpansionRecord.
+func (in *ExpansionRecord) DeepCopy() *ExpansionRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(ExpansionRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthAnomalyPolicy) DeepCopyInto(out *GrowthAnomalyPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthAnomalyPolicy.
+func (in *GrowthAnomalyPolicy) DeepCopy() *GrowthAnomalyPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthAnomalyPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthBudget) DeepCopyInto(out *GrowthBudget) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthBudget.
+func (in *GrowthBudget) DeepCopy() *GrowthBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleEventUsage) DeepCopyInto(out *ScaleEventUsage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleEventUsage.
+func (in *ScaleEventUsage) DeepCopy() *ScaleEventUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleEventUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleSchedule) DeepCopyInto(out *ScaleSchedule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleSchedule.
+func (in *ScaleSchedule) DeepCopy() *ScaleSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShrinkPolicy) DeepCopyInto(out *ShrinkPolicy) {
+	*out = *in
+	out.Workload = in.Workload
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(BlackoutWindow)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShrinkPolicy.
+func (in *ShrinkPolicy) DeepCopy() *ShrinkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ShrinkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShrinkStatus) DeepCopyInto(out *ShrinkStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShrinkStatus.
+func (in *ShrinkStatus) DeepCopy() *ShrinkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ShrinkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotPolicy) DeepCopyInto(out *SnapshotPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotPolicy.
+func (in *SnapshotPolicy) DeepCopy() *SnapshotPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpendBudget) DeepCopyInto(out *SpendBudget) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpendBudget.
+func (in *SpendBudget) DeepCopy() *SpendBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(SpendBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsagePeak) DeepCopyInto(out *UsagePeak) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsagePeak.
+func (in *UsagePeak) DeepCopy() *UsagePeak {
+	if in == nil {
+		return nil
+	}
+	out := new(UsagePeak)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsageRollup) DeepCopyInto(out *UsageRollup) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageRollup.
+func (in *UsageRollup) DeepCopy() *UsageRollup {
+	if in == nil {
+		return nil
+	}
+	out := new(UsageRollup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeRecommendation) DeepCopyInto(out *VolumeRecommendation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeRecommendation.
+func (in *VolumeRecommendation) DeepCopy() *VolumeRecommendation {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeRecommendation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeRecommendation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeRecommendationList) DeepCopyInto(out *VolumeRecommendationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VolumeRecommendation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeRecommendationList.
+func (in *VolumeRecommendationList) DeepCopy() *VolumeRecommendationList {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeRecommendationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeRecommendationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeRecommendationSpec) DeepCopyInto(out *VolumeRecommendationSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeRecommendationSpec.
+func (in *VolumeRecommendationSpec) DeepCopy() *VolumeRecommendationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeRecommendationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeRecommendationStatus) DeepCopyInto(out *VolumeRecommendationStatus) {
+	*out = *in
+	if in.DailyPeaks != nil {
+		in, out := &in.DailyPeaks, &out.DailyPeaks
+		*out = make([]UsagePeak, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeRecommendationStatus.
+func (in *VolumeRecommendationStatus) DeepCopy() *VolumeRecommendationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeRecommendationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeScaleEvent) DeepCopyInto(out *VolumeScaleEvent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeScaleEvent.
+func (in *VolumeScaleEvent) DeepCopy() *VolumeScaleEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeScaleEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeScaleEvent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeScaleEventList) DeepCopyInto(out *VolumeScaleEventList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VolumeScaleEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeScaleEventList.
+func (in *VolumeScaleEventList) DeepCopy() *VolumeScaleEventList {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeScaleEventList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeScaleEventList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeScaleEventSpec) DeepCopyInto(out *VolumeScaleEventSpec) {
+	*out = *in
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(ScaleEventUsage)
+		**out = **in
+	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(VolumeScalerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeScaleEventSpec.
+func (in *VolumeScaleEventSpec) DeepCopy() *VolumeScaleEventSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeScaleEventSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeScaleRequest) DeepCopyInto(out *VolumeScaleRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeScaleRequest.
+func (in *VolumeScaleRequest) DeepCopy() *VolumeScaleRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeScaleRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeScaleRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeScaleRequestList) DeepCopyInto(out *VolumeScaleRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VolumeScaleRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeScaleRequestList.
+func (in *VolumeScaleRequestList) DeepCopy() *VolumeScaleRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeScaleRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeScaleRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeScaleRequestSpec) DeepCopyInto(out *VolumeScaleRequestSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeScaleRequestSpec.
+func (in *VolumeScaleRequestSpec) DeepCopy() *VolumeScaleRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeScaleRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeScaleRequestStatus) DeepCopyInto(out *VolumeScaleRequestStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeScaleRequestStatus.
+func (in *VolumeScaleRequestStatus) DeepCopy() *VolumeScaleRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeScaleRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeScaler) DeepCopyInto(out *VolumeScaler) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeScaler.
+func (in *VolumeScaler) DeepCopy() *VolumeScaler {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeScaler)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeScaler) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeScalerLimit) DeepCopyInto(out *VolumeScalerLimit) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeScalerLimit.
+func (in *VolumeScalerLimit) DeepCopy() *VolumeScalerLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeScalerLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeScalerLimit) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeScalerLimitList) DeepCopyInto(out *VolumeScalerLimitList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VolumeScalerLimit, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeScalerLimitList.
+func (in *VolumeScalerLimitList) DeepCopy() *VolumeScalerLimitList {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeScalerLimitList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeScalerLimitList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeScalerLimitSpec) DeepCopyInto(out *VolumeScalerLimitSpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StorageClassNames != nil {
+		in, out := &in.StorageClassNames, &out.StorageClassNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ApprovalPolicy != nil {
+		in, out := &in.ApprovalPolicy, &out.ApprovalPolicy
+		*out = new(ApprovalPolicy)
+		**out = **in
+	}
+	if in.NamespaceGrowthBudget != nil {
+		in, out := &in.NamespaceGrowthBudget, &out.NamespaceGrowthBudget
+		*out = new(GrowthBudget)
+		**out = **in
+	}
+	if in.SpendBudget != nil {
+		in, out := &in.SpendBudget, &out.SpendBudget
+		*out = new(SpendBudget)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeScalerLimitSpec.
+func (in *VolumeScalerLimitSpec) DeepCopy() *VolumeScalerLimitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeScalerLimitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeScalerList) DeepCopyInto(out *VolumeScalerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VolumeScaler, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeScalerList.
+func (in *VolumeScalerList) DeepCopy() *VolumeScalerList {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeScalerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeScalerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeScalerSpec) DeepCopyInto(out *VolumeScalerSpec) {
+	*out = *in
+	if in.ApprovalPolicy != nil {
+		in, out := &in.ApprovalPolicy, &out.ApprovalPolicy
+		*out = new(ApprovalPolicy)
+		**out = **in
+	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]ScaleSchedule, len(*in))
+		copy(*out, *in)
+	}
+	if in.BlackoutWindows != nil {
+		in, out := &in.BlackoutWindows, &out.BlackoutWindows
+		*out = make([]BlackoutWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.GrowthAnomaly != nil {
+		in, out := &in.GrowthAnomaly, &out.GrowthAnomaly
+		*out = new(GrowthAnomalyPolicy)
+		**out = **in
+	}
+	if in.GrowthBudget != nil {
+		in, out := &in.GrowthBudget, &out.GrowthBudget
+		*out = new(GrowthBudget)
+		**out = **in
+	}
+	if in.SnapshotBeforeResize != nil {
+		in, out := &in.SnapshotBeforeResize, &out.SnapshotBeforeResize
+		*out = new(SnapshotPolicy)
+		**out = **in
+	}
+	if in.Shrink != nil {
+		in, out := &in.Shrink, &out.Shrink
+		*out = new(ShrinkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeScalerSpec.
+func (in *VolumeScalerSpec) DeepCopy() *VolumeScalerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeScalerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeScalerStatus) DeepCopyInto(out *VolumeScalerStatus) {
+	*out = *in
+	if in.ExpansionLedger != nil {
+		in, out := &in.ExpansionLedger, &out.ExpansionLedger
+		*out = make([]ExpansionRecord, len(*in))
+		copy(*out, *in)
+	}
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Shrink != nil {
+		in, out := &in.Shrink, &out.Shrink
+		*out = new(ShrinkStatus)
+		**out = **in
+	}
+	if in.Cost != nil {
+		in, out := &in.Cost, &out.Cost
+		*out = new(CostStatus)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeScalerStatus.
+func (in *VolumeScalerStatus) DeepCopy() *VolumeScalerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeScalerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeUsageHistory) DeepCopyInto(out *VolumeUsageHistory) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeUsageHistory.
+func (in *VolumeUsageHistory) DeepCopy() *VolumeUsageHistory {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeUsageHistory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeUsageHistory) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeUsageHistoryList) DeepCopyInto(out *VolumeUsageHistoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VolumeUsageHistory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeUsageHistoryList.
+func (in *VolumeUsageHistoryList) DeepCopy() *VolumeUsageHistoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeUsageHistoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeUsageHistoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeUsageHistorySpec) DeepCopyInto(out *VolumeUsageHistorySpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeUsageHistorySpec.
+func (in *VolumeUsageHistorySpec) DeepCopy() *VolumeUsageHistorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeUsageHistorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeUsageHistoryStatus) DeepCopyInto(out *VolumeUsageHistoryStatus) {
+	*out = *in
+	if in.Minutes != nil {
+		in, out := &in.Minutes, &out.Minutes
+		*out = make([]UsageRollup, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hours != nil {
+		in, out := &in.Hours, &out.Hours
+		*out = make([]UsageRollup, len(*in))
+		copy(*out, *in)
+	}
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]UsageRollup, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeUsageHistoryStatus.
+func (in *VolumeUsageHistoryStatus) DeepCopy() *VolumeUsageHistoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeUsageHistoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadReference) DeepCopyInto(out *WorkloadReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadReference.
+func (in *WorkloadReference) DeepCopy() *WorkloadReference {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadReference)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,327 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+// V1alpha1SpecAnnotation holds the original v1alpha1 spec of an object whose
+// spec cannot be represented exactly in v1beta1 (for example a cooldownPeriod
+// of "600s" or an unparsable size). Converting back to v1alpha1 restores it as
+// long as the v1beta1 spec was not changed in between.
+const V1alpha1SpecAnnotation = "autoscaling.storage.k8s.io/v1alpha1-spec"
+
+// ConvertFromV1alpha1 converts a v1alpha1 VolumeScaler into dst. Values that
+// cannot be parsed are left at their zero value and the original spec is kept
+// in the V1alpha1SpecAnnotation annotation.
+func ConvertFromV1alpha1(src *v1alpha1.VolumeScaler, dst *VolumeScaler) error {
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	delete(dst.Annotations, V1alpha1SpecAnnotation)
+	dst.Spec = specFromV1alpha1(&src.Spec)
+	dst.Status = VolumeScalerStatus(*src.Status.DeepCopy())
+
+	roundTrip := specToV1alpha1(&dst.Spec)
+	if !equality.Semantic.DeepEqual(roundTrip, src.Spec) {
+		raw, err := json.Marshal(src.Spec)
+		if err != nil {
+			return fmt.Errorf("encoding v1alpha1 spec of '%s/%s': %v", src.Namespace, src.Name, err)
+		}
+		if dst.Annotations == nil {
+			dst.Annotations = make(map[string]string)
+		}
+		dst.Annotations[V1alpha1SpecAnnotation] = string(raw)
+	}
+	if len(dst.Annotations) == 0 {
+		dst.Annotations = nil
+	}
+	return nil
+}
+
+// ConvertToV1alpha1 converts src into a v1alpha1 VolumeScaler, restoring the
+// spec saved by ConvertFromV1alpha1 when the v1beta1 spec still matches it.
+func ConvertToV1alpha1(src *VolumeScaler, dst *v1alpha1.VolumeScaler) error {
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	delete(dst.Annotations, V1alpha1SpecAnnotation)
+	if len(dst.Annotations) == 0 {
+		dst.Annotations = nil
+	}
+	dst.Spec = specToV1alpha1(&src.Spec)
+	dst.Status = v1alpha1.VolumeScalerStatus(*src.Status.DeepCopy())
+
+	if raw, ok := src.Annotations[V1alpha1SpecAnnotation]; ok {
+		saved := v1alpha1.VolumeScalerSpec{}
+		if err := json.Unmarshal([]byte(raw), &saved); err != nil {
+			return fmt.Errorf("decoding %s on '%s/%s': %v", V1alpha1SpecAnnotation, src.Namespace, src.Name, err)
+		}
+		if equality.Semantic.DeepEqual(specFromV1alpha1(&saved), src.Spec) {
+			dst.Spec = saved
+		}
+	}
+	return nil
+}
+
+// specFromV1alpha1 maps the string fields of a v1alpha1 spec onto v1beta1.
+func specFromV1alpha1(in *v1alpha1.VolumeScalerSpec) VolumeScalerSpec {
+	out := VolumeScalerSpec{
+		PVCName:          in.PVCName,
+		ThresholdPercent: parsePercent(in.Threshold),
+		Priority:         in.Priority,
+		Mode:             ScalerMode(in.Mode),
+		Suspend:          in.Suspend,
+	}
+
+	switch strings.ToLower(strings.TrimSpace(in.ScaleType)) {
+	case "fixed":
+		out.Scale.Policy = ScalePolicyFixed
+	case "":
+		out.Scale.Policy = ScalePolicyFixed
+		if strings.HasSuffix(strings.TrimSpace(in.Scale), "%") {
+			out.Scale.Policy = ScalePolicyPercentage
+		}
+	default:
+		// "percentage", the legacy "VolumeScaler" and anything unknown are
+		// treated as percentages, as the controller does.
+		out.Scale.Policy = ScalePolicyPercentage
+	}
+	if out.Scale.Policy == ScalePolicyFixed {
+		if q, err := resource.ParseQuantity(in.Scale); err == nil {
+			out.Scale.Increment = &q
+		}
+	} else {
+		out.Scale.Percent = parsePercent(in.Scale)
+	}
+
+	if d, err := time.ParseDuration(in.CooldownPeriod); err == nil {
+		out.CooldownPeriod = metav1.Duration{Duration: d}
+	}
+	if q, err := resource.ParseQuantity(in.MaxSize); err == nil {
+		out.MaxSize = q
+	}
+	if in.ApprovalPolicy != nil {
+		out.ApprovalPolicy = &ApprovalPolicy{}
+		if q, err := resource.ParseQuantity(in.ApprovalPolicy.SizeAbove); err == nil {
+			out.ApprovalPolicy.SizeAbove = &q
+		}
+		if q, err := resource.ParseQuantity(in.ApprovalPolicy.IncrementAbove); err == nil {
+			out.ApprovalPolicy.IncrementAbove = &q
+		}
+		if d, err := time.ParseDuration(in.ApprovalPolicy.Expiry); err == nil {
+			out.ApprovalPolicy.Expiry = &metav1.Duration{Duration: d}
+		}
+	}
+	for _, s := range in.Schedules {
+		schedule := ScaleSchedule{Name: s.Name, Schedule: s.Schedule}
+		if q, err := resource.ParseQuantity(s.MinSize); err == nil {
+			schedule.MinSize = q
+		}
+		out.Schedules = append(out.Schedules, schedule)
+	}
+	for _, w := range in.BlackoutWindows {
+		window := BlackoutWindow{Name: w.Name, Start: w.Start}
+		if d, err := time.ParseDuration(w.Duration); err == nil {
+			window.Duration = metav1.Duration{Duration: d}
+		}
+		out.BlackoutWindows = append(out.BlackoutWindows, window)
+	}
+	if in.CriticalThreshold != "" {
+		out.CriticalThresholdPercent = parsePercent(in.CriticalThreshold)
+	}
+	if in.CriticalScale != "" {
+		out.CriticalScale = &VolumeScalerScale{Policy: ScalePolicyFixed}
+		if strings.HasSuffix(strings.TrimSpace(in.CriticalScale), "%") {
+			out.CriticalScale.Policy = ScalePolicyPercentage
+			out.CriticalScale.Percent = parsePercent(in.CriticalScale)
+		} else if q, err := resource.ParseQuantity(in.CriticalScale); err == nil {
+			out.CriticalScale.Increment = &q
+		}
+	}
+	if d, err := time.ParseDuration(in.BreachDuration); err == nil {
+		out.BreachDuration = &metav1.Duration{Duration: d}
+	}
+	if in.ResetThreshold != "" {
+		out.ResetThresholdPercent = parsePercent(in.ResetThreshold)
+	}
+	if in.GrowthAnomaly != nil {
+		out.GrowthAnomaly = &GrowthAnomalyPolicy{BaselineMultiple: in.GrowthAnomaly.BaselineMultiple}
+		if q, err := resource.ParseQuantity(in.GrowthAnomaly.MaxGrowthPerHour); err == nil {
+			out.GrowthAnomaly.MaxGrowthPerHour = &q
+		}
+	}
+	if in.GrowthBudget != nil {
+		out.GrowthBudget = &GrowthBudget{MaxExpansionsPerDay: in.GrowthBudget.MaxExpansionsPerDay}
+		if q, err := resource.ParseQuantity(in.GrowthBudget.MaxGrowthPerDay); err == nil {
+			out.GrowthBudget.MaxGrowthPerDay = &q
+		}
+	}
+	if in.SnapshotBeforeResize != nil {
+		out.SnapshotBeforeResize = &SnapshotPolicy{
+			VolumeSnapshotClassName: in.SnapshotBeforeResize.VolumeSnapshotClassName,
+			Retain:                  in.SnapshotBeforeResize.Retain,
+		}
+		if d, err := time.ParseDuration(in.SnapshotBeforeResize.ReadyTimeout); err == nil {
+			out.SnapshotBeforeResize.ReadyTimeout = &metav1.Duration{Duration: d}
+		}
+		if d, err := time.ParseDuration(in.SnapshotBeforeResize.MaxAge); err == nil {
+			out.SnapshotBeforeResize.MaxAge = &metav1.Duration{Duration: d}
+		}
+	}
+	if in.Shrink != nil {
+		out.Shrink = &ShrinkPolicy{
+			BelowUsagePercent: parsePercent(in.Shrink.BelowUsage),
+			Workload:          in.Shrink.Workload,
+			CopyImage:         in.Shrink.CopyImage,
+		}
+		if d, err := time.ParseDuration(in.Shrink.SustainedFor); err == nil {
+			out.Shrink.SustainedFor = metav1.Duration{Duration: d}
+		}
+		if in.Shrink.TargetUsage != "" {
+			out.Shrink.TargetUsagePercent = parsePercent(in.Shrink.TargetUsage)
+		}
+		if q, err := resource.ParseQuantity(in.Shrink.MinSize); err == nil {
+			out.Shrink.MinSize = &q
+		}
+		if w := in.Shrink.MaintenanceWindow; w != nil {
+			out.Shrink.MaintenanceWindow = &BlackoutWindow{Name: w.Name, Start: w.Start}
+			if d, err := time.ParseDuration(w.Duration); err == nil {
+				out.Shrink.MaintenanceWindow.Duration = metav1.Duration{Duration: d}
+			}
+		}
+	}
+	return out
+}
+
+// specToV1alpha1 renders a v1beta1 spec with the string formats of v1alpha1.
+func specToV1alpha1(in *VolumeScalerSpec) v1alpha1.VolumeScalerSpec {
+	out := v1alpha1.VolumeScalerSpec{
+		PVCName:   in.PVCName,
+		Threshold: fmt.Sprintf("%d%%", in.ThresholdPercent),
+		MaxSize:   in.MaxSize.String(),
+		Priority:  in.Priority,
+		Mode:      string(in.Mode),
+		Suspend:   in.Suspend,
+	}
+	switch in.Scale.Policy {
+	case ScalePolicyFixed:
+		out.ScaleType = "fixed"
+		if in.Scale.Increment != nil {
+			out.Scale = in.Scale.Increment.String()
+		}
+	default:
+		out.ScaleType = "percentage"
+		out.Scale = fmt.Sprintf("%d%%", in.Scale.Percent)
+	}
+	if in.CooldownPeriod.Duration > 0 {
+		out.CooldownPeriod = formatDuration(in.CooldownPeriod.Duration)
+	}
+	if in.ApprovalPolicy != nil {
+		out.ApprovalPolicy = &v1alpha1.ApprovalPolicy{}
+		if in.ApprovalPolicy.SizeAbove != nil {
+			out.ApprovalPolicy.SizeAbove = in.ApprovalPolicy.SizeAbove.String()
+		}
+		if in.ApprovalPolicy.IncrementAbove != nil {
+			out.ApprovalPolicy.IncrementAbove = in.ApprovalPolicy.IncrementAbove.String()
+		}
+		if in.ApprovalPolicy.Expiry != nil {
+			out.ApprovalPolicy.Expiry = formatDuration(in.ApprovalPolicy.Expiry.Duration)
+		}
+	}
+	for _, s := range in.Schedules {
+		out.Schedules = append(out.Schedules, v1alpha1.ScaleSchedule{Name: s.Name, Schedule: s.Schedule, MinSize: s.MinSize.String()})
+	}
+	for _, w := range in.BlackoutWindows {
+		out.BlackoutWindows = append(out.BlackoutWindows, v1alpha1.BlackoutWindow{Name: w.Name, Start: w.Start, Duration: formatDuration(w.Duration.Duration)})
+	}
+	if in.CriticalThresholdPercent > 0 {
+		out.CriticalThreshold = fmt.Sprintf("%d%%", in.CriticalThresholdPercent)
+	}
+	if in.CriticalScale != nil {
+		switch in.CriticalScale.Policy {
+		case ScalePolicyFixed:
+			if in.CriticalScale.Increment != nil {
+				out.CriticalScale = in.CriticalScale.Increment.String()
+			}
+		default:
+			out.CriticalScale = fmt.Sprintf("%d%%", in.CriticalScale.Percent)
+		}
+	}
+	if in.BreachDuration != nil {
+		out.BreachDuration = formatDuration(in.BreachDuration.Duration)
+	}
+	if in.ResetThresholdPercent > 0 {
+		out.ResetThreshold = fmt.Sprintf("%d%%", in.ResetThresholdPercent)
+	}
+	if in.GrowthAnomaly != nil {
+		out.GrowthAnomaly = &v1alpha1.GrowthAnomalyPolicy{BaselineMultiple: in.GrowthAnomaly.BaselineMultiple}
+		if in.GrowthAnomaly.MaxGrowthPerHour != nil {
+			out.GrowthAnomaly.MaxGrowthPerHour = in.GrowthAnomaly.MaxGrowthPerHour.String()
+		}
+	}
+	if in.GrowthBudget != nil {
+		out.GrowthBudget = &v1alpha1.GrowthBudget{MaxExpansionsPerDay: in.GrowthBudget.MaxExpansionsPerDay}
+		if in.GrowthBudget.MaxGrowthPerDay != nil {
+			out.GrowthBudget.MaxGrowthPerDay = in.GrowthBudget.MaxGrowthPerDay.String()
+		}
+	}
+	if in.SnapshotBeforeResize != nil {
+		out.SnapshotBeforeResize = &v1alpha1.SnapshotPolicy{
+			VolumeSnapshotClassName: in.SnapshotBeforeResize.VolumeSnapshotClassName,
+			Retain:                  in.SnapshotBeforeResize.Retain,
+		}
+		if in.SnapshotBeforeResize.ReadyTimeout != nil {
+			out.SnapshotBeforeResize.ReadyTimeout = formatDuration(in.SnapshotBeforeResize.ReadyTimeout.Duration)
+		}
+		if in.SnapshotBeforeResize.MaxAge != nil {
+			out.SnapshotBeforeResize.MaxAge = formatDuration(in.SnapshotBeforeResize.MaxAge.Duration)
+		}
+	}
+	if in.Shrink != nil {
+		out.Shrink = &v1alpha1.ShrinkPolicy{
+			BelowUsage:   fmt.Sprintf("%d%%", in.Shrink.BelowUsagePercent),
+			SustainedFor: formatDuration(in.Shrink.SustainedFor.Duration),
+			Workload:     in.Shrink.Workload,
+			CopyImage:    in.Shrink.CopyImage,
+		}
+		if in.Shrink.TargetUsagePercent > 0 {
+			out.Shrink.TargetUsage = fmt.Sprintf("%d%%", in.Shrink.TargetUsagePercent)
+		}
+		if in.Shrink.MinSize != nil {
+			out.Shrink.MinSize = in.Shrink.MinSize.String()
+		}
+		if w := in.Shrink.MaintenanceWindow; w != nil {
+			out.Shrink.MaintenanceWindow = &v1alpha1.BlackoutWindow{Name: w.Name, Start: w.Start, Duration: formatDuration(w.Duration.Duration)}
+		}
+	}
+	return out
+}
+
+// parsePercent parses "70%" or "70" into 70. Fractions are rounded and
+// unparsable values yield 0.
+func parsePercent(s string) int32 {
+	f, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64)
+	if err != nil {
+		return 0
+	}
+	return int32(math.Round(f))
+}
+
+// formatDuration prints d without trailing zero units, e.g. "10m" rather than "10m0s".
+func formatDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
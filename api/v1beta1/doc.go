@@ -0,0 +1,8 @@
+// Package v1beta1 contains the v1beta1 API of the autoscaling.storage.k8s.io
+// group. It replaces the string-typed fields of v1alpha1 with integer
+// percentages, resource quantities, durations and a typed scale policy.
+//
+// +k8s:deepcopy-gen=package
+// +groupName=autoscaling.storage.k8s.io
+// +groupGoName=Autoscaling
+package v1beta1
@@ -0,0 +1,213 @@
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+// ScalePolicy selects how the increment of an expansion is computed.
+type ScalePolicy string
+
+const (
+	// ScalePolicyFixed grows the PVC by Scale.Increment on every expansion.
+	ScalePolicyFixed ScalePolicy = "Fixed"
+	// ScalePolicyPercentage grows the PVC by Scale.Percent of its current size.
+	ScalePolicyPercentage ScalePolicy = "Percentage"
+)
+
+// ScalerMode decides whether the controller acts on its scaling decisions.
+type ScalerMode string
+
+const (
+	// ScalerModeObserve only reports usage.
+	ScalerModeObserve ScalerMode = "Observe"
+	// ScalerModeRecommend records the expansion the controller would perform
+	// without patching the PVC.
+	ScalerModeRecommend ScalerMode = "Recommend"
+	// ScalerModeEnforce expands the PVC. It is the default.
+	ScalerModeEnforce ScalerMode = "Enforce"
+)
+
+// VolumeScalerScale describes the size of each expansion.
+type VolumeScalerScale struct {
+	Policy    ScalePolicy        `json:"policy"`
+	Increment *resource.Quantity `json:"increment,omitempty"` // set for the Fixed policy, e.g. 2Gi
+	Percent   int32              `json:"percent,omitempty"`   // set for the Percentage policy, e.g. 30
+}
+
+// ApprovalPolicy lists the expansions that must be approved by a person
+// through a VolumeScaleRequest before the PVC is patched.
+type ApprovalPolicy struct {
+	SizeAbove      *resource.Quantity `json:"sizeAbove,omitempty"`      // expansions to a size above this, e.g. 1Ti
+	IncrementAbove *resource.Quantity `json:"incrementAbove,omitempty"` // expansions growing the PVC by more than this, e.g. 500Gi
+	Expiry         *metav1.Duration   `json:"expiry,omitempty"`         // how long a request waits for approval, e.g. 24h
+}
+
+// ScaleSchedule raises the PVC to MinSize whenever Schedule fires.
+type ScaleSchedule struct {
+	Name     string            `json:"name,omitempty"`
+	Schedule string            `json:"schedule"` // cron expression, e.g. "0 0 28 * *"
+	MinSize  resource.Quantity `json:"minSize"`  // e.g. 800Gi
+}
+
+// BlackoutWindow defers threshold-triggered expansions for Duration after
+// each time Start fires.
+type BlackoutWindow struct {
+	Name     string          `json:"name,omitempty"`
+	Start    string          `json:"start"`    // cron expression, e.g. "0 8 * * 1"
+	Duration metav1.Duration `json:"duration"` // e.g. 4h
+}
+
+// GrowthAnomalyPolicy holds threshold-triggered expansions while usage grows
+// unusually fast, e.g. because an application writes runaway logs.
+type GrowthAnomalyPolicy struct {
+	MaxGrowthPerHour *resource.Quantity `json:"maxGrowthPerHour,omitempty"` // absolute rate limit, e.g. 20Gi
+	BaselineMultiple int32              `json:"baselineMultiple,omitempty"` // times the usual growth rate, e.g. 5
+}
+
+// GrowthBudget caps how often and how much the volume may grow in any 24 hours.
+type GrowthBudget struct {
+	MaxExpansionsPerDay int32              `json:"maxExpansionsPerDay,omitempty"` // e.g. 4
+	MaxGrowthPerDay     *resource.Quantity `json:"maxGrowthPerDay,omitempty"`     // e.g. 200Gi
+}
+
+// SnapshotPolicy takes a VolumeSnapshot of the PVC before every expansion.
+type SnapshotPolicy struct {
+	VolumeSnapshotClassName string           `json:"volumeSnapshotClassName"`
+	ReadyTimeout            *metav1.Duration `json:"readyTimeout,omitempty"` // how long to wait for readyToUse, default 10m
+	MaxAge                  *metav1.Duration `json:"maxAge,omitempty"`       // a ready snapshot older than this is retaken, default 30m
+	Retain                  int32            `json:"retain,omitempty"`       // snapshots kept per PVC, default 3
+}
+
+// WorkloadReference names the Deployment or StatefulSet that mounts a PVC.
+type WorkloadReference = v1alpha1.WorkloadReference
+
+// ShrinkPolicy migrates a PVC that stays mostly empty to a smaller one,
+// copying the data while the workload is scaled to zero.
+type ShrinkPolicy struct {
+	BelowUsagePercent  int32              `json:"belowUsagePercent"`            // e.g. 20
+	SustainedFor       metav1.Duration    `json:"sustainedFor"`                 // e.g. 72h
+	TargetUsagePercent int32              `json:"targetUsagePercent,omitempty"` // usage after the shrink, default 60
+	MinSize            *resource.Quantity `json:"minSize,omitempty"`            // e.g. 50Gi
+	Workload           WorkloadReference  `json:"workload"`
+	// MaintenanceWindow lets the controller scale the workload to zero when
+	// it opens; without one the shrink waits for the workload to be scaled
+	// to zero by someone else
+	MaintenanceWindow *BlackoutWindow `json:"maintenanceWindow,omitempty"`
+	CopyImage         string          `json:"copyImage,omitempty"` // image with a POSIX cp, default busybox:1.36
+}
+
+// ShrinkStatus tracks a shrink migration through its phases. It is the
+// v1alpha1 type so the status converts unchanged.
+type ShrinkStatus = v1alpha1.ShrinkStatus
+
+// ExpansionRecord is one entry of the expansion ledger. It is the v1alpha1
+// type so the status converts unchanged.
+type ExpansionRecord = v1alpha1.ExpansionRecord
+
+// CostStatus estimates what the PVC costs under the controller's price table.
+// It is the v1alpha1 type so the status converts unchanged.
+type CostStatus = v1alpha1.CostStatus
+
+// VolumeScalerSpec defines the desired state of VolumeScaler
+type VolumeScalerSpec struct {
+	PVCName          string            `json:"pvcName"`
+	ThresholdPercent int32             `json:"thresholdPercent"` // e.g. 70
+	Scale            VolumeScalerScale `json:"scale"`
+	CooldownPeriod   metav1.Duration   `json:"cooldownPeriod,omitempty"` // e.g. 10m
+	MaxSize          resource.Quantity `json:"maxSize"`                  // e.g. 15Gi
+	Priority         int32             `json:"priority,omitempty"`       // breaks ties when several VolumeScalers target one PVC
+	Mode             ScalerMode        `json:"mode,omitempty"`           // defaults to Enforce
+	Suspend          bool              `json:"suspend,omitempty"`        // pauses scaling; usage is still reported
+
+	ApprovalPolicy *ApprovalPolicy `json:"approvalPolicy,omitempty"`
+
+	Schedules                []ScaleSchedule    `json:"schedules,omitempty"`
+	BlackoutWindows          []BlackoutWindow   `json:"blackoutWindows,omitempty"`
+	CriticalThresholdPercent int32              `json:"criticalThresholdPercent,omitempty"` // e.g. 95; skips the cooldown and blackout windows
+	CriticalScale            *VolumeScalerScale `json:"criticalScale,omitempty"`            // increment above CriticalThresholdPercent
+
+	BreachDuration        *metav1.Duration `json:"breachDuration,omitempty"`        // how long usage must stay above the threshold, e.g. 10m
+	ResetThresholdPercent int32            `json:"resetThresholdPercent,omitempty"` // usage must drop below this to end a breach, e.g. 65
+
+	GrowthAnomaly *GrowthAnomalyPolicy `json:"growthAnomaly,omitempty"`
+	GrowthBudget  *GrowthBudget        `json:"growthBudget,omitempty"`
+
+	SnapshotBeforeResize *SnapshotPolicy `json:"snapshotBeforeResize,omitempty"`
+
+	Shrink *ShrinkPolicy `json:"shrink,omitempty"`
+}
+
+// VolumeScalerStatus defines the observed state of VolumeScaler. It is shared
+// with v1alpha1 field for field so status written by either version survives
+// conversion unchanged.
+type VolumeScalerStatus struct {
+	ScaledAt            string `json:"scaledAt,omitempty"`
+	ReachedMaxSize      bool   `json:"reachedMaxSize,omitempty"`
+	ResizeInProgress    bool   `json:"resizeInProgress,omitempty"`
+	LastRequestedSize   string `json:"lastRequestedSize,omitempty"`
+	CurrentUsagePercent int    `json:"currentUsagePercent,omitempty"`
+	CurrentUsedGi       string `json:"currentUsedGi,omitempty"`
+	CurrentSizeGi       string `json:"currentSizeGi,omitempty"`
+	LimitClamp          string `json:"limitClamp,omitempty"` // guardrails applied to the last expansion
+
+	// Expansion proposed in Recommend mode
+	RecommendedSize      string `json:"recommendedSize,omitempty"`
+	RecommendationReason string `json:"recommendationReason,omitempty"`
+	RecommendedAt        string `json:"recommendedAt,omitempty"`
+
+	// Schedules and blackout windows
+	LastScheduleTime string `json:"lastScheduleTime,omitempty"`
+	NextScheduleTime string `json:"nextScheduleTime,omitempty"`
+	NextScheduleSize string `json:"nextScheduleSize,omitempty"`
+	BlackoutUntil    string `json:"blackoutUntil,omitempty"`    // set while a blackout window is open
+	NextBlackoutTime string `json:"nextBlackoutTime,omitempty"` // start of the next blackout window
+
+	BreachingSince string `json:"breachingSince,omitempty"` // set while usage is above threshold
+
+	// Growth rate between the last two samples and its long-running average
+	GrowthRatePerHour     string `json:"growthRatePerHour,omitempty"`
+	BaselineGrowthPerHour string `json:"baselineGrowthPerHour,omitempty"`
+
+	// Expansions of the last 24 hours, oldest first, for growth budgets
+	ExpansionLedger []ExpansionRecord `json:"expansionLedger,omitempty"`
+
+	// Set while an expansion waits for a cluster-wide resize slot
+	QueuePosition  int32  `json:"queuePosition,omitempty"`
+	QueuedSince    string `json:"queuedSince,omitempty"`
+	QueueHeartbeat string `json:"queueHeartbeat,omitempty"` // refreshed every loop the volume waits
+
+	// Pre-expansion VolumeSnapshots: the one being taken, and those kept, oldest first
+	PendingSnapshot string   `json:"pendingSnapshot,omitempty"`
+	Snapshots       []string `json:"snapshots,omitempty"`
+
+	Shrink *ShrinkStatus `json:"shrink,omitempty"`
+
+	Cost *CostStatus `json:"cost,omitempty"`
+
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VolumeScaler is the Schema for the volumescalers API
+type VolumeScaler struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VolumeScalerSpec   `json:"spec"`
+	Status VolumeScalerStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VolumeScalerList contains a list of VolumeScaler
+type VolumeScalerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []VolumeScaler `json:"items"`
+}
@@ -0,0 +1,364 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+// Code generated by deepcopy-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1alpha1 "github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApprovalPolicy) DeepCopyInto(out *ApprovalPolicy) {
+	*out = *in
+	if in.SizeAbove != nil {
+		in, out := &in.SizeAbove, &out.SizeAbove
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.IncrementAbove != nil {
+		in, out := &in.IncrementAbove, &out.IncrementAbove
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Expiry != nil {
+		in, out := &in.Expiry, &out.Expiry
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApprovalPolicy.
+func (in *ApprovalPolicy) DeepCopy() *ApprovalPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ApprovalPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackoutWindow) DeepCopyInto(out *BlackoutWindow) {
+	*out = *in
+	out.Duration = in.Duration
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackoutWindow.
+func (in *BlackoutWindow) DeepCopy() *BlackoutWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackoutWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthAnomalyPolicy) DeepCopyInto(out *GrowthAnomalyPolicy) {
+	*out = *in
+	if in.MaxGrowthPerHour != nil {
+		in, out := &in.MaxGrowthPerHour, &out.MaxGrowthPerHour
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthAnomalyPolicy.
+func (in *GrowthAnomalyPolicy) DeepCopy() *GrowthAnomalyPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthAnomalyPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthBudget) DeepCopyInto(out *GrowthBudget) {
+	*out = *in
+	if in.MaxGrowthPerDay != nil {
+		in, out := &in.MaxGrowthPerDay, &out.MaxGrowthPerDay
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthBudget.
+func (in *GrowthBudget) DeepCopy() *GrowthBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleSchedule) DeepCopyInto(out *ScaleSchedule) {
+	*out = *in
+	out.MinSize = in.MinSize.DeepCopy()
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleSchedule.
+func (in *ScaleSchedule) DeepCopy() *ScaleSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShrinkPolicy) DeepCopyInto(out *ShrinkPolicy) {
+	*out = *in
+	out.SustainedFor = in.SustainedFor
+	if in.MinSize != nil {
+		in, out := &in.MinSize, &out.MinSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	out.Workload = in.Workload
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(BlackoutWindow)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShrinkPolicy.
+func (in *ShrinkPolicy) DeepCopy() *ShrinkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ShrinkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotPolicy) DeepCopyInto(out *SnapshotPolicy) {
+	*out = *in
+	if in.ReadyTimeout != nil {
+		in, out := &in.ReadyTimeout, &out.ReadyTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotPolicy.
+func (in *SnapshotPolicy) DeepCopy() *SnapshotPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeScaler) DeepCopyInto(out *VolumeScaler) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeScaler.
+func (in *VolumeScaler) DeepCopy() *VolumeScaler {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeScaler)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeScaler) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeScalerList) DeepCopyInto(out *VolumeScalerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VolumeScaler, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeScalerList.
+func (in *VolumeScalerList) DeepCopy() *VolumeScalerList {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeScalerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumeScalerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeScalerScale) DeepCopyInto(out *VolumeScalerScale) {
+	*out = *in
+	if in.Increment != nil {
+		in, out := &in.Increment, &out.Increment
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeScalerScale.
+func (in *VolumeScalerScale) DeepCopy() *VolumeScalerScale {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeScalerScale)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeScalerSpec) DeepCopyInto(out *VolumeScalerSpec) {
+	*out = *in
+	in.Scale.DeepCopyInto(&out.Scale)
+	out.CooldownPeriod = in.CooldownPeriod
+	out.MaxSize = in.MaxSize.DeepCopy()
+	if in.ApprovalPolicy != nil {
+		in, out := &in.ApprovalPolicy, &out.ApprovalPolicy
+		*out = new(ApprovalPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]ScaleSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BlackoutWindows != nil {
+		in, out := &in.BlackoutWindows, &out.BlackoutWindows
+		*out = make([]BlackoutWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.CriticalScale != nil {
+		in, out := &in.CriticalScale, &out.CriticalScale
+		*out = new(VolumeScalerScale)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BreachDuration != nil {
+		in, out := &in.BreachDuration, &out.BreachDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.GrowthAnomaly != nil {
+		in, out := &in.GrowthAnomaly, &out.GrowthAnomaly
+		*out = new(GrowthAnomalyPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GrowthBudget != nil {
+		in, out := &in.GrowthBudget, &out.GrowthBudget
+		*out = new(GrowthBudget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SnapshotBeforeResize != nil {
+		in, out := &in.SnapshotBeforeResize, &out.SnapshotBeforeResize
+		*out = new(SnapshotPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Shrink != nil {
+		in, out := &in.Shrink, &out.Shrink
+		*out = new(ShrinkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeScalerSpec.
+func (in *VolumeScalerSpec) DeepCopy() *VolumeScalerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeScalerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeScalerStatus) DeepCopyInto(out *VolumeScalerStatus) {
+	*out = *in
+	if in.ExpansionLedger != nil {
+		in, out := &in.ExpansionLedger, &out.ExpansionLedger
+		*out = make([]v1alpha1.ExpansionRecord, len(*in))
+		copy(*out, *in)
+	}
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Shrink != nil {
+		in, out := &in.Shrink, &out.Shrink
+		*out = new(v1alpha1.ShrinkStatus)
+		**out = **in
+	}
+	if in.Cost != nil {
+		in, out := &in.Cost, &out.Cost
+		*out = new(v1alpha1.CostStatus)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeScalerStatus.
+func (in *VolumeScalerStatus) DeepCopy() *VolumeScalerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeScalerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
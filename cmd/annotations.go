@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+const (
+	// PVC annotations that enable scaling without a separate VolumeScaler object
+	annotationPrefix    = "volumescaler.io/"
+	annotationThreshold = annotationPrefix + "threshold"
+	annotationScale     = annotationPrefix + "scale"
+	annotationScaleType = annotationPrefix + "scale-type"
+	annotationMaxSize   = annotationPrefix + "max-size"
+	annotationCooldown  = annotationPrefix + "cooldown"
+
+	// labelSource marks VolumeScalers that the controller created from PVC annotations
+	labelSource            = annotationPrefix + "source"
+	labelSourceAnnotations = "pvc-annotations"
+)
+
+// hasScalingAnnotations reports whether the PVC opted into annotation-driven scaling.
+func hasScalingAnnotations(pvc *corev1.PersistentVolumeClaim) bool {
+	for key := range pvc.Annotations {
+		switch key {
+		case annotationThreshold, annotationScale, annotationScaleType, annotationMaxSize, annotationCooldown:
+			return true
+		}
+	}
+	return false
+}
+
+// specFromAnnotations builds a VolumeScalerSpec from the volumescaler.io/*
+// annotations on a PVC. The threshold, scale and max-size annotations are
+// required. The spec is defaulted as the mutating webhook would, so it can be
+// compared with a VolumeScaler the webhook already defaulted.
+func specFromAnnotations(pvc *corev1.PersistentVolumeClaim) (*v1alpha1.VolumeScalerSpec, error) {
+	ann := pvc.Annotations
+	spec := &v1alpha1.VolumeScalerSpec{
+		PVCName:        pvc.Name,
+		Threshold:      strings.TrimSpace(ann[annotationThreshold]),
+		Scale:          strings.TrimSpace(ann[annotationScale]),
+		ScaleType:      strings.TrimSpace(ann[annotationScaleType]),
+		CooldownPeriod: strings.TrimSpace(ann[annotationCooldown]),
+		MaxSize:        strings.TrimSpace(ann[annotationMaxSize]),
+	}
+
+	var missing []string
+	if spec.Threshold == "" {
+		missing = append(missing, annotationThreshold)
+	}
+	if spec.Scale == "" {
+		missing = append(missing, annotationScale)
+	}
+	if spec.MaxSize == "" {
+		missing = append(missing, annotationMaxSize)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required annotations: %s", strings.Join(missing, ", "))
+	}
+
+	defaultSpec(spec)
+	return spec, nil
+}
+
+// isAnnotationManaged reports whether the VolumeScaler was created from PVC annotations.
+func isAnnotationManaged(vsObj *v1alpha1.VolumeScaler) bool {
+	return vsObj.Labels[labelSource] == labelSourceAnnotations
+}
+
+// ensureAnnotationScaler creates a VolumeScaler owned by the PVC when the PVC
+// carries scaling annotations. It returns a nil VolumeScaler when the PVC has
+// not opted in, so callers can skip it.
+func (c *VolumeScalerController) ensureAnnotationScaler(ctx context.Context, pvc *corev1.PersistentVolumeClaim) (*v1alpha1.VolumeScaler, types.NamespacedName, error) {
+	vsName := types.NamespacedName{Namespace: pvc.Namespace, Name: pvc.Name}
+	if !hasScalingAnnotations(pvc) {
+		return nil, vsName, nil
+	}
+
+	spec, err := specFromAnnotations(pvc)
+	if err != nil {
+		return nil, vsName, fmt.Errorf("PVC '%s/%s' annotations: %v", pvc.Namespace, pvc.Name, err)
+	}
+
+	vsObj := &v1alpha1.VolumeScaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      vsName.Name,
+			Namespace: vsName.Namespace,
+			Labels:    map[string]string{labelSource: labelSourceAnnotations},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "v1",
+				Kind:       "PersistentVolumeClaim",
+				Name:       pvc.Name,
+				UID:        pvc.UID,
+			}},
+		},
+		Spec: *spec,
+	}
+
+	created, err := c.vsClient.AutoscalingV1alpha1().VolumeScalers(vsName.Namespace).
+		Create(ctx, vsObj, metav1.CreateOptions{})
+	if err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil, vsName, fmt.Errorf("VolumeScaler '%s/%s' already exists and does not target PVC '%s'",
+				vsName.Namespace, vsName.Name, pvc.Name)
+		}
+		return nil, vsName, fmt.Errorf("creating VolumeScaler from annotations: %v", err)
+	}
+
+	fmt.Printf("[INFO] Created VolumeScaler '%s/%s' from PVC annotations\n", vsName.Namespace, vsName.Name)
+	return created, vsName, nil
+}
+
+// syncAnnotationScaler keeps an annotation-managed VolumeScaler in step with the
+// PVC annotations. It deletes the VolumeScaler and returns nil once the PVC no
+// longer carries any scaling annotations.
+func (c *VolumeScalerController) syncAnnotationScaler(ctx context.Context, pvc *corev1.PersistentVolumeClaim, vsObj *v1alpha1.VolumeScaler, vsName types.NamespacedName) (*v1alpha1.VolumeScaler, error) {
+	if !hasScalingAnnotations(pvc) {
+		err := c.vsClient.AutoscalingV1alpha1().VolumeScalers(vsName.Namespace).
+			Delete(ctx, vsName.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("deleting VolumeScaler '%s/%s': %v", vsName.Namespace, vsName.Name, err)
+		}
+		fmt.Printf("[INFO] Scaling annotations removed from PVC '%s/%s'; deleted VolumeScaler\n", pvc.Namespace, pvc.Name)
+		return nil, nil
+	}
+
+	spec, err := specFromAnnotations(pvc)
+	if err != nil {
+		return nil, fmt.Errorf("PVC '%s/%s' annotations: %v", pvc.Namespace, pvc.Name, err)
+	}
+	if equality.Semantic.DeepEqual(*spec, vsObj.Spec) {
+		return vsObj, nil
+	}
+
+	specPatch, err := json.Marshal(map[string]interface{}{"spec": spec})
+	if err != nil {
+		return nil, fmt.Errorf("encoding spec patch: %v", err)
+	}
+	_, err = c.vsClient.AutoscalingV1alpha1().VolumeScalers(vsName.Namespace).
+		Patch(ctx, vsName.Name, types.MergePatchType, specPatch, metav1.PatchOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("patching VolumeScaler spec: %v", err)
+	}
+
+	updated := *vsObj
+	updated.Spec = *spec
+	return &updated, nil
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	kfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	vsfake "github.com/zghanem/sample-volumeScaler/pkg/generated/clientset/versioned/fake"
+)
+
+func TestSpecFromAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    *v1alpha1.VolumeScalerSpec
+		wantErr     bool
+	}{
+		{
+			name: "all annotations",
+			annotations: map[string]string{
+				annotationThreshold: "80%",
+				annotationScale:     "2Gi",
+				annotationScaleType: "fixed",
+				annotationMaxSize:   "20Gi",
+				annotationCooldown:  "10m",
+			},
+			expected: &v1alpha1.VolumeScalerSpec{PVCName: "data", Threshold: "80%", Scale: "2Gi", ScaleType: "fixed", CooldownPeriod: "10m", MaxSize: "20Gi"},
+		},
+		{
+			name: "percentage scale type inferred",
+			annotations: map[string]string{
+				annotationThreshold: "70",
+				annotationScale:     "30%",
+				annotationMaxSize:   "50Gi",
+			},
+			expected: &v1alpha1.VolumeScalerSpec{PVCName: "data", Threshold: "70%", Scale: "30%", ScaleType: "percentage", CooldownPeriod: defaultCooldownPeriod, MaxSize: "50Gi"},
+		},
+		{
+			name: "fixed scale type inferred",
+			annotations: map[string]string{
+				annotationThreshold: "70%",
+				annotationScale:     "5Gi",
+				annotationMaxSize:   "50Gi",
+			},
+			expected: &v1alpha1.VolumeScalerSpec{PVCName: "data", Threshold: "70%", Scale: "5Gi", ScaleType: "fixed", CooldownPeriod: defaultCooldownPeriod, MaxSize: "50Gi"},
+		},
+		{
+			name: "missing max size",
+			annotations: map[string]string{
+				annotationThreshold: "70%",
+				annotationScale:     "5Gi",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pvc := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default", Annotations: tt.annotations},
+			}
+			got, err := specFromAnnotations(pvc)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("specFromAnnotations() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(*got, *tt.expected) {
+				t.Errorf("specFromAnnotations() = %+v, want %+v", *got, *tt.expected)
+			}
+		})
+	}
+}
+
+func TestSyncAnnotationScaler_WebhookDefaultedSpec(t *testing.T) {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default", Annotations: map[string]string{
+			annotationThreshold: "70", annotationScale: "2Gi", annotationMaxSize: "10Gi",
+		}},
+	}
+	// The mutating webhook defaults the spec the controller created
+	spec := v1alpha1.VolumeScalerSpec{PVCName: "data", Threshold: "70", Scale: "2Gi", MaxSize: "10Gi"}
+	defaultSpec(&spec)
+	vs := &v1alpha1.VolumeScaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default", Labels: map[string]string{labelSource: labelSourceAnnotations}},
+		Spec:       spec,
+	}
+	vsClient := vsfake.NewSimpleClientset(vs)
+	controller := NewVolumeScalerController(NewDefaultConfig(), kfake.NewSimpleClientset(pvc), vsClient, record.NewFakeRecorder(10))
+	vsName := types.NamespacedName{Namespace: "default", Name: "data"}
+
+	for pass := 1; pass <= 2; pass++ {
+		synced, err := controller.syncAnnotationScaler(context.TODO(), pvc, vs, vsName)
+		if err != nil {
+			t.Fatalf("syncAnnotationScaler() error = %v", err)
+		}
+		if synced.Spec.CooldownPeriod != defaultCooldownPeriod {
+			t.Errorf("pass %d: cooldownPeriod = %q, want %q", pass, synced.Spec.CooldownPeriod, defaultCooldownPeriod)
+		}
+		vs = synced
+	}
+	for _, a := range vsClient.Actions() {
+		if a.GetVerb() == "patch" {
+			t.Errorf("Expected no patch of a VolumeScaler matching its annotations, got %v", a)
+		}
+	}
+}
+
+func TestReconcileLoop_AnnotatedPVC(t *testing.T) {
+	originalFetch := fetchNodePVCUsageFunc
+	defer func() { fetchNodePVCUsageFunc = originalFetch }()
+
+	fetchNodePVCUsageFunc = func(ctx context.Context, clientset kubernetes.Interface, nodeName string) (map[string]*PVCUsageInfo, error) {
+		return map[string]*PVCUsageInfo{
+			"default/annotated-pvc": {UsedBytes: 4 * 1024 * 1024 * 1024, CapacityBytes: 5 * 1024 * 1024 * 1024, UsagePercent: 80, UsedGi: 4.0},
+			"default/plain-pvc":     {UsedBytes: 4 * 1024 * 1024 * 1024, CapacityBytes: 5 * 1024 * 1024 * 1024, UsagePercent: 80, UsedGi: 4.0},
+		}, nil
+	}
+
+	newPVC := func(name string, annotations map[string]string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID("uid-" + name), Annotations: annotations},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")},
+				},
+			},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")},
+			},
+		}
+	}
+
+	annotated := newPVC("annotated-pvc", map[string]string{
+		annotationThreshold: "70%",
+		annotationScale:     "2Gi",
+		annotationMaxSize:   "10Gi",
+	})
+	plain := newPVC("plain-pvc", nil)
+
+	clientset := kfake.NewSimpleClientset(annotated, plain)
+	vsClient := vsfake.NewSimpleClientset()
+
+	controller := NewVolumeScalerController(NewDefaultConfig(), clientset, vsClient, record.NewFakeRecorder(100))
+	t.Setenv("NODE_NAME_ENV", "test-node")
+
+	if err := controller.reconcileLoop(context.Background()); err != nil {
+		t.Fatalf("reconcileLoop() error = %v", err)
+	}
+	var gets, lists int
+	for _, a := range clientset.Actions() {
+		if a.GetResource().Resource != "persistentvolumeclaims" {
+			continue
+		}
+		switch a.GetVerb() {
+		case "get":
+			gets++
+		case "list":
+			lists++
+		}
+	}
+	if gets != 0 || lists != 1 {
+		t.Errorf("Expected unmanaged PVCs fetched with a single List, got %d gets and %d lists", gets, lists)
+	}
+
+	vs, err := vsClient.AutoscalingV1alpha1().VolumeScalers("default").Get(context.Background(), "annotated-pvc", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected VolumeScaler to be created from annotations: %v", err)
+	}
+	if vs.Labels[labelSource] != labelSourceAnnotations {
+		t.Errorf("Expected label %s=%s, got %v", labelSource, labelSourceAnnotations, vs.Labels)
+	}
+	owners := vs.OwnerReferences
+	if len(owners) != 1 || owners[0].Kind != "PersistentVolumeClaim" || owners[0].Name != "annotated-pvc" {
+		t.Errorf("Expected VolumeScaler to be owned by the PVC, got %v", owners)
+	}
+
+	updated, _ := clientset.CoreV1().PersistentVolumeClaims("default").Get(context.Background(), "annotated-pvc", metav1.GetOptions{})
+	if size := updated.Spec.Resources.Requests[corev1.ResourceStorage]; size.String() != "7Gi" {
+		t.Errorf("Expected annotated PVC size to be 7Gi, got %s", size.String())
+	}
+	untouched, _ := clientset.CoreV1().PersistentVolumeClaims("default").Get(context.Background(), "plain-pvc", metav1.GetOptions{})
+	if size := untouched.Spec.Resources.Requests[corev1.ResourceStorage]; size.String() != "5Gi" {
+		t.Errorf("Expected plain PVC to stay at 5Gi, got %s", size.String())
+	}
+	if _, err := vsClient.AutoscalingV1alpha1().VolumeScalers("default").Get(context.Background(), "plain-pvc", metav1.GetOptions{}); err == nil {
+		t.Error("Expected no VolumeScaler for a PVC without annotations")
+	}
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+const (
+	// VolumeScaleRequest phases
+	requestPhasePending  = "Pending"
+	requestPhaseApproved = "Approved"
+	requestPhaseDenied   = "Denied"
+	requestPhaseApplied  = "Applied"
+	requestPhaseExpired  = "Expired"
+
+	// labelVolumeScaler links a VolumeScaleRequest to the VolumeScaler it expands
+	labelVolumeScaler = annotationPrefix + "volumescaler"
+
+	// Annotation approvals. approved-by is written by the admission webhook
+	// after checking that the user may approve VolumeScaleRequests.
+	annotationApproval   = annotationPrefix + "approval"
+	annotationApprovedBy = annotationPrefix + "approved-by"
+
+	// defaultApprovalExpiry is how long a request waits when the policy sets no expiry
+	defaultApprovalExpiry = 24 * time.Hour
+
+	conditionTypeApprovalRequired = "ApprovalRequired"
+
+	// Event reasons
+	eventReasonApprovalRequired = "ApprovalRequired"
+	eventReasonApprovalExpired  = "ApprovalExpired"
+	eventReasonApprovalDenied   = "ApprovalDenied"
+)
+
+// parseApprovalPolicy converts an ApprovalPolicy into Gi thresholds and an
+// expiry. Unset fields are returned as zero.
+func parseApprovalPolicy(p *v1alpha1.ApprovalPolicy) (sizeAboveGi, incrementAboveGi float64, expiry time.Duration, err error) {
+	if p.SizeAbove != "" {
+		if sizeAboveGi, err = convertToGi(p.SizeAbove); err != nil {
+			return 0, 0, 0, fmt.Errorf("approvalPolicy.sizeAbove: %v", err)
+		}
+	}
+	if p.IncrementAbove != "" {
+		if incrementAboveGi, err = convertToGi(p.IncrementAbove); err != nil {
+			return 0, 0, 0, fmt.Errorf("approvalPolicy.incrementAbove: %v", err)
+		}
+	}
+	if p.Expiry != "" {
+		if expiry, err = time.ParseDuration(p.Expiry); err != nil || expiry <= 0 {
+			return 0, 0, 0, fmt.Errorf("approvalPolicy.expiry '%s' is not a positive duration", p.Expiry)
+		}
+	}
+	return sizeAboveGi, incrementAboveGi, expiry, nil
+}
+
+// mergeApprovalPolicy folds p into the effective approval policy, keeping the
+// lowest thresholds and the shortest expiry. from names the policy's owner.
+func (eff *effectiveLimits) mergeApprovalPolicy(p *v1alpha1.ApprovalPolicy, from string) error {
+	sizeAboveGi, incrementAboveGi, expiry, err := parseApprovalPolicy(p)
+	if err != nil {
+		return fmt.Errorf("%s %v", from, err)
+	}
+	if sizeAboveGi > 0 && (eff.ApprovalSizeAboveGi == 0 || sizeAboveGi < eff.ApprovalSizeAboveGi) {
+		eff.ApprovalSizeAboveGi, eff.ApprovalFrom = sizeAboveGi, from
+	}
+	if incrementAboveGi > 0 && (eff.ApprovalIncrementAboveGi == 0 || incrementAboveGi < eff.ApprovalIncrementAboveGi) {
+		eff.ApprovalIncrementAboveGi, eff.ApprovalFrom = incrementAboveGi, from
+	}
+	if expiry > 0 && (eff.ApprovalExpiry == 0 || expiry < eff.ApprovalExpiry) {
+		eff.ApprovalExpiry = expiry
+	}
+	return nil
+}
+
+// approvalReason returns why growing a PVC from currentGi to newSizeGi needs
+// approval, or "" when it does not.
+func approvalReason(limits *effectiveLimits, currentGi, newSizeGi float64) string {
+	var reasons []string
+	if limits.ApprovalSizeAboveGi > 0 && newSizeGi > limits.ApprovalSizeAboveGi {
+		reasons = append(reasons, fmt.Sprintf("new size %.0fGi exceeds %.0fGi", newSizeGi, limits.ApprovalSizeAboveGi))
+	}
+	if limits.ApprovalIncrementAboveGi > 0 && newSizeGi-currentGi > limits.ApprovalIncrementAboveGi {
+		reasons = append(reasons, fmt.Sprintf("increment %.0fGi exceeds %.0fGi", newSizeGi-currentGi, limits.ApprovalIncrementAboveGi))
+	}
+	if len(reasons) > 0 {
+		reasons = []string{strings.Join(reasons, "; ") + " (" + limits.ApprovalFrom + ")"}
+	}
+	if limits.SpendApproval != "" {
+		reasons = append(reasons, limits.SpendApproval)
+	}
+	return strings.Join(reasons, "; ")
+}
+
+// checkApproval decides whether an expansion that needs approval may go ahead.
+// It returns the approved VolumeScaleRequest to apply, or nil when the
+// expansion has to wait. A Pending request is created when none exists.
+func (c *VolumeScalerController) checkApproval(ctx context.Context, invRef *corev1.ObjectReference, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, pvc *corev1.PersistentVolumeClaim, limits *effectiveLimits, currentGi, newSizeGi, maxSizeGi float64, reason string) (*v1alpha1.VolumeScaleRequest, error) {
+	client := c.vsClient.AutoscalingV1alpha1().VolumeScaleRequests(vsName.Namespace)
+	list, err := client.List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{labelVolumeScaler: vsName.Name}).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing VolumeScaleRequests: %v", err)
+	}
+
+	now := time.Now().UTC()
+	var pending, denied *v1alpha1.VolumeScaleRequest
+	for i := range list.Items {
+		req := &list.Items[i]
+		if req.Spec.PVCName != pvc.Name || isOnDemandRequest(req) {
+			continue
+		}
+		if err := c.syncAnnotationDecision(ctx, req); err != nil {
+			return nil, err
+		}
+
+		switch req.Status.Phase {
+		case requestPhaseApproved:
+			approvedGi, err := convertToGi(req.Spec.RequestedSize)
+			if err == nil && approvedGi > currentGi && approvedGi <= maxSizeGi {
+				return req, nil
+			}
+			msg := fmt.Sprintf("Approved size %s no longer applies: PVC is %.0fGi, maxSize %.0fGi", req.Spec.RequestedSize, currentGi, maxSizeGi)
+			if err := c.patchRequestStatus(ctx, req, map[string]interface{}{"phase": requestPhaseExpired, "message": msg}); err != nil {
+				return nil, err
+			}
+		case requestPhasePending, "":
+			if requestExpired(req, now) {
+				msg := fmt.Sprintf("VolumeScaleRequest '%s' for PVC '%s/%s' expired without approval", req.Name, vsName.Namespace, pvc.Name)
+				if err := c.patchRequestStatus(ctx, req, map[string]interface{}{"phase": requestPhaseExpired, "message": "Not approved before expiresAt"}); err != nil {
+					return nil, err
+				}
+				c.recorder.Event(invRef, corev1.EventTypeWarning, eventReasonApprovalExpired, msg)
+				fmt.Printf("[INFO] %s\n", msg)
+				continue
+			}
+			pending = req
+		case requestPhaseDenied:
+			// A denial holds back new requests until the denied one would have expired
+			if !requestExpired(req, now) {
+				denied = req
+			}
+		}
+	}
+
+	switch {
+	case pending != nil:
+		return nil, c.setCondition(ctx, vsName, vsObj, metav1.Condition{
+			Type:    conditionTypeApprovalRequired,
+			Status:  metav1.ConditionTrue,
+			Reason:  requestPhasePending,
+			Message: fmt.Sprintf("VolumeScaleRequest '%s' to expand to %s is awaiting approval", pending.Name, pending.Spec.RequestedSize),
+		})
+	case denied != nil:
+		if prev := meta.FindStatusCondition(vsObj.Status.Conditions, conditionTypeApprovalRequired); prev == nil || prev.Reason != requestPhaseDenied {
+			c.recorder.Eventf(invRef, corev1.EventTypeWarning, eventReasonApprovalDenied,
+				"VolumeScaleRequest '%s' for PVC '%s/%s' was denied", denied.Name, vsName.Namespace, pvc.Name)
+		}
+		return nil, c.setCondition(ctx, vsName, vsObj, metav1.Condition{
+			Type:    conditionTypeApprovalRequired,
+			Status:  metav1.ConditionTrue,
+			Reason:  requestPhaseDenied,
+			Message: fmt.Sprintf("VolumeScaleRequest '%s' was denied; a new request is created after %s", denied.Name, denied.Status.ExpiresAt),
+		})
+	}
+
+	req, err := c.createScaleRequest(ctx, vsName, vsObj, pvc, limits, currentGi, newSizeGi, reason)
+	if err != nil {
+		return nil, err
+	}
+	msg := fmt.Sprintf("Expansion of PVC '%s/%s' from %.0fGi -> %s needs approval: %s. Approve VolumeScaleRequest '%s'.",
+		vsName.Namespace, pvc.Name, currentGi, req.Spec.RequestedSize, reason, req.Name)
+	c.recorder.Event(invRef, corev1.EventTypeNormal, eventReasonApprovalRequired, msg)
+	fmt.Printf("[INFO] %s\n", msg)
+	return nil, c.setCondition(ctx, vsName, vsObj, metav1.Condition{
+		Type:    conditionTypeApprovalRequired,
+		Status:  metav1.ConditionTrue,
+		Reason:  requestPhasePending,
+		Message: fmt.Sprintf("VolumeScaleRequest '%s' to expand to %s is awaiting approval", req.Name, req.Spec.RequestedSize),
+	})
+}
+
+// createScaleRequest creates a Pending VolumeScaleRequest owned by the VolumeScaler.
+func (c *VolumeScalerController) createScaleRequest(ctx context.Context, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, pvc *corev1.PersistentVolumeClaim, limits *effectiveLimits, currentGi, newSizeGi float64, reason string) (*v1alpha1.VolumeScaleRequest, error) {
+	expiry := limits.ApprovalExpiry
+	if expiry == 0 {
+		expiry = defaultApprovalExpiry
+	}
+	now := time.Now().UTC()
+
+	req := &v1alpha1.VolumeScaleRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%d", vsName.Name, now.Unix()),
+			Namespace: vsName.Namespace,
+			Labels:    map[string]string{labelVolumeScaler: vsName.Name},
+		},
+		Spec: v1alpha1.VolumeScaleRequestSpec{
+			VolumeScalerName: vsName.Name,
+			PVCName:          pvc.Name,
+			CurrentSize:      fmt.Sprintf("%.0fGi", currentGi),
+			RequestedSize:    fmt.Sprintf("%.0fGi", newSizeGi),
+			Reason:           reason,
+		},
+	}
+	if vsObj.UID != "" {
+		req.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       "VolumeScaler",
+			Name:       vsObj.Name,
+			UID:        vsObj.UID,
+		}}
+	}
+
+	created, err := c.vsClient.AutoscalingV1alpha1().VolumeScaleRequests(vsName.Namespace).Create(ctx, req, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("creating VolumeScaleRequest: %v", err)
+	}
+	err = c.patchRequestStatus(ctx, created, map[string]interface{}{
+		"phase":     requestPhasePending,
+		"expiresAt": now.Add(expiry).Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// syncAnnotationDecision moves a Pending request to Approved or Denied when a
+// user set the volumescaler.io/approval annotation. The decision is trusted
+// only when the admission webhook is served and stamped the request with
+// volumescaler.io/approved-by after checking the user; it doesn't stamp
+// annotations present at creation. Without the webhook, decisions go through
+// the RBAC-restricted status subresource.
+func (c *VolumeScalerController) syncAnnotationDecision(ctx context.Context, req *v1alpha1.VolumeScaleRequest) error {
+	if req.Status.Phase != requestPhasePending && req.Status.Phase != "" {
+		return nil
+	}
+	var phase string
+	switch strings.ToLower(strings.TrimSpace(req.Annotations[annotationApproval])) {
+	case "approved", "approve", "true":
+		phase = requestPhaseApproved
+	case "denied", "deny", "false":
+		phase = requestPhaseDenied
+	default:
+		return nil
+	}
+	by := req.Annotations[annotationApprovedBy]
+	if c.config.WebhookCertDir == "" || by == "" {
+		return c.setRequestMessage(ctx, req, fmt.Sprintf(
+			"Ignoring annotation %s: it needs the admission webhook to verify the approver. Set status.phase instead.", annotationApproval))
+	}
+	return c.patchRequestStatus(ctx, req, map[string]interface{}{"phase": phase, "approvedBy": by})
+}
+
+// markRequestApplied records that the PVC was patched for an approved request.
+func (c *VolumeScalerController) markRequestApplied(ctx context.Context, req *v1alpha1.VolumeScaleRequest) error {
+	return c.patchRequestStatus(ctx, req, map[string]interface{}{
+		"phase":     requestPhaseApplied,
+		"appliedAt": time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// patchRequestStatus merges fields into the request's status and mirrors them
+// onto req so callers see the new state.
+func (c *VolumeScalerController) patchRequestStatus(ctx context.Context, req *v1alpha1.VolumeScaleRequest, fields map[string]interface{}) error {
+	patch, err := json.Marshal(map[string]interface{}{"status": fields})
+	if err != nil {
+		return fmt.Errorf("encoding VolumeScaleRequest status patch: %v", err)
+	}
+	updated, err := c.vsClient.AutoscalingV1alpha1().VolumeScaleRequests(req.Namespace).
+		Patch(ctx, req.Name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+	if err != nil {
+		return fmt.Errorf("patching VolumeScaleRequest '%s/%s' status: %v", req.Namespace, req.Name, err)
+	}
+	req.Status = updated.Status
+	return nil
+}
+
+// requestExpired reports whether the request's expiresAt has passed. Requests
+// without a parseable expiresAt never expire.
+func requestExpired(req *v1alpha1.VolumeScaleRequest, now time.Time) bool {
+	if req.Status.ExpiresAt == "" {
+		return false
+	}
+	expiresAt, err := time.Parse(time.RFC3339, req.Status.ExpiresAt)
+	if err != nil {
+		return false
+	}
+	return now.After(expiresAt)
+}
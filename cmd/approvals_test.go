@@ -0,0 +1,394 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kfake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	vsfake "github.com/zghanem/sample-volumeScaler/pkg/generated/clientset/versioned/fake"
+)
+
+// scalerFixture is a 5Gi PVC at 80% usage whose VolumeScaler grows it by 2Gi
+// up to 10Gi.
+type scalerFixture struct {
+	controller *VolumeScalerController
+	clientset  *kfake.Clientset
+	vsClient   *vsfake.Clientset
+	recorder   *record.FakeRecorder
+	vsName     types.NamespacedName
+	usage      *PVCUsageInfo
+}
+
+// newApprovalFixture returns a scalerFixture whose VolumeScaler has the given
+// approval policy.
+func newApprovalFixture(t *testing.T, policy *v1alpha1.ApprovalPolicy, objs ...runtime.Object) *scalerFixture {
+	t.Helper()
+	return newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) { vs.Spec.ApprovalPolicy = policy }, objs...)
+}
+
+// newScalerFixture builds the fixture, letting mutate adjust the VolumeScaler.
+func newScalerFixture(t *testing.T, mutate func(*v1alpha1.VolumeScaler), objs ...runtime.Object) *scalerFixture {
+	t.Helper()
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")},
+			},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")},
+		},
+	}
+	vs := &v1alpha1.VolumeScaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default", UID: "vs-uid"},
+		Spec: v1alpha1.VolumeScalerSpec{
+			PVCName: "data", Threshold: "70%", Scale: "2Gi", ScaleType: "fixed", CooldownPeriod: "5m", MaxSize: "10Gi",
+		},
+	}
+	mutate(vs)
+	clientset := kfake.NewSimpleClientset(pvc)
+	vsClient := vsfake.NewSimpleClientset(append([]runtime.Object{vs}, objs...)...)
+	recorder := record.NewFakeRecorder(100)
+	return &scalerFixture{
+		controller: NewVolumeScalerController(NewDefaultConfig(), clientset, vsClient, recorder),
+		clientset:  clientset,
+		vsClient:   vsClient,
+		recorder:   recorder,
+		vsName:     types.NamespacedName{Namespace: "default", Name: "data"},
+		usage:      &PVCUsageInfo{UsedBytes: 4 << 30, CapacityBytes: 5 << 30, AvailableBytes: 1 << 30, UsagePercent: 80, UsedGi: 4.0},
+	}
+}
+
+// reconcile runs reconcilePVC against the stored PVC and VolumeScaler.
+func (f *scalerFixture) reconcile(t *testing.T) {
+	t.Helper()
+	vs, err := f.vsClient.AutoscalingV1alpha1().VolumeScalers("default").Get(context.TODO(), "data", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get VolumeScaler: %v", err)
+	}
+	pvc, err := f.clientset.CoreV1().PersistentVolumeClaims("default").Get(context.TODO(), "data", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get PVC: %v", err)
+	}
+	if err := f.controller.reconcilePVC(context.TODO(), pvc, vs, f.vsName, f.usage); err != nil {
+		t.Fatalf("reconcilePVC() error = %v", err)
+	}
+}
+
+func (f *scalerFixture) pvcSize(t *testing.T) string {
+	t.Helper()
+	pvc, err := f.clientset.CoreV1().PersistentVolumeClaims("default").Get(context.TODO(), "data", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get PVC: %v", err)
+	}
+	size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	return size.String()
+}
+
+func (f *scalerFixture) requests(t *testing.T) []v1alpha1.VolumeScaleRequest {
+	t.Helper()
+	list, err := f.vsClient.AutoscalingV1alpha1().VolumeScaleRequests("default").List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Failed to list VolumeScaleRequests: %v", err)
+	}
+	return list.Items
+}
+
+func TestApproval_RequestCreatedAndAppliedAfterApproval(t *testing.T) {
+	f := newApprovalFixture(t, &v1alpha1.ApprovalPolicy{SizeAbove: "6Gi"})
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "5Gi" {
+		t.Fatalf("Expected the PVC to wait for approval, got size %s", size)
+	}
+	reqs := f.requests(t)
+	if len(reqs) != 1 {
+		t.Fatalf("Expected one VolumeScaleRequest, got %d", len(reqs))
+	}
+	req := reqs[0]
+	if req.Status.Phase != requestPhasePending || req.Spec.RequestedSize != "7Gi" || req.Status.ExpiresAt == "" {
+		t.Errorf("Unexpected request %+v", req)
+	}
+	if req.Labels[labelVolumeScaler] != "data" || len(req.OwnerReferences) != 1 {
+		t.Errorf("Expected the request to be labelled and owned by the VolumeScaler, got %+v", req.ObjectMeta)
+	}
+	vs, _ := f.vsClient.AutoscalingV1alpha1().VolumeScalers("default").Get(context.TODO(), "data", metav1.GetOptions{})
+	if !meta.IsStatusConditionTrue(vs.Status.Conditions, conditionTypeApprovalRequired) {
+		t.Errorf("Expected an %s condition, got %+v", conditionTypeApprovalRequired, vs.Status.Conditions)
+	}
+
+	// Waiting does not create more requests
+	f.reconcile(t)
+	if n := len(f.requests(t)); n != 1 {
+		t.Fatalf("Expected still one VolumeScaleRequest, got %d", n)
+	}
+
+	// Approve through the status subresource
+	_, err := f.vsClient.AutoscalingV1alpha1().VolumeScaleRequests("default").Patch(context.TODO(), req.Name,
+		types.MergePatchType, []byte(`{"status":{"phase":"Approved","approvedBy":"finance"}}`), metav1.PatchOptions{}, "status")
+	if err != nil {
+		t.Fatalf("Failed to approve: %v", err)
+	}
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "7Gi" {
+		t.Errorf("Expected the approved size to be applied, got %s", size)
+	}
+	applied, _ := f.vsClient.AutoscalingV1alpha1().VolumeScaleRequests("default").Get(context.TODO(), req.Name, metav1.GetOptions{})
+	if applied.Status.Phase != requestPhaseApplied || applied.Status.AppliedAt == "" {
+		t.Errorf("Expected the request to be Applied, got %+v", applied.Status)
+	}
+	vs, _ = f.vsClient.AutoscalingV1alpha1().VolumeScalers("default").Get(context.TODO(), "data", metav1.GetOptions{})
+	if meta.FindStatusCondition(vs.Status.Conditions, conditionTypeApprovalRequired) != nil {
+		t.Errorf("Expected the %s condition to be cleared, got %+v", conditionTypeApprovalRequired, vs.Status.Conditions)
+	}
+}
+
+func TestApproval_AnnotationApproval(t *testing.T) {
+	req := &v1alpha1.VolumeScaleRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "data-1", Namespace: "default",
+			Labels:      map[string]string{labelVolumeScaler: "data"},
+			Annotations: map[string]string{annotationApproval: "approved", annotationApprovedBy: "alice"},
+		},
+		Spec:   v1alpha1.VolumeScaleRequestSpec{VolumeScalerName: "data", PVCName: "data", CurrentSize: "5Gi", RequestedSize: "7Gi"},
+		Status: v1alpha1.VolumeScaleRequestStatus{Phase: requestPhasePending, ExpiresAt: time.Now().Add(time.Hour).UTC().Format(time.RFC3339)},
+	}
+	f := newApprovalFixture(t, &v1alpha1.ApprovalPolicy{IncrementAbove: "1Gi"}, req)
+	f.controller.config.WebhookCertDir = "/certs"
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "7Gi" {
+		t.Errorf("Expected the annotation approval to be applied, got %s", size)
+	}
+	got, _ := f.vsClient.AutoscalingV1alpha1().VolumeScaleRequests("default").Get(context.TODO(), "data-1", metav1.GetOptions{})
+	if got.Status.Phase != requestPhaseApplied || got.Status.ApprovedBy != "alice" {
+		t.Errorf("Expected an Applied request approved by alice, got %+v", got.Status)
+	}
+}
+
+func TestApproval_UnverifiedAnnotationIgnored(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		webhook     bool
+		annotations map[string]string
+	}{
+		{name: "without the webhook", annotations: map[string]string{annotationApproval: "approved", annotationApprovedBy: "alice"}},
+		{name: "without approved-by", webhook: true, annotations: map[string]string{annotationApproval: "approved"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &v1alpha1.VolumeScaleRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "data-1", Namespace: "default",
+					Labels:      map[string]string{labelVolumeScaler: "data"},
+					Annotations: tt.annotations,
+				},
+				Spec:   v1alpha1.VolumeScaleRequestSpec{VolumeScalerName: "data", PVCName: "data", CurrentSize: "5Gi", RequestedSize: "7Gi"},
+				Status: v1alpha1.VolumeScaleRequestStatus{Phase: requestPhasePending, ExpiresAt: time.Now().Add(time.Hour).UTC().Format(time.RFC3339)},
+			}
+			f := newApprovalFixture(t, &v1alpha1.ApprovalPolicy{IncrementAbove: "1Gi"}, req)
+			if tt.webhook {
+				f.controller.config.WebhookCertDir = "/certs"
+			}
+
+			f.reconcile(t)
+			if size := f.pvcSize(t); size != "5Gi" {
+				t.Errorf("Expected the unverified approval to be ignored, got %s", size)
+			}
+			got, _ := f.vsClient.AutoscalingV1alpha1().VolumeScaleRequests("default").Get(context.TODO(), "data-1", metav1.GetOptions{})
+			if got.Status.Phase != requestPhasePending || !strings.Contains(got.Status.Message, "Ignoring annotation") {
+				t.Errorf("Expected a Pending request explaining the ignored annotation, got %+v", got.Status)
+			}
+		})
+	}
+}
+
+func TestApproval_ExpiredRequestIsReplaced(t *testing.T) {
+	req := &v1alpha1.VolumeScaleRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "data-1", Namespace: "default", Labels: map[string]string{labelVolumeScaler: "data"}},
+		Spec:       v1alpha1.VolumeScaleRequestSpec{VolumeScalerName: "data", PVCName: "data", CurrentSize: "5Gi", RequestedSize: "7Gi"},
+		Status:     v1alpha1.VolumeScaleRequestStatus{Phase: requestPhasePending, ExpiresAt: time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)},
+	}
+	f := newApprovalFixture(t, &v1alpha1.ApprovalPolicy{SizeAbove: "6Gi", Expiry: "1h"}, req)
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "5Gi" {
+		t.Fatalf("Expected no expansion without approval, got %s", size)
+	}
+	phases := map[string]int{}
+	for _, r := range f.requests(t) {
+		phases[r.Status.Phase]++
+	}
+	if phases[requestPhaseExpired] != 1 || phases[requestPhasePending] != 1 {
+		t.Errorf("Expected one Expired and one new Pending request, got %v", phases)
+	}
+
+	var sawExpired bool
+	for _, e := range drainEvents(f.recorder) {
+		sawExpired = sawExpired || strings.Contains(e, eventReasonApprovalExpired)
+	}
+	if !sawExpired {
+		t.Errorf("Expected an %s event", eventReasonApprovalExpired)
+	}
+}
+
+func TestApproval_DeniedRequestBlocksNewRequests(t *testing.T) {
+	req := &v1alpha1.VolumeScaleRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "data-1", Namespace: "default", Labels: map[string]string{labelVolumeScaler: "data"}},
+		Spec:       v1alpha1.VolumeScaleRequestSpec{VolumeScalerName: "data", PVCName: "data", CurrentSize: "5Gi", RequestedSize: "7Gi"},
+		Status:     v1alpha1.VolumeScaleRequestStatus{Phase: requestPhaseDenied, ExpiresAt: time.Now().Add(time.Hour).UTC().Format(time.RFC3339)},
+	}
+	f := newApprovalFixture(t, &v1alpha1.ApprovalPolicy{SizeAbove: "6Gi"}, req)
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "5Gi" {
+		t.Errorf("Expected no expansion after a denial, got %s", size)
+	}
+	if n := len(f.requests(t)); n != 1 {
+		t.Errorf("Expected no new request while the denial stands, got %d requests", n)
+	}
+}
+
+func TestApproval_ClusterWidePolicyFromLimit(t *testing.T) {
+	limit := &v1alpha1.VolumeScalerLimit{
+		ObjectMeta: metav1.ObjectMeta{Name: "finance"},
+		Spec:       v1alpha1.VolumeScalerLimitSpec{ApprovalPolicy: &v1alpha1.ApprovalPolicy{IncrementAbove: "1Gi"}},
+	}
+	f := newApprovalFixture(t, nil, limit)
+
+	f.reconcile(t)
+	reqs := f.requests(t)
+	if len(reqs) != 1 || !strings.Contains(reqs[0].Spec.Reason, "VolumeScalerLimit 'finance'") {
+		t.Errorf("Expected a request citing VolumeScalerLimit 'finance', got %+v", reqs)
+	}
+}
+
+func TestApproval_BelowPolicyExpandsDirectly(t *testing.T) {
+	f := newApprovalFixture(t, &v1alpha1.ApprovalPolicy{SizeAbove: "1Ti"})
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "7Gi" {
+		t.Errorf("Expected a direct expansion below the policy, got %s", size)
+	}
+	if n := len(f.requests(t)); n != 0 {
+		t.Errorf("Expected no VolumeScaleRequest, got %d", n)
+	}
+}
+
+// sendApprovalReview posts an UPDATE of a VolumeScaleRequest from user to the
+// approval webhook.
+func sendApprovalReview(t *testing.T, handler http.Handler, user string, oldObj, newObj *v1alpha1.VolumeScaleRequest) *admissionv1.AdmissionResponse {
+	t.Helper()
+	oldRaw, _ := json.Marshal(oldObj)
+	newRaw, _ := json.Marshal(newObj)
+	review := admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+		Request: &admissionv1.AdmissionRequest{
+			UID:       "req-1",
+			Operation: admissionv1.Update,
+			Namespace: "default",
+			Name:      newObj.Name,
+			UserInfo:  authenticationv1.UserInfo{Username: user},
+			Object:    runtime.RawExtension{Raw: newRaw},
+			OldObject: runtime.RawExtension{Raw: oldRaw},
+		},
+	}
+	body, _ := json.Marshal(review)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, webhookApprovalPath, bytes.NewReader(body)))
+	var out admissionv1.AdmissionReview
+	if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	return out.Response
+}
+
+func TestWebhook_AuthorizeApproval(t *testing.T) {
+	clientset := kfake.NewSimpleClientset()
+	clientset.PrependReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		sar := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		attrs := sar.Spec.ResourceAttributes
+		sar.Status.Allowed = sar.Spec.User == "alice" && attrs.Verb == "approve" && attrs.Resource == "volumescalerequests"
+		return true, sar, nil
+	})
+	server := NewWebhookServer(NewDefaultConfig(), clientset, vsfake.NewSimpleClientset())
+
+	old := &v1alpha1.VolumeScaleRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "data-1", Namespace: "default"},
+		Spec:       v1alpha1.VolumeScaleRequestSpec{VolumeScalerName: "data", PVCName: "data", RequestedSize: "7Gi"},
+	}
+	approved := old.DeepCopy()
+	approved.Annotations = map[string]string{annotationApproval: "approved"}
+
+	resp := sendApprovalReview(t, server.Handler(), "alice", old, approved)
+	if !resp.Allowed {
+		t.Fatalf("Expected alice's approval to be allowed, got %v", resp.Result)
+	}
+	if !strings.Contains(string(resp.Patch), "approved-by") || !strings.Contains(string(resp.Patch), "alice") {
+		t.Errorf("Expected the patch to record alice as approver, got %s", resp.Patch)
+	}
+
+	resp = sendApprovalReview(t, server.Handler(), "mallory", old, approved)
+	if resp.Allowed {
+		t.Error("Expected mallory's approval to be rejected")
+	}
+
+	forged := old.DeepCopy()
+	forged.Annotations = map[string]string{annotationApprovedBy: "alice"}
+	resp = sendApprovalReview(t, server.Handler(), "mallory", old, forged)
+	if resp.Allowed {
+		t.Errorf("Expected setting %s directly to be rejected", annotationApprovedBy)
+	}
+
+	// A decision present at creation is allowed but never stamped, so the
+	// controller ignores it
+	create := func(obj *v1alpha1.VolumeScaleRequest) *admissionv1.AdmissionResponse {
+		raw, _ := json.Marshal(obj)
+		body, _ := json.Marshal(admissionv1.AdmissionReview{
+			TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+			Request: &admissionv1.AdmissionRequest{
+				UID:       "req-1",
+				Operation: admissionv1.Create,
+				Namespace: "default",
+				Name:      obj.Name,
+				UserInfo:  authenticationv1.UserInfo{Username: "alice"},
+				Object:    runtime.RawExtension{Raw: raw},
+			},
+		})
+		rec := httptest.NewRecorder()
+		server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, webhookApprovalPath, bytes.NewReader(body)))
+		var out admissionv1.AdmissionReview
+		if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return out.Response
+	}
+	if resp := create(approved); !resp.Allowed || len(resp.Patch) != 0 {
+		t.Errorf("Expected a create with a decision allowed without approved-by, got %+v", resp)
+	}
+	forged = approved.DeepCopy()
+	forged.Annotations[annotationApprovedBy] = "alice"
+	if resp := create(forged); resp.Allowed {
+		t.Errorf("Expected a create setting %s to be rejected", annotationApprovedBy)
+	}
+}
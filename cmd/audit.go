@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+const (
+	defaultAuditRetention = 30 * 24 * time.Hour
+	defaultAuditMaxEvents = 1000
+
+	// auditPruneInterval is how often the VolumeScaleEvents of a namespace
+	// are pruned
+	auditPruneInterval = time.Hour
+
+	// Decisions recorded in VolumeScaleEvents
+	decisionExpanded        = "Expanded"
+	decisionCompleted       = "Completed"
+	decisionSkippedCooldown = "SkippedCooldown"
+	decisionAtMaxSize       = "AtMaxSize"
+	decisionBlocked         = "Blocked"
+	decisionHeld            = "Held"
+	decisionRecommended     = "Recommended"
+	decisionFailed          = "Failed"
+	decisionRolledBack      = "RolledBack"
+
+	// What led to a decision
+	triggerThreshold    = "Threshold"
+	triggerCritical     = "Critical"
+	triggerSchedule     = "Schedule"
+	triggerScaleRequest = "ScaleRequest"
+	triggerShrink       = "Shrink"
+
+	// labelDecision lets kubectl filter VolumeScaleEvents by decision
+	labelDecision = annotationPrefix + "decision"
+)
+
+// scaleDecision is one decision to record in the audit trail.
+type scaleDecision struct {
+	decision     string
+	trigger      string
+	currentSize  string
+	computedSize string
+	clamps       string
+	message      string
+}
+
+// key identifies repeats of the same decision, such as a cooldown skip on
+// every loop, which are recorded once.
+func (d scaleDecision) key() string {
+	return d.decision + "|" + d.trigger + "|" + d.currentSize + "|" + d.computedSize
+}
+
+// recordDecision appends d to the audit trail as a VolumeScaleEvent holding
+// the usage and spec of vsObj at the time. A decision repeating the previous
+// one for the PVC is skipped. Failures are logged and never hold back scaling.
+func (c *VolumeScalerController) recordDecision(ctx context.Context, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, pvcName string, d scaleDecision) {
+	if !c.config.AuditTrail {
+		return
+	}
+	pvcKey := vsName.Namespace + "/" + pvcName
+	if c.lastDecisions == nil {
+		c.lastDecisions = make(map[string]string)
+	}
+	if c.lastDecisions[pvcKey] == d.key() {
+		return
+	}
+
+	now := time.Now().UTC()
+	prefix := vsName.Name
+	if len(prefix) > 240 {
+		prefix = prefix[:240]
+	}
+	ev := &v1alpha1.VolumeScaleEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      prefix + "-" + strconv.FormatInt(now.UnixNano(), 36),
+			Namespace: vsName.Namespace,
+			Labels:    map[string]string{labelVolumeScaler: vsName.Name, labelDecision: d.decision},
+		},
+		Spec: v1alpha1.VolumeScaleEventSpec{
+			VolumeScalerName: vsName.Name,
+			PVCName:          pvcName,
+			Time:             now.Format(time.RFC3339),
+			Decision:         d.decision,
+			Trigger:          d.trigger,
+			Policy:           vsObj.Spec.DeepCopy(),
+			CurrentSize:      d.currentSize,
+			ComputedSize:     d.computedSize,
+			Clamps:           d.clamps,
+			Actor:            "node/" + os.Getenv("NODE_NAME_ENV"),
+			Controller:       os.Getenv("HOSTNAME"),
+			Message:          d.message,
+		},
+	}
+	if vsObj.Status.CurrentSizeGi != "" {
+		ev.Spec.Usage = &v1alpha1.ScaleEventUsage{
+			UsedGi:       vsObj.Status.CurrentUsedGi,
+			SizeGi:       vsObj.Status.CurrentSizeGi,
+			UsagePercent: vsObj.Status.CurrentUsagePercent,
+		}
+	}
+	_, err := c.vsClient.AutoscalingV1alpha1().VolumeScaleEvents(vsName.Namespace).Create(ctx, ev, metav1.CreateOptions{})
+	if err != nil {
+		fmt.Printf("[WARN] recording VolumeScaleEvent for PVC '%s': %v\n", pvcKey, err)
+		return
+	}
+	c.lastDecisions[pvcKey] = d.key()
+	c.pruneScaleEvents(ctx, vsName.Namespace, now)
+}
+
+// pruneScaleEvents deletes the VolumeScaleEvents of a namespace older than
+// AuditRetention, and the oldest of each VolumeScaler beyond AuditMaxEvents.
+// Events of deleted VolumeScalers are kept until they expire. It runs at
+// most once per auditPruneInterval for each namespace.
+func (c *VolumeScalerController) pruneScaleEvents(ctx context.Context, namespace string, now time.Time) {
+	if c.auditPruned == nil {
+		c.auditPruned = make(map[string]time.Time)
+	}
+	if last, ok := c.auditPruned[namespace]; ok && now.Sub(last) < auditPruneInterval {
+		return
+	}
+	c.auditPruned[namespace] = now
+
+	events := c.vsClient.AutoscalingV1alpha1().VolumeScaleEvents(namespace)
+	list, err := events.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		fmt.Printf("[WARN] listing VolumeScaleEvents in '%s': %v\n", namespace, err)
+		return
+	}
+	byScaler := make(map[string][]v1alpha1.VolumeScaleEvent)
+	for _, ev := range list.Items {
+		byScaler[ev.Spec.VolumeScalerName] = append(byScaler[ev.Spec.VolumeScalerName], ev)
+	}
+	for _, items := range byScaler {
+		sort.Slice(items, func(i, j int) bool {
+			if items[i].Spec.Time != items[j].Spec.Time {
+				return items[i].Spec.Time < items[j].Spec.Time
+			}
+			return items[i].Name < items[j].Name
+		})
+		excess := 0
+		if c.config.AuditMaxEvents > 0 {
+			excess = len(items) - c.config.AuditMaxEvents
+		}
+		for i, ev := range items {
+			t, err := time.Parse(time.RFC3339, ev.Spec.Time)
+			expired := c.config.AuditRetention > 0 && err == nil && now.Sub(t) > c.config.AuditRetention
+			if i >= excess && !expired {
+				continue
+			}
+			if err := events.Delete(ctx, ev.Name, metav1.DeleteOptions{}); err != nil {
+				fmt.Printf("[WARN] deleting VolumeScaleEvent '%s/%s': %v\n", namespace, ev.Name, err)
+			}
+		}
+	}
+}
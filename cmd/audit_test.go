@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+// scaleEvents returns the VolumeScaleEvents in the default namespace.
+func (f *scalerFixture) scaleEvents(t *testing.T) []v1alpha1.VolumeScaleEvent {
+	t.Helper()
+	list, err := f.vsClient.AutoscalingV1alpha1().VolumeScaleEvents("default").List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Failed to list VolumeScaleEvents: %v", err)
+	}
+	return list.Items
+}
+
+func TestAudit_RecordsExpansion(t *testing.T) {
+	t.Setenv("NODE_NAME_ENV", "node-a")
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {})
+
+	f.reconcile(t)
+	events := f.scaleEvents(t)
+	if len(events) != 1 {
+		t.Fatalf("Expected one VolumeScaleEvent, got %d", len(events))
+	}
+	ev := events[0]
+	if ev.Labels[labelVolumeScaler] != "data" || ev.Labels[labelDecision] != decisionExpanded {
+		t.Errorf("Expected the event labelled with its VolumeScaler and decision, got %v", ev.Labels)
+	}
+	spec := ev.Spec
+	if spec.Decision != decisionExpanded || spec.Trigger != triggerThreshold || spec.PVCName != "data" ||
+		spec.CurrentSize != "5Gi" || spec.ComputedSize != "7Gi" || spec.Actor != "node/node-a" {
+		t.Errorf("Expected a threshold expansion from 5Gi to 7Gi by node-a, got %+v", spec)
+	}
+	if spec.Usage == nil || spec.Usage.UsagePercent != 80 || spec.Usage.UsedGi != "4.0Gi" {
+		t.Errorf("Expected the usage at the time of the decision, got %+v", spec.Usage)
+	}
+	if spec.Policy == nil || spec.Policy.Threshold != "70%" || spec.Policy.MaxSize != "10Gi" {
+		t.Errorf("Expected the policy in effect, got %+v", spec.Policy)
+	}
+}
+
+func TestAudit_RecordsRepeatedDecisionOnce(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		vs.Status.ScaledAt = time.Now().UTC().Format(time.RFC3339)
+	})
+
+	f.reconcile(t)
+	f.reconcile(t)
+	events := f.scaleEvents(t)
+	if len(events) != 1 || events[0].Spec.Decision != decisionSkippedCooldown {
+		t.Fatalf("Expected a single SkippedCooldown event, got %+v", events)
+	}
+}
+
+func TestAudit_Disabled(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {})
+	f.controller.config.AuditTrail = false
+
+	f.reconcile(t)
+	if events := f.scaleEvents(t); len(events) != 0 {
+		t.Errorf("Expected no VolumeScaleEvents, got %d", len(events))
+	}
+}
+
+func TestPruneScaleEvents(t *testing.T) {
+	now := time.Now().UTC()
+	event := func(name, scaler string, age time.Duration) *v1alpha1.VolumeScaleEvent {
+		return &v1alpha1.VolumeScaleEvent{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       v1alpha1.VolumeScaleEventSpec{VolumeScalerName: scaler, Time: now.Add(-age).Format(time.RFC3339)},
+		}
+	}
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {},
+		event("expired", "data", 48*time.Hour),
+		event("oldest", "data", 3*time.Hour),
+		event("older", "data", 2*time.Hour),
+		event("newer", "data", time.Hour),
+		event("newest", "data", 0),
+		// A deleted VolumeScaler's events stay until they expire
+		event("orphan", "gone", time.Hour),
+		event("expired-orphan", "gone", 48*time.Hour),
+	)
+	f.controller.config.AuditRetention = 24 * time.Hour
+	f.controller.config.AuditMaxEvents = 2
+
+	f.controller.pruneScaleEvents(context.TODO(), "default", now)
+	kept := map[string]bool{}
+	for _, ev := range f.scaleEvents(t) {
+		kept[ev.Name] = true
+	}
+	if want := map[string]bool{"newer": true, "newest": true, "orphan": true}; fmt.Sprint(kept) != fmt.Sprint(want) {
+		t.Errorf("Expected %v kept, got %v", want, kept)
+	}
+
+	// Pruning runs once per interval
+	f.controller.config.AuditMaxEvents = 1
+	f.controller.pruneScaleEvents(context.TODO(), "default", now.Add(time.Minute))
+	if got := len(f.scaleEvents(t)); got != 3 {
+		t.Errorf("Expected no pruning within the interval, got %d events", got)
+	}
+}
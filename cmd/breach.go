@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+const (
+	// Event reasons
+	eventReasonBreachPending = "BreachPending"
+)
+
+// breachLevel returns the usage percentage a breach must stay at or above to
+// continue: resetThreshold when set, the threshold otherwise.
+func breachLevel(vsObj *v1alpha1.VolumeScaler, thresholdF float64) float64 {
+	if vsObj.Spec.ResetThreshold != "" {
+		if reset, err := Percentage(vsObj.Spec.ResetThreshold).ToFloat(); err == nil {
+			return reset
+		}
+	}
+	return thresholdF
+}
+
+// trackBreach records since when the PVC's usage has been above threshold and
+// returns that time, or the zero time when it is not breaching. A breach
+// starts when usage reaches the threshold and ends when usage drops below the
+// resetThreshold. The start is kept in memory across loops and mirrored to
+// status.breachingSince, which also seeds it after a controller restart.
+func (c *VolumeScalerController) trackBreach(ctx context.Context, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, pvcKey string, usagePercent int, thresholdF float64) (time.Time, error) {
+	if c.breaches == nil {
+		c.breaches = make(map[string]time.Time)
+	}
+	since, breaching := c.breaches[pvcKey]
+	if !breaching {
+		if t, err := time.Parse(time.RFC3339, vsObj.Status.BreachingSince); err == nil {
+			since, breaching = t, true
+		}
+	}
+
+	switch {
+	case breaching && float64(usagePercent) < breachLevel(vsObj, thresholdF):
+		delete(c.breaches, pvcKey)
+		since = time.Time{}
+	case breaching:
+		c.breaches[pvcKey] = since
+	case usagePercent >= int(thresholdF):
+		since = time.Now().UTC()
+		c.breaches[pvcKey] = since
+	}
+
+	return since, c.patchBreachingSince(ctx, vsName, vsObj, formatStatusTime(since))
+}
+
+// resetBreach forgets the PVC's breach once an expansion has been started.
+func (c *VolumeScalerController) resetBreach(ctx context.Context, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, pvcKey string) error {
+	delete(c.breaches, pvcKey)
+	return c.patchBreachingSince(ctx, vsName, vsObj, "")
+}
+
+// patchBreachingSince updates status.breachingSince when it changed.
+func (c *VolumeScalerController) patchBreachingSince(ctx context.Context, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, since string) error {
+	if vsObj.Status.BreachingSince == since {
+		return nil
+	}
+	var value interface{}
+	if since != "" {
+		value = since
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{"breachingSince": value},
+	})
+	if err != nil {
+		return fmt.Errorf("encoding breachingSince patch: %v", err)
+	}
+	_, err = c.vsClient.AutoscalingV1alpha1().VolumeScalers(vsName.Namespace).
+		Patch(ctx, vsName.Name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+	if err != nil {
+		return fmt.Errorf("patching breachingSince: %v", err)
+	}
+	vsObj.Status.BreachingSince = since
+	return nil
+}
+
+// pruneVolumeState drops the breach, growth, cost, history and audit state of PVCs
+// that no longer report usage on this node, such as deleted PVCs or those
+// whose pod moved elsewhere.
+func (c *VolumeScalerController) pruneVolumeState(pvcUsageMap map[string]*PVCUsageInfo) {
+	for key := range c.breaches {
+		if _, ok := pvcUsageMap[key]; !ok {
+			delete(c.breaches, key)
+		}
+	}
+	for key := range c.growth {
+		if _, ok := pvcUsageMap[key]; !ok {
+			delete(c.growth, key)
+		}
+	}
+	for key, labels := range c.costSeries {
+		if _, ok := pvcUsageMap[key]; !ok {
+			deleteCostSeries(labels)
+			delete(c.costSeries, key)
+		}
+	}
+	for key := range c.histories {
+		if _, ok := pvcUsageMap[key]; !ok {
+			delete(c.histories, key)
+		}
+	}
+	for key := range c.lastDecisions {
+		if _, ok := pvcUsageMap[key]; !ok {
+			delete(c.lastDecisions, key)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+// sustained requires a 10 minute breach before expanding.
+func sustained(vs *v1alpha1.VolumeScaler) {
+	vs.Spec.BreachDuration = "10m"
+}
+
+// setUsedGi changes the fixture's usage of its 5Gi PVC.
+func (f *scalerFixture) setUsedGi(usedGi float64) {
+	f.usage.UsedGi = usedGi
+	f.usage.UsedBytes = uint64(usedGi * (1 << 30))
+	f.usage.AvailableBytes = f.usage.CapacityBytes - f.usage.UsedBytes
+	f.usage.UsagePercent = int(usedGi / 5 * 100)
+}
+
+func TestBreach_WaitsForBreachDuration(t *testing.T) {
+	f := newScalerFixture(t, sustained)
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "5Gi" {
+		t.Fatalf("Expected the first sample above threshold not to expand, got %s", size)
+	}
+	if st := f.status(t); st.BreachingSince == "" {
+		t.Errorf("Expected breachingSince in the status, got %+v", st)
+	}
+	if events := strings.Join(drainEvents(f.recorder), "\n"); !strings.Contains(events, eventReasonBreachPending) {
+		t.Errorf("Expected a %s event, got %s", eventReasonBreachPending, events)
+	}
+
+	f.controller.breaches["default/data"] = time.Now().Add(-11 * time.Minute)
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "7Gi" {
+		t.Fatalf("Expected the sustained breach to expand the PVC, got %s", size)
+	}
+	if st := f.status(t); st.BreachingSince != "" {
+		t.Errorf("Expected breachingSince to be cleared after the expansion, got %q", st.BreachingSince)
+	}
+}
+
+func TestBreach_SpikeEndsBreach(t *testing.T) {
+	f := newScalerFixture(t, sustained)
+
+	f.reconcile(t)
+	f.setUsedGi(3)
+	f.reconcile(t)
+	if _, ok := f.controller.breaches["default/data"]; ok {
+		t.Errorf("Expected the breach to end once usage dropped below threshold")
+	}
+	if st := f.status(t); st.BreachingSince != "" {
+		t.Errorf("Expected breachingSince to be cleared, got %q", st.BreachingSince)
+	}
+}
+
+func TestBreach_ResetThresholdHysteresis(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		sustained(vs)
+		vs.Spec.ResetThreshold = "60%"
+	})
+
+	f.reconcile(t)
+	since := f.status(t).BreachingSince
+
+	f.setUsedGi(3.25) // 65%: below threshold, above resetThreshold
+	f.reconcile(t)
+	if st := f.status(t); st.BreachingSince != since {
+		t.Errorf("Expected the breach to continue above resetThreshold, got %q want %q", st.BreachingSince, since)
+	}
+
+	f.setUsedGi(2.75) // 55%
+	f.reconcile(t)
+	if st := f.status(t); st.BreachingSince != "" {
+		t.Errorf("Expected the breach to end below resetThreshold, got %q", st.BreachingSince)
+	}
+}
+
+func TestBreach_ResumesFromStatus(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		sustained(vs)
+		vs.Status.BreachingSince = time.Now().Add(-20 * time.Minute).UTC().Format(time.RFC3339)
+	})
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "7Gi" {
+		t.Errorf("Expected a breach recorded before a restart to count, got %s", size)
+	}
+}
+
+func TestBreach_CriticalUsageSkipsBreachDuration(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		sustained(vs)
+		vs.Spec.CriticalThreshold = "75%"
+	})
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "7Gi" {
+		t.Errorf("Expected critical usage to expand without waiting, got %s", size)
+	}
+}
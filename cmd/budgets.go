@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+const (
+	// budgetWindow is the sliding window growth budgets are counted over
+	budgetWindow = 24 * time.Hour
+	// maxLedgerEntries bounds the expansion ledger kept in the status
+	maxLedgerEntries = 500
+
+	conditionTypeBudgetExhausted = "BudgetExhausted"
+
+	// Event reasons
+	eventReasonBudgetExhausted = "BudgetExhausted"
+)
+
+// ledgerUsage sums the ledger records within the budget window ending at now.
+// oldest is the time of the earliest record counted, which is when budget
+// first frees up again.
+type ledgerUsage struct {
+	count    int
+	growthGi float64
+	oldest   time.Time
+}
+
+// add counts the records of ledger that fall inside the window ending at now.
+func (u *ledgerUsage) add(ledger []v1alpha1.ExpansionRecord, now time.Time) {
+	for _, r := range ledger {
+		t, err := time.Parse(time.RFC3339, r.Time)
+		if err != nil || now.Sub(t) >= budgetWindow {
+			continue
+		}
+		increaseGi, err := convertToGi(r.Increase)
+		if err != nil {
+			continue
+		}
+		u.count++
+		u.growthGi += increaseGi
+		if u.oldest.IsZero() || t.Before(u.oldest) {
+			u.oldest = t
+		}
+	}
+}
+
+// appendLedger returns ledger with a record of increaseGi at now, dropping
+// records that left the budget window and the oldest beyond maxLedgerEntries.
+func appendLedger(ledger []v1alpha1.ExpansionRecord, now time.Time, increaseGi float64) []v1alpha1.ExpansionRecord {
+	out := make([]v1alpha1.ExpansionRecord, 0, len(ledger)+1)
+	for _, r := range ledger {
+		if t, err := time.Parse(time.RFC3339, r.Time); err == nil && now.Sub(t) < budgetWindow {
+			out = append(out, r)
+		}
+	}
+	out = append(out, v1alpha1.ExpansionRecord{
+		Time:     now.UTC().Format(time.RFC3339),
+		Increase: fmt.Sprintf("%.0fGi", increaseGi),
+	})
+	if len(out) > maxLedgerEntries {
+		out = out[len(out)-maxLedgerEntries:]
+	}
+	return out
+}
+
+// mergeGrowthBudget folds a VolumeScalerLimit's namespace growth budget into
+// the effective limits; the lowest values win.
+func (eff *effectiveLimits) mergeGrowthBudget(b *v1alpha1.GrowthBudget, from string) error {
+	if b.MaxExpansionsPerDay > 0 && (eff.NamespaceMaxExpansions == 0 || b.MaxExpansionsPerDay < eff.NamespaceMaxExpansions) {
+		eff.NamespaceMaxExpansions, eff.NamespaceBudgetFrom = b.MaxExpansionsPerDay, from
+	}
+	if b.MaxGrowthPerDay != "" {
+		v, err := convertToGi(b.MaxGrowthPerDay)
+		if err != nil {
+			return fmt.Errorf("VolumeScalerLimit '%s' namespaceGrowthBudget.maxGrowthPerDay: %v", from, err)
+		}
+		if eff.NamespaceMaxGrowthGi == 0 || v < eff.NamespaceMaxGrowthGi {
+			eff.NamespaceMaxGrowthGi, eff.NamespaceBudgetFrom = v, from
+		}
+	}
+	return nil
+}
+
+// applyGrowthBudgets clamps newSizeGi to what is left of the VolumeScaler's
+// growthBudget and the namespace budget of its VolumeScalerLimits over the
+// last 24 hours. It reports whether a budget is exhausted, in which case the
+// BudgetExhausted condition is set and newSizeGi is returned as currentGi.
+func (c *VolumeScalerController) applyGrowthBudgets(ctx context.Context, invRef *corev1.ObjectReference, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, limits *effectiveLimits, currentGi, newSizeGi float64) (float64, []string, bool, error) {
+	now := time.Now().UTC()
+	var clamps, exhausted []string
+	var freesAt time.Time
+	check := func(scope string, used ledgerUsage, maxCount int32, maxGrowthGi float64) {
+		blocked := false
+		if maxCount > 0 && used.count >= int(maxCount) {
+			exhausted = append(exhausted, fmt.Sprintf("%s allows %d expansions per day, %d used", scope, maxCount, used.count))
+			blocked = true
+		}
+		if maxGrowthGi > 0 {
+			remaining := math.Floor(maxGrowthGi - used.growthGi)
+			switch {
+			case remaining <= 0:
+				exhausted = append(exhausted, fmt.Sprintf("%s allows %.0fGi of growth per day, %.0fGi used", scope, maxGrowthGi, used.growthGi))
+				blocked = true
+			case newSizeGi-currentGi > remaining:
+				clamps = append(clamps, fmt.Sprintf("increment %.0fGi -> %.0fGi (%s growth budget)", newSizeGi-currentGi, remaining, scope))
+				newSizeGi = currentGi + remaining
+			}
+		}
+		if blocked && used.oldest.Add(budgetWindow).After(freesAt) {
+			freesAt = used.oldest.Add(budgetWindow)
+		}
+	}
+
+	if b := vsObj.Spec.GrowthBudget; b != nil {
+		var maxGrowthGi float64
+		if b.MaxGrowthPerDay != "" {
+			v, err := convertToGi(b.MaxGrowthPerDay)
+			if err != nil {
+				return 0, nil, false, fmt.Errorf("invalid growthBudget.maxGrowthPerDay '%s': %v", b.MaxGrowthPerDay, err)
+			}
+			maxGrowthGi = v
+		}
+		var used ledgerUsage
+		used.add(vsObj.Status.ExpansionLedger, now)
+		check("growthBudget", used, b.MaxExpansionsPerDay, maxGrowthGi)
+	}
+
+	if limits.NamespaceMaxExpansions > 0 || limits.NamespaceMaxGrowthGi > 0 {
+		vsList, err := c.vsClient.AutoscalingV1alpha1().VolumeScalers(vsName.Namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return 0, nil, false, fmt.Errorf("listing VolumeScalers in '%s': %v", vsName.Namespace, err)
+		}
+		var used ledgerUsage
+		for i := range vsList.Items {
+			used.add(vsList.Items[i].Status.ExpansionLedger, now)
+		}
+		check(fmt.Sprintf("namespace budget of VolumeScalerLimit '%s'", limits.NamespaceBudgetFrom),
+			used, limits.NamespaceMaxExpansions, limits.NamespaceMaxGrowthGi)
+	}
+
+	if len(exhausted) == 0 {
+		return newSizeGi, clamps, false, c.clearCondition(ctx, vsName, vsObj, conditionTypeBudgetExhausted)
+	}
+
+	msg := fmt.Sprintf("%s; budget frees up at %s", strings.Join(exhausted, "; "), formatStatusTime(freesAt))
+	if cond := meta.FindStatusCondition(vsObj.Status.Conditions, conditionTypeBudgetExhausted); cond == nil {
+		c.recorder.Eventf(invRef, corev1.EventTypeWarning, eventReasonBudgetExhausted,
+			"Expansions of VolumeScaler '%s/%s' are blocked: %s", vsName.Namespace, vsName.Name, msg)
+	}
+	err := c.setCondition(ctx, vsName, vsObj, metav1.Condition{
+		Type:    conditionTypeBudgetExhausted,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Exhausted",
+		Message: msg,
+	})
+	return currentGi, append(clamps, exhausted...), true, err
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+// expandedAgo returns a ledger record of increase made ago.
+func expandedAgo(ago time.Duration, increase string) v1alpha1.ExpansionRecord {
+	return v1alpha1.ExpansionRecord{Time: time.Now().Add(-ago).UTC().Format(time.RFC3339), Increase: increase}
+}
+
+func TestAppendLedger(t *testing.T) {
+	now := time.Now()
+	ledger := []v1alpha1.ExpansionRecord{expandedAgo(25*time.Hour, "2Gi"), expandedAgo(time.Hour, "2Gi")}
+
+	got := appendLedger(ledger, now, 4)
+	if len(got) != 2 || got[1].Increase != "4Gi" {
+		t.Errorf("appendLedger() = %+v, want the old record dropped and 4Gi appended", got)
+	}
+
+	var used ledgerUsage
+	used.add(got, now)
+	if used.count != 2 || used.growthGi != 6 {
+		t.Errorf("ledgerUsage = %+v, want 2 expansions and 6Gi", used)
+	}
+}
+
+func TestBudget_ExpansionRecordedInLedger(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {})
+
+	f.reconcile(t)
+	if ledger := f.status(t).ExpansionLedger; len(ledger) != 1 || ledger[0].Increase != "2Gi" {
+		t.Errorf("Expected the expansion in the ledger, got %+v", ledger)
+	}
+}
+
+func TestBudget_MaxExpansionsPerDayBlocks(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		vs.Spec.GrowthBudget = &v1alpha1.GrowthBudget{MaxExpansionsPerDay: 2}
+		vs.Status.ExpansionLedger = []v1alpha1.ExpansionRecord{expandedAgo(3*time.Hour, "1Gi"), expandedAgo(2*time.Hour, "1Gi")}
+	})
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "5Gi" {
+		t.Fatalf("Expected the exhausted budget to block the expansion, got %s", size)
+	}
+	cond := meta.FindStatusCondition(f.status(t).Conditions, conditionTypeBudgetExhausted)
+	if cond == nil || cond.Status != metav1.ConditionTrue || !strings.Contains(cond.Message, "2 expansions per day") {
+		t.Errorf("Expected a %s condition, got %+v", conditionTypeBudgetExhausted, cond)
+	}
+}
+
+func TestBudget_WindowRolls(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		vs.Spec.GrowthBudget = &v1alpha1.GrowthBudget{MaxExpansionsPerDay: 1}
+		vs.Status.ExpansionLedger = []v1alpha1.ExpansionRecord{expandedAgo(25*time.Hour, "1Gi")}
+		vs.Status.Conditions = []metav1.Condition{{Type: conditionTypeBudgetExhausted, Status: metav1.ConditionTrue, Reason: "Exhausted"}}
+	})
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "7Gi" {
+		t.Fatalf("Expected expansions to resume once the window rolled, got %s", size)
+	}
+	if cond := meta.FindStatusCondition(f.status(t).Conditions, conditionTypeBudgetExhausted); cond != nil {
+		t.Errorf("Expected the condition to be cleared, got %+v", cond)
+	}
+}
+
+func TestBudget_MaxGrowthPerDayClamps(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		vs.Spec.GrowthBudget = &v1alpha1.GrowthBudget{MaxGrowthPerDay: "3Gi"}
+		vs.Status.ExpansionLedger = []v1alpha1.ExpansionRecord{expandedAgo(time.Hour, "2Gi")}
+	})
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "6Gi" {
+		t.Errorf("Expected the increment to be clamped to the remaining 1Gi, got %s", size)
+	}
+}
+
+func TestBudget_NamespaceAggregate(t *testing.T) {
+	other := &v1alpha1.VolumeScaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "logs", Namespace: "default"},
+		Spec:       v1alpha1.VolumeScalerSpec{PVCName: "logs", Threshold: "70%", Scale: "2Gi", ScaleType: "fixed", MaxSize: "10Gi"},
+		Status: v1alpha1.VolumeScalerStatus{
+			ExpansionLedger: []v1alpha1.ExpansionRecord{expandedAgo(time.Hour, "10Gi")},
+		},
+	}
+	limit := &v1alpha1.VolumeScalerLimit{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-budget"},
+		Spec:       v1alpha1.VolumeScalerLimitSpec{NamespaceGrowthBudget: &v1alpha1.GrowthBudget{MaxGrowthPerDay: "10Gi"}},
+	}
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {}, other, limit)
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "5Gi" {
+		t.Fatalf("Expected the namespace budget to block the expansion, got %s", size)
+	}
+	cond := meta.FindStatusCondition(f.status(t).Conditions, conditionTypeBudgetExhausted)
+	if cond == nil || !strings.Contains(cond.Message, "team-budget") {
+		t.Errorf("Expected the condition to name the VolumeScalerLimit, got %+v", cond)
+	}
+}
+
+func TestBudget_DelaysScheduledRaise(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		withSchedule("8Gi")(vs)
+		vs.Spec.GrowthBudget = &v1alpha1.GrowthBudget{MaxExpansionsPerDay: 1}
+		vs.Status.ExpansionLedger = []v1alpha1.ExpansionRecord{expandedAgo(time.Hour, "1Gi")}
+	})
+	before := f.status(t).LastScheduleTime
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "5Gi" {
+		t.Errorf("Expected the scheduled raise to wait for the budget, got %s", size)
+	}
+	if st := f.status(t); st.LastScheduleTime != before {
+		t.Errorf("Expected the schedule to stay due, got lastScheduleTime %q", st.LastScheduleTime)
+	}
+}
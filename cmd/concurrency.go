@@ -0,0 +1,302 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+const (
+	// resizeSlotPrefix names the Leases that act as cluster-wide resize slots
+	resizeSlotPrefix = "volumescaler-resize-slot-"
+	// resizeSlotDuration is how long a slot stays held without renewal, so a
+	// slot held by a controller that died is eventually reclaimed
+	resizeSlotDuration = 15 * time.Minute
+
+	// Event reasons
+	eventReasonResizeQueued = "ResizeQueued"
+)
+
+// defaultLeaseNamespace is the namespace the controller runs in, as exposed
+// through the downward API, or "default".
+func defaultLeaseNamespace() string {
+	if ns := os.Getenv("POD_NAMESPACE"); ns != "" {
+		return ns
+	}
+	return "default"
+}
+
+// slotFree reports whether a resize slot can be claimed at now: it does not
+// exist yet, has no holder, or its holder stopped renewing it.
+func slotFree(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease == nil || lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity == "" {
+		return true
+	}
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+	expiry := lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second)
+	return now.After(expiry)
+}
+
+// hoursToFull estimates how long until the volume of a VolumeScaler fills up
+// at its current growth rate. Volumes that aren't growing never fill.
+func hoursToFull(st *v1alpha1.VolumeScalerStatus) float64 {
+	rate := parseRate(st.GrowthRatePerHour)
+	sizeGi := parseRate(st.CurrentSizeGi)
+	if rate <= 0 || sizeGi <= 0 {
+		return math.Inf(1)
+	}
+	return math.Max(sizeGi-parseRate(st.CurrentUsedGi), 0) / rate
+}
+
+// sortResizeQueue orders waiting VolumeScalers by urgency: the shortest time
+// to full first, then the highest usage, then the longest wait.
+func sortResizeQueue(queue []v1alpha1.VolumeScaler) {
+	sort.SliceStable(queue, func(i, j int) bool {
+		a, b := &queue[i].Status, &queue[j].Status
+		if ha, hb := hoursToFull(a), hoursToFull(b); ha != hb {
+			return ha < hb
+		}
+		if a.CurrentUsagePercent != b.CurrentUsagePercent {
+			return a.CurrentUsagePercent > b.CurrentUsagePercent
+		}
+		if a.QueuedSince != b.QueuedSince {
+			return a.QueuedSince < b.QueuedSince
+		}
+		return queue[i].Namespace+"/"+queue[i].Name < queue[j].Namespace+"/"+queue[j].Name
+	})
+}
+
+// getResizeSlots fetches the resize slot Leases. Slots that were never
+// created are returned as nil.
+func (c *VolumeScalerController) getResizeSlots(ctx context.Context) ([]*coordinationv1.Lease, error) {
+	slots := make([]*coordinationv1.Lease, c.config.MaxConcurrentResizes)
+	for i := range slots {
+		lease, err := c.clientset.CoordinationV1().Leases(c.config.ResizeLeaseNamespace).
+			Get(ctx, fmt.Sprintf("%s%d", resizeSlotPrefix, i), metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+		case err != nil:
+			return nil, fmt.Errorf("fetching resize slot %d: %v", i, err)
+		default:
+			slots[i] = lease
+		}
+	}
+	return slots, nil
+}
+
+// claimResizeSlot makes pvcKey the holder of slot i. Another controller
+// claiming the same slot first makes the create or update fail, so a slot is
+// never handed out twice.
+func (c *VolumeScalerController) claimResizeSlot(ctx context.Context, i int, lease *coordinationv1.Lease, pvcKey string, now time.Time) error {
+	leases := c.clientset.CoordinationV1().Leases(c.config.ResizeLeaseNamespace)
+	holder, duration, at := pvcKey, int32(resizeSlotDuration/time.Second), metav1.NewMicroTime(now)
+	if lease == nil {
+		_, err := leases.Create(ctx, &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s%d", resizeSlotPrefix, i),
+				Namespace: c.config.ResizeLeaseNamespace,
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity: &holder, LeaseDurationSeconds: &duration, AcquireTime: &at, RenewTime: &at,
+			},
+		}, metav1.CreateOptions{})
+		return err
+	}
+	lease = lease.DeepCopy()
+	if lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != pvcKey {
+		lease.Spec.HolderIdentity, lease.Spec.AcquireTime = &holder, &at
+	}
+	lease.Spec.LeaseDurationSeconds, lease.Spec.RenewTime = &duration, &at
+	_, err := leases.Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
+
+// acquireResizeSlot reports whether the PVC may start a resize under the
+// cluster-wide --max-concurrent-resizes limit. Volumes waiting for a slot are
+// queued by urgency; a slot goes to a volume only when fewer slots are taken
+// than its position in the queue allows. The position is published in
+// status.queuePosition while the volume waits.
+func (c *VolumeScalerController) acquireResizeSlot(ctx context.Context, invRef *corev1.ObjectReference, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, pvcKey string) (bool, error) {
+	if c.config.MaxConcurrentResizes <= 0 {
+		return true, c.patchQueuePosition(ctx, vsName, vsObj, 0)
+	}
+	now := time.Now().UTC()
+	slots, err := c.getResizeSlots(ctx)
+	if err != nil {
+		return false, err
+	}
+	var free []int
+	for i, lease := range slots {
+		if lease != nil && !slotFree(lease, now) && *lease.Spec.HolderIdentity == pvcKey {
+			return true, c.patchQueuePosition(ctx, vsName, vsObj, 0)
+		}
+		if slotFree(lease, now) {
+			free = append(free, i)
+		}
+	}
+
+	vsList, err := c.vsClient.AutoscalingV1alpha1().VolumeScalers("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return false, fmt.Errorf("listing VolumeScalers: %v", err)
+	}
+	queue := []v1alpha1.VolumeScaler{*vsObj}
+	if queue[0].Status.QueuedSince == "" {
+		queue[0].Status.QueuedSince = now.Format(time.RFC3339)
+	}
+	for _, item := range vsList.Items {
+		if item.Status.QueuedSince != "" && item.UID != vsObj.UID && c.queueEntryLive(&item, now) {
+			queue = append(queue, item)
+		}
+	}
+	sortResizeQueue(queue)
+	position := 0
+	for i := range queue {
+		if queue[i].UID == vsObj.UID {
+			position = i + 1
+			break
+		}
+	}
+
+	if position <= len(free) {
+		for _, i := range free {
+			err := c.claimResizeSlot(ctx, i, slots[i], pvcKey, now)
+			if err == nil {
+				return true, c.patchQueuePosition(ctx, vsName, vsObj, 0)
+			}
+			if !apierrors.IsConflict(err) && !apierrors.IsAlreadyExists(err) {
+				return false, fmt.Errorf("claiming resize slot %d: %v", i, err)
+			}
+		}
+	}
+
+	if vsObj.Status.QueuedSince == "" {
+		msg := fmt.Sprintf("Resize of PVC '%s' is queued at position %d; %d resizes are already in flight across the cluster.",
+			pvcKey, position, c.config.MaxConcurrentResizes-len(free))
+		c.recorder.Event(invRef, corev1.EventTypeNormal, eventReasonResizeQueued, msg)
+		fmt.Printf("[INFO] %s\n", msg)
+	}
+	if c.slotWaiters == nil {
+		c.slotWaiters = make(map[string]bool)
+		c.queued = make(map[string]types.NamespacedName)
+	}
+	c.slotWaiters[pvcKey], c.queued[pvcKey] = true, vsName
+	return false, c.patchQueuePosition(ctx, vsName, vsObj, position)
+}
+
+// renewResizeSlot extends the slot held by pvcKey while its resize runs.
+func (c *VolumeScalerController) renewResizeSlot(ctx context.Context, pvcKey string) error {
+	if c.config.MaxConcurrentResizes <= 0 {
+		return nil
+	}
+	slots, err := c.getResizeSlots(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	for i, lease := range slots {
+		if lease != nil && lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity == pvcKey {
+			if err := c.claimResizeSlot(ctx, i, lease, pvcKey, now); err != nil {
+				return fmt.Errorf("renewing resize slot %d: %v", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// releaseResizeSlot frees the slot held by pvcKey once its resize finished.
+func (c *VolumeScalerController) releaseResizeSlot(ctx context.Context, pvcKey string) error {
+	if c.config.MaxConcurrentResizes <= 0 {
+		return nil
+	}
+	slots, err := c.getResizeSlots(ctx)
+	if err != nil {
+		return err
+	}
+	for i, lease := range slots {
+		if lease == nil || lease.Spec.HolderIdentity == nil || *lease.Spec.HolderIdentity != pvcKey {
+			continue
+		}
+		lease = lease.DeepCopy()
+		lease.Spec.HolderIdentity = nil
+		if _, err := c.clientset.CoordinationV1().Leases(c.config.ResizeLeaseNamespace).
+			Update(ctx, lease, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("releasing resize slot %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// queueEntryLive reports whether the queue entry of vsObj is still refreshed
+// by its controller. An entry whose volume left its node, or whose controller
+// is gone, stops being refreshed and no longer holds back other volumes.
+func (c *VolumeScalerController) queueEntryLive(vsObj *v1alpha1.VolumeScaler, now time.Time) bool {
+	heartbeat := vsObj.Status.QueueHeartbeat
+	if heartbeat == "" {
+		heartbeat = vsObj.Status.QueuedSince
+	}
+	t, err := time.Parse(time.RFC3339, heartbeat)
+	return err == nil && now.Sub(t) <= 2*c.config.PollInterval
+}
+
+// leaveResizeQueue removes the queue entries of PVCs that stopped reporting
+// usage on this node, whose loops no longer refresh or clear them.
+func (c *VolumeScalerController) leaveResizeQueue(ctx context.Context, pvcUsageMap map[string]*PVCUsageInfo) {
+	for pvcKey, vsName := range c.queued {
+		if _, ok := pvcUsageMap[pvcKey]; ok {
+			continue
+		}
+		patch := []byte(`{"status":{"queuePosition":null,"queuedSince":null,"queueHeartbeat":null}}`)
+		_, err := c.vsClient.AutoscalingV1alpha1().VolumeScalers(vsName.Namespace).
+			Patch(ctx, vsName.Name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+		if err != nil && !apierrors.IsNotFound(err) {
+			fmt.Printf("[WARN] removing PVC '%s' from the resize queue: %v\n", pvcKey, err)
+			continue
+		}
+		delete(c.queued, pvcKey)
+	}
+}
+
+// patchQueuePosition publishes the position of the VolumeScaler in the resize
+// queue and refreshes its heartbeat, or removes it from the queue when
+// position is 0.
+func (c *VolumeScalerController) patchQueuePosition(ctx context.Context, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, position int) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	since, heartbeat := "", ""
+	if position > 0 {
+		since, heartbeat = vsObj.Status.QueuedSince, now
+		if since == "" {
+			since = now
+		}
+	} else if vsObj.Status.QueuedSince == "" && vsObj.Status.QueuePosition == 0 && vsObj.Status.QueueHeartbeat == "" {
+		return nil
+	}
+	status := map[string]interface{}{"queuePosition": nil, "queuedSince": nil, "queueHeartbeat": nil}
+	if position > 0 {
+		status["queuePosition"], status["queuedSince"], status["queueHeartbeat"] = position, since, heartbeat
+	}
+	patch, err := json.Marshal(map[string]interface{}{"status": status})
+	if err != nil {
+		return fmt.Errorf("encoding queue position patch: %v", err)
+	}
+	_, err = c.vsClient.AutoscalingV1alpha1().VolumeScalers(vsName.Namespace).
+		Patch(ctx, vsName.Name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+	if err != nil {
+		return fmt.Errorf("patching queue position: %v", err)
+	}
+	vsObj.Status.QueuePosition, vsObj.Status.QueuedSince, vsObj.Status.QueueHeartbeat = int32(position), since, heartbeat
+	return nil
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+// holdSlot stores resize slot i as held by holder, last renewed ago.
+func (f *scalerFixture) holdSlot(t *testing.T, i int, holder string, ago time.Duration) {
+	t.Helper()
+	duration, renewed := int32(resizeSlotDuration/time.Second), metav1.NewMicroTime(time.Now().Add(-ago))
+	lease := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s%d", resizeSlotPrefix, i), Namespace: "default"},
+		Spec:       coordinationv1.LeaseSpec{HolderIdentity: &holder, LeaseDurationSeconds: &duration, RenewTime: &renewed},
+	}
+	if _, err := f.clientset.CoordinationV1().Leases("default").Create(context.TODO(), lease, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create Lease: %v", err)
+	}
+}
+
+func (f *scalerFixture) slotHolder(t *testing.T, i int) string {
+	t.Helper()
+	lease, err := f.clientset.CoordinationV1().Leases("default").Get(context.TODO(), fmt.Sprintf("%s%d", resizeSlotPrefix, i), metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get Lease: %v", err)
+	}
+	if lease.Spec.HolderIdentity == nil {
+		return ""
+	}
+	return *lease.Spec.HolderIdentity
+}
+
+func TestSortResizeQueue(t *testing.T) {
+	scaler := func(name string, usage int, usedGi, sizeGi, ratePerHour string) v1alpha1.VolumeScaler {
+		return v1alpha1.VolumeScaler{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Status: v1alpha1.VolumeScalerStatus{
+				CurrentUsagePercent: usage, CurrentUsedGi: usedGi, CurrentSizeGi: sizeGi, GrowthRatePerHour: ratePerHour,
+			},
+		}
+	}
+	queue := []v1alpha1.VolumeScaler{
+		scaler("idle-high", 95, "95.0Gi", "100Gi", ""),
+		scaler("slow", 80, "80.0Gi", "100Gi", "1.00Gi"),  // 20h to full
+		scaler("fast", 75, "75.0Gi", "100Gi", "10.00Gi"), // 2.5h to full
+		scaler("idle-low", 85, "85.0Gi", "100Gi", ""),
+	}
+	sortResizeQueue(queue)
+
+	var got []string
+	for _, vs := range queue {
+		got = append(got, vs.Name)
+	}
+	if want := "fast,slow,idle-high,idle-low"; strings.Join(got, ",") != want {
+		t.Errorf("sortResizeQueue() = %v, want %s", got, want)
+	}
+}
+
+func TestResizeSlots_ClaimedAndReleased(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {})
+	f.controller.config.MaxConcurrentResizes = 1
+
+	f.reconcile(t)
+	if got := f.pvcSize(t); got != "7Gi" {
+		t.Fatalf("PVC size = %s, want 7Gi with a free slot", got)
+	}
+	if got := f.slotHolder(t, 0); got != "default/data" {
+		t.Fatalf("slot holder = %q, want default/data", got)
+	}
+
+	f.finishResize(t)
+	f.reconcile(t)
+	if got := f.slotHolder(t, 0); got != "" {
+		t.Errorf("slot holder = %q after the resize finished, want it released", got)
+	}
+}
+
+func TestResizeSlots_QueuesWhenFull(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {})
+	f.controller.config.MaxConcurrentResizes = 1
+	f.holdSlot(t, 0, "other/pvc", time.Minute)
+
+	f.reconcile(t)
+	if got := f.pvcSize(t); got != "5Gi" {
+		t.Fatalf("PVC size = %s, want 5Gi while every slot is taken", got)
+	}
+	st := f.status(t)
+	if st.QueuePosition != 1 || st.QueuedSince == "" {
+		t.Errorf("queuePosition = %d, queuedSince = %q; want position 1 and a queue time", st.QueuePosition, st.QueuedSince)
+	}
+	if events := strings.Join(drainEvents(f.recorder), "\n"); !strings.Contains(events, eventReasonResizeQueued) {
+		t.Errorf("events = %q, want %s", events, eventReasonResizeQueued)
+	}
+}
+
+func TestResizeSlots_ReclaimsExpiredSlot(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		vs.Status.QueuePosition, vs.Status.QueuedSince = 1, time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	})
+	f.controller.config.MaxConcurrentResizes = 1
+	f.holdSlot(t, 0, "other/pvc", time.Hour)
+
+	f.reconcile(t)
+	if got := f.pvcSize(t); got != "7Gi" {
+		t.Fatalf("PVC size = %s, want 7Gi once the stale slot is reclaimed", got)
+	}
+	if st := f.status(t); st.QueuePosition != 0 || st.QueuedSince != "" {
+		t.Errorf("queuePosition = %d, queuedSince = %q; want the queue entry cleared", st.QueuePosition, st.QueuedSince)
+	}
+}
+
+func TestResizeSlots_LeavesQueueWhenNotWaiting(t *testing.T) {
+	for name, mutate := range map[string]func(vs *v1alpha1.VolumeScaler){
+		"suspended": func(vs *v1alpha1.VolumeScaler) { vs.Spec.Suspend = true },
+		"observe":   func(vs *v1alpha1.VolumeScaler) { vs.Spec.Mode = modeObserve },
+	} {
+		t.Run(name, func(t *testing.T) {
+			f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {
+				mutate(vs)
+				vs.Status.QueuePosition, vs.Status.QueuedSince = 1, time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+			})
+			f.controller.config.MaxConcurrentResizes = 1
+			f.holdSlot(t, 0, "other/pvc", time.Hour)
+
+			f.reconcile(t)
+			if st := f.status(t); st.QueuePosition != 0 || st.QueuedSince != "" {
+				t.Errorf("queuePosition = %d, queuedSince = %q; want the queue entry cleared", st.QueuePosition, st.QueuedSince)
+			}
+		})
+	}
+}
+
+func TestResizeSlots_MoreUrgentVolumeGoesFirst(t *testing.T) {
+	urgent := &v1alpha1.VolumeScaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "urgent", Namespace: "other", UID: "urgent-uid"},
+		Spec:       v1alpha1.VolumeScalerSpec{PVCName: "urgent"},
+		Status: v1alpha1.VolumeScalerStatus{
+			CurrentUsagePercent: 95, CurrentUsedGi: "95.0Gi", CurrentSizeGi: "100Gi", GrowthRatePerHour: "10.00Gi",
+			QueuePosition: 1, QueuedSince: time.Now().UTC().Format(time.RFC3339),
+		},
+	}
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {}, urgent)
+	f.controller.config.MaxConcurrentResizes = 1
+
+	f.reconcile(t)
+	if got := f.pvcSize(t); got != "5Gi" {
+		t.Fatalf("PVC size = %s, want 5Gi while a more urgent volume waits for the only slot", got)
+	}
+	if st := f.status(t); st.QueuePosition != 2 {
+		t.Errorf("queuePosition = %d, want 2", st.QueuePosition)
+	}
+}
+
+func TestResizeSlots_SkipsStaleQueueEntries(t *testing.T) {
+	stale := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	gone := &v1alpha1.VolumeScaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "gone", Namespace: "other", UID: "gone-uid"},
+		Spec:       v1alpha1.VolumeScalerSpec{PVCName: "gone"},
+		Status: v1alpha1.VolumeScalerStatus{
+			CurrentUsagePercent: 99, CurrentUsedGi: "99.0Gi", CurrentSizeGi: "100Gi", GrowthRatePerHour: "10.00Gi",
+			QueuePosition: 1, QueuedSince: stale, QueueHeartbeat: stale,
+		},
+	}
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {}, gone)
+	f.controller.config.MaxConcurrentResizes = 1
+
+	f.reconcile(t)
+	if got := f.pvcSize(t); got != "7Gi" {
+		t.Fatalf("PVC size = %s, want 7Gi when the only other queue entry went stale", got)
+	}
+}
+
+func TestResizeSlots_LeavesQueueWhenPVCLeavesNode(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {})
+	f.controller.config.MaxConcurrentResizes = 1
+	f.holdSlot(t, 0, "other/pvc", time.Minute)
+
+	f.reconcile(t)
+	if st := f.status(t); st.QueuePosition != 1 || st.QueueHeartbeat == "" {
+		t.Fatalf("queuePosition = %d, queueHeartbeat = %q; want a refreshed entry at 1", st.QueuePosition, st.QueueHeartbeat)
+	}
+
+	f.controller.leaveResizeQueue(context.TODO(), map[string]*PVCUsageInfo{})
+	if st := f.status(t); st.QueuePosition != 0 || st.QueuedSince != "" || st.QueueHeartbeat != "" {
+		t.Errorf("status = %+v; want the queue entry cleared once the PVC left the node", st)
+	}
+}
+
+func TestResizeSlots_UnlimitedByDefault(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {})
+
+	f.reconcile(t)
+	if got := f.pvcSize(t); got != "7Gi" {
+		t.Fatalf("PVC size = %s, want 7Gi", got)
+	}
+	leases, err := f.clientset.CoordinationV1().Leases("default").List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Failed to list Leases: %v", err)
+	}
+	if len(leases.Items) != 0 {
+		t.Errorf("got %d Leases, want none without --max-concurrent-resizes", len(leases.Items))
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+const (
+	// Condition types reported in VolumeScaler status
+	conditionTypeConflict = "Conflict"
+)
+
+// patchConditions writes the full conditions list to the VolumeScaler status.
+// A merge patch replaces the list as a whole, so callers always pass the
+// complete, updated set.
+func (c *VolumeScalerController) patchConditions(ctx context.Context, vsName types.NamespacedName, conditions []metav1.Condition) error {
+	if conditions == nil {
+		conditions = []metav1.Condition{}
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{"conditions": conditions},
+	})
+	if err != nil {
+		return fmt.Errorf("encoding conditions patch: %v", err)
+	}
+	_, err = c.vsClient.AutoscalingV1alpha1().VolumeScalers(vsName.Namespace).
+		Patch(ctx, vsName.Name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+	if err != nil {
+		return fmt.Errorf("patching conditions for '%s/%s': %v", vsName.Namespace, vsName.Name, err)
+	}
+	return nil
+}
+
+// setCondition adds or updates a status condition on the VolumeScaler and
+// patches it only when its status, reason or message changed.
+func (c *VolumeScalerController) setCondition(ctx context.Context, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, cond metav1.Condition) error {
+	if conditionMatches(vsObj.Status.Conditions, cond) {
+		return nil
+	}
+	cond.ObservedGeneration = vsObj.Generation
+	meta.SetStatusCondition(&vsObj.Status.Conditions, cond)
+	return c.patchConditions(ctx, vsName, vsObj.Status.Conditions)
+}
+
+// clearCondition removes a status condition from the VolumeScaler if present.
+func (c *VolumeScalerController) clearCondition(ctx context.Context, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, condType string) error {
+	if meta.FindStatusCondition(vsObj.Status.Conditions, condType) == nil {
+		return nil
+	}
+	meta.RemoveStatusCondition(&vsObj.Status.Conditions, condType)
+	return c.patchConditions(ctx, vsName, vsObj.Status.Conditions)
+}
+
+// conditionMatches reports whether conditions already hold cond with the same
+// status, reason and message.
+func conditionMatches(conditions []metav1.Condition, cond metav1.Condition) bool {
+	for _, existing := range conditions {
+		if existing.Type == cond.Type {
+			return existing.Status == cond.Status && existing.Reason == cond.Reason && existing.Message == cond.Message
+		}
+	}
+	return false
+}
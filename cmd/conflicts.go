@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+const (
+	// Event and condition reasons for VolumeScalers targeting the same PVC
+	eventReasonConflict         = "Conflict"
+	conditionReasonConflictWon  = "ConflictWon"
+	conditionReasonConflictLost = "ConflictLost"
+)
+
+// scalerCandidate pairs a VolumeScaler with its namespaced name.
+type scalerCandidate struct {
+	obj  *v1alpha1.VolumeScaler
+	name types.NamespacedName
+}
+
+// sortScalerCandidates orders VolumeScalers targeting the same PVC so that the
+// first entry is the one that manages it: highest spec.priority first, then the
+// oldest object, then the lexically smallest name as a final tie-breaker.
+func sortScalerCandidates(cands []scalerCandidate) {
+	sort.SliceStable(cands, func(i, j int) bool {
+		a, b := cands[i].obj, cands[j].obj
+		if a.Spec.Priority != b.Spec.Priority {
+			return a.Spec.Priority > b.Spec.Priority
+		}
+		ta, tb := a.CreationTimestamp.Time, b.CreationTimestamp.Time
+		if !ta.Equal(tb) {
+			return ta.Before(tb)
+		}
+		return cands[i].name.Name < cands[j].name.Name
+	})
+}
+
+// candidateNames lists the names of all candidates except the one at skip.
+func candidateNames(cands []scalerCandidate, skip int) string {
+	var names []string
+	for i, cand := range cands {
+		if i != skip {
+			names = append(names, "'"+cand.name.Name+"'")
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// reportScalerConflict records a Conflict condition on every VolumeScaler that
+// targets the same PVC, naming the others. cands must already be sorted so the
+// first entry is the winner. With a single candidate any stale condition is cleared.
+func (c *VolumeScalerController) reportScalerConflict(ctx context.Context, pvcKey string, cands []scalerCandidate) {
+	if len(cands) == 1 {
+		if err := c.clearCondition(ctx, cands[0].name, cands[0].obj, conditionTypeConflict); err != nil {
+			fmt.Printf("[WARN] %v\n", err)
+		}
+		return
+	}
+
+	for i, cand := range cands {
+		cond := metav1.Condition{
+			Type:   conditionTypeConflict,
+			Status: metav1.ConditionTrue,
+		}
+		if i == 0 {
+			cond.Reason = conditionReasonConflictWon
+			cond.Message = fmt.Sprintf("PVC '%s' is also targeted by VolumeScaler %s; this VolumeScaler manages it.",
+				pvcKey, candidateNames(cands, i))
+		} else {
+			cond.Reason = conditionReasonConflictLost
+			cond.Message = fmt.Sprintf("PVC '%s' is also targeted by VolumeScaler %s; '%s' manages it and this VolumeScaler is ignored.",
+				pvcKey, candidateNames(cands, i), cands[0].name.Name)
+		}
+
+		changed := !conditionMatches(cand.obj.Status.Conditions, cond)
+		if err := c.setCondition(ctx, cand.name, cand.obj, cond); err != nil {
+			fmt.Printf("[WARN] %v\n", err)
+			continue
+		}
+		if changed {
+			c.recorder.Event(makeInvolvedObjectRef(cand.name, cand.obj), corev1.EventTypeWarning, eventReasonConflict, cond.Message)
+			fmt.Printf("[WARNING] %s\n", cond.Message)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	kfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	vsfake "github.com/zghanem/sample-volumeScaler/pkg/generated/clientset/versioned/fake"
+)
+
+func TestSortScalerCandidates(t *testing.T) {
+	older := metav1.NewTime(time.Now().Add(-time.Hour))
+	newer := metav1.NewTime(time.Now())
+
+	candidate := func(name string, priority int32, created metav1.Time) scalerCandidate {
+		return scalerCandidate{
+			obj: &v1alpha1.VolumeScaler{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", CreationTimestamp: created},
+				Spec:       v1alpha1.VolumeScalerSpec{PVCName: "data", Priority: priority},
+			},
+			name: types.NamespacedName{Namespace: "default", Name: name},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		cands    []scalerCandidate
+		expected string
+	}{
+		{
+			name:     "oldest wins",
+			cands:    []scalerCandidate{candidate("b", 0, newer), candidate("a", 0, older)},
+			expected: "a",
+		},
+		{
+			name:     "priority beats age",
+			cands:    []scalerCandidate{candidate("old", 0, older), candidate("new", 10, newer)},
+			expected: "new",
+		},
+		{
+			name:     "name breaks ties",
+			cands:    []scalerCandidate{candidate("zeta", 0, older), candidate("alpha", 0, older)},
+			expected: "alpha",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sortScalerCandidates(tt.cands)
+			if got := tt.cands[0].name.Name; got != tt.expected {
+				t.Errorf("winner = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReconcileLoop_ConflictingScalers(t *testing.T) {
+	originalFetch := fetchNodePVCUsageFunc
+	defer func() { fetchNodePVCUsageFunc = originalFetch }()
+
+	fetchNodePVCUsageFunc = func(ctx context.Context, clientset kubernetes.Interface, nodeName string) (map[string]*PVCUsageInfo, error) {
+		return map[string]*PVCUsageInfo{
+			"default/shared-pvc": {UsedBytes: 4 * 1024 * 1024 * 1024, CapacityBytes: 5 * 1024 * 1024 * 1024, UsagePercent: 80, UsedGi: 4.0},
+		}, nil
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared-pvc", Namespace: "default"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")},
+			},
+		},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")},
+		},
+	}
+
+	winner := &v1alpha1.VolumeScaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "first", Namespace: "default", CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour))},
+		Spec:       v1alpha1.VolumeScalerSpec{PVCName: "shared-pvc", Threshold: "70%", Scale: "2Gi", ScaleType: "fixed", MaxSize: "10Gi"},
+	}
+	loser := &v1alpha1.VolumeScaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "second", Namespace: "default", CreationTimestamp: metav1.NewTime(time.Now())},
+		Spec:       v1alpha1.VolumeScalerSpec{PVCName: "shared-pvc", Threshold: "70%", Scale: "4Gi", ScaleType: "fixed", MaxSize: "10Gi"},
+	}
+
+	clientset := kfake.NewSimpleClientset(pvc)
+	vsClient := vsfake.NewSimpleClientset(loser, winner)
+	controller := NewVolumeScalerController(NewDefaultConfig(), clientset, vsClient, record.NewFakeRecorder(100))
+	t.Setenv("NODE_NAME_ENV", "test-node")
+
+	if err := controller.reconcileLoop(context.Background()); err != nil {
+		t.Fatalf("reconcileLoop() error = %v", err)
+	}
+
+	updated, _ := clientset.CoreV1().PersistentVolumeClaims("default").Get(context.Background(), "shared-pvc", metav1.GetOptions{})
+	if size := updated.Spec.Resources.Requests[corev1.ResourceStorage]; size.String() != "7Gi" {
+		t.Errorf("Expected the oldest VolumeScaler to scale the PVC to 7Gi, got %s", size.String())
+	}
+
+	for name, reason := range map[string]string{"first": conditionReasonConflictWon, "second": conditionReasonConflictLost} {
+		vs, err := vsClient.AutoscalingV1alpha1().VolumeScalers("default").Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Failed to get VolumeScaler %s: %v", name, err)
+		}
+		cond := meta.FindStatusCondition(vs.Status.Conditions, conditionTypeConflict)
+		if cond == nil {
+			t.Errorf("Expected Conflict condition on %s", name)
+			continue
+		}
+		if cond.Reason != reason {
+			t.Errorf("Conflict reason on %s = %s, want %s", name, cond.Reason, reason)
+		}
+	}
+}
@@ -8,38 +8,32 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
-	dfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes"
 	kfake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/record"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	vsfake "github.com/zghanem/sample-volumeScaler/pkg/generated/clientset/versioned/fake"
 )
 
 func TestReconcilePVC(t *testing.T) {
 	// Create fake clients
 	clientset := kfake.NewSimpleClientset()
-	dynClient := dfake.NewSimpleDynamicClient(runtime.NewScheme())
+	vsClient := vsfake.NewSimpleClientset()
 	recorder := record.NewFakeRecorder(100)
 
 	controller := &VolumeScalerController{
 		config:    NewDefaultConfig(),
 		clientset: clientset,
-		dynClient: dynClient,
+		vsClient:  vsClient,
 		recorder:  recorder,
-		gvr: schema.GroupVersionResource{
-			Group:    "autoscaling.storage.k8s.io",
-			Version:  "v1alpha1",
-			Resource: "volumescalers",
-		},
 	}
 
 	tests := []struct {
 		name          string
 		pvc           *corev1.PersistentVolumeClaim
-		vs            *VolumeScaler
+		vs            *v1alpha1.VolumeScaler
 		vsName        types.NamespacedName
 		usageInfo     *PVCUsageInfo
 		expectError   bool
@@ -59,10 +53,10 @@ func TestReconcilePVC(t *testing.T) {
 					Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")},
 				},
 			},
-			vs: &VolumeScaler{
+			vs: &v1alpha1.VolumeScaler{
 				TypeMeta:   metav1.TypeMeta{APIVersion: "autoscaling.storage.k8s.io/v1alpha1", Kind: "VolumeScaler"},
 				ObjectMeta: metav1.ObjectMeta{Name: "test-vs", Namespace: "default"},
-				Spec:       VolumeScalerSpec{PVCName: "test-pvc", Threshold: "70%", Scale: "2Gi", ScaleType: "fixed", CooldownPeriod: "5m", MaxSize: "10Gi"},
+				Spec:       v1alpha1.VolumeScalerSpec{PVCName: "test-pvc", Threshold: "70%", Scale: "2Gi", ScaleType: "fixed", CooldownPeriod: "5m", MaxSize: "10Gi"},
 			},
 			vsName:       types.NamespacedName{Namespace: "default", Name: "test-vs"},
 			usageInfo:    &PVCUsageInfo{UsedBytes: 4 * 1024 * 1024 * 1024, CapacityBytes: 5 * 1024 * 1024 * 1024, AvailableBytes: 1 * 1024 * 1024 * 1024, UsagePercent: 80, UsedGi: 4.0},
@@ -81,10 +75,10 @@ func TestReconcilePVC(t *testing.T) {
 					Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
 				},
 			},
-			vs: &VolumeScaler{
+			vs: &v1alpha1.VolumeScaler{
 				TypeMeta:   metav1.TypeMeta{APIVersion: "autoscaling.storage.k8s.io/v1alpha1", Kind: "VolumeScaler"},
 				ObjectMeta: metav1.ObjectMeta{Name: "test-vs-max", Namespace: "default"},
-				Spec:       VolumeScalerSpec{PVCName: "test-pvc-max", Threshold: "70%", Scale: "2Gi", ScaleType: "fixed", CooldownPeriod: "5m", MaxSize: "10Gi"},
+				Spec:       v1alpha1.VolumeScalerSpec{PVCName: "test-pvc-max", Threshold: "70%", Scale: "2Gi", ScaleType: "fixed", CooldownPeriod: "5m", MaxSize: "10Gi"},
 			},
 			vsName:        types.NamespacedName{Namespace: "default", Name: "test-vs-max"},
 			usageInfo:     &PVCUsageInfo{UsedBytes: 8 * 1024 * 1024 * 1024, CapacityBytes: 10 * 1024 * 1024 * 1024, AvailableBytes: 2 * 1024 * 1024 * 1024, UsagePercent: 80, UsedGi: 8.0},
@@ -103,10 +97,10 @@ func TestReconcilePVC(t *testing.T) {
 					Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")},
 				},
 			},
-			vs: &VolumeScaler{
+			vs: &v1alpha1.VolumeScaler{
 				TypeMeta:   metav1.TypeMeta{APIVersion: "autoscaling.storage.k8s.io/v1alpha1", Kind: "VolumeScaler"},
 				ObjectMeta: metav1.ObjectMeta{Name: "test-vs-invalid", Namespace: "default"},
-				Spec:       VolumeScalerSpec{PVCName: "test-pvc-invalid", Threshold: "invalid%", Scale: "2Gi", ScaleType: "fixed", CooldownPeriod: "5m", MaxSize: "10Gi"},
+				Spec:       v1alpha1.VolumeScalerSpec{PVCName: "test-pvc-invalid", Threshold: "invalid%", Scale: "2Gi", ScaleType: "fixed", CooldownPeriod: "5m", MaxSize: "10Gi"},
 			},
 			vsName:      types.NamespacedName{Namespace: "default", Name: "test-vs-invalid"},
 			usageInfo:   &PVCUsageInfo{UsedBytes: 4 * 1024 * 1024 * 1024, CapacityBytes: 5 * 1024 * 1024 * 1024, UsagePercent: 80, UsedGi: 4.0},
@@ -125,10 +119,10 @@ func TestReconcilePVC(t *testing.T) {
 					Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")},
 				},
 			},
-			vs: &VolumeScaler{
+			vs: &v1alpha1.VolumeScaler{
 				TypeMeta:   metav1.TypeMeta{APIVersion: "autoscaling.storage.k8s.io/v1alpha1", Kind: "VolumeScaler"},
 				ObjectMeta: metav1.ObjectMeta{Name: "test-vs-invalid-max", Namespace: "default"},
-				Spec:       VolumeScalerSpec{PVCName: "test-pvc-invalid-max", Threshold: "70%", Scale: "2Gi", ScaleType: "fixed", CooldownPeriod: "5m", MaxSize: "invalid"},
+				Spec:       v1alpha1.VolumeScalerSpec{PVCName: "test-pvc-invalid-max", Threshold: "70%", Scale: "2Gi", ScaleType: "fixed", CooldownPeriod: "5m", MaxSize: "invalid"},
 			},
 			vsName:      types.NamespacedName{Namespace: "default", Name: "test-vs-invalid-max"},
 			usageInfo:   &PVCUsageInfo{UsedBytes: 4 * 1024 * 1024 * 1024, CapacityBytes: 5 * 1024 * 1024 * 1024, UsagePercent: 80, UsedGi: 4.0},
@@ -147,11 +141,11 @@ func TestReconcilePVC(t *testing.T) {
 					Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")},
 				},
 			},
-			vs: &VolumeScaler{
+			vs: &v1alpha1.VolumeScaler{
 				TypeMeta:   metav1.TypeMeta{APIVersion: "autoscaling.storage.k8s.io/v1alpha1", Kind: "VolumeScaler"},
 				ObjectMeta: metav1.ObjectMeta{Name: "test-vs-resize", Namespace: "default"},
-				Spec:       VolumeScalerSpec{PVCName: "test-pvc-resize", Threshold: "70%", Scale: "2Gi", ScaleType: "fixed", CooldownPeriod: "5m", MaxSize: "10Gi"},
-				Status:     VolumeScalerStatus{ResizeInProgress: true},
+				Spec:       v1alpha1.VolumeScalerSpec{PVCName: "test-pvc-resize", Threshold: "70%", Scale: "2Gi", ScaleType: "fixed", CooldownPeriod: "5m", MaxSize: "10Gi"},
+				Status:     v1alpha1.VolumeScalerStatus{ResizeInProgress: true},
 			},
 			vsName:    types.NamespacedName{Namespace: "default", Name: "test-vs-resize"},
 			usageInfo: &PVCUsageInfo{UsedBytes: 4 * 1024 * 1024 * 1024, CapacityBytes: 5 * 1024 * 1024 * 1024, UsagePercent: 80, UsedGi: 4.0},
@@ -169,11 +163,11 @@ func TestReconcilePVC(t *testing.T) {
 					Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")},
 				},
 			},
-			vs: &VolumeScaler{
+			vs: &v1alpha1.VolumeScaler{
 				TypeMeta:   metav1.TypeMeta{APIVersion: "autoscaling.storage.k8s.io/v1alpha1", Kind: "VolumeScaler"},
 				ObjectMeta: metav1.ObjectMeta{Name: "test-vs-cooldown", Namespace: "default"},
-				Spec:       VolumeScalerSpec{PVCName: "test-pvc-cooldown", Threshold: "70%", Scale: "2Gi", ScaleType: "fixed", CooldownPeriod: "10m", MaxSize: "10Gi"},
-				Status:     VolumeScalerStatus{ScaledAt: time.Now().Add(-5 * time.Minute).Format(time.RFC3339)},
+				Spec:       v1alpha1.VolumeScalerSpec{PVCName: "test-pvc-cooldown", Threshold: "70%", Scale: "2Gi", ScaleType: "fixed", CooldownPeriod: "10m", MaxSize: "10Gi"},
+				Status:     v1alpha1.VolumeScalerStatus{ScaledAt: time.Now().Add(-5 * time.Minute).Format(time.RFC3339)},
 			},
 			vsName:    types.NamespacedName{Namespace: "default", Name: "test-vs-cooldown"},
 			usageInfo: &PVCUsageInfo{UsedBytes: 4 * 1024 * 1024 * 1024, CapacityBytes: 5 * 1024 * 1024 * 1024, UsagePercent: 80, UsedGi: 4.0},
@@ -182,21 +176,12 @@ func TestReconcilePVC(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			vsUnstructured, err := runtime.DefaultUnstructuredConverter.ToUnstructured(tt.vs)
-			if err != nil {
-				t.Fatalf("Failed to convert VolumeScaler to unstructured: %v", err)
-			}
-
-			_, err = clientset.CoreV1().PersistentVolumeClaims(tt.pvc.Namespace).Create(context.TODO(), tt.pvc, metav1.CreateOptions{})
+			_, err := clientset.CoreV1().PersistentVolumeClaims(tt.pvc.Namespace).Create(context.TODO(), tt.pvc, metav1.CreateOptions{})
 			if err != nil {
 				t.Fatalf("Failed to create PVC: %v", err)
 			}
 
-			_, err = dynClient.Resource(controller.gvr).Namespace(tt.vs.Namespace).Create(
-				context.TODO(),
-				&unstructured.Unstructured{Object: vsUnstructured},
-				metav1.CreateOptions{},
-			)
+			_, err = vsClient.AutoscalingV1alpha1().VolumeScalers(tt.vs.Namespace).Create(context.TODO(), tt.vs, metav1.CreateOptions{})
 			if err != nil {
 				t.Fatalf("Failed to create VolumeScaler: %v", err)
 			}
@@ -220,17 +205,11 @@ func TestReconcilePVC(t *testing.T) {
 				}
 			}
 
-			result, err := dynClient.Resource(controller.gvr).Namespace(tt.vs.Namespace).Get(context.TODO(), tt.vs.Name, metav1.GetOptions{})
+			updatedVS, err := vsClient.AutoscalingV1alpha1().VolumeScalers(tt.vs.Namespace).Get(context.TODO(), tt.vs.Name, metav1.GetOptions{})
 			if err != nil {
 				t.Fatalf("Failed to get updated VolumeScaler: %v", err)
 			}
 
-			var updatedVS VolumeScaler
-			err = runtime.DefaultUnstructuredConverter.FromUnstructured(result.Object, &updatedVS)
-			if err != nil {
-				t.Fatalf("Failed to convert unstructured to VolumeScaler: %v", err)
-			}
-
 			if tt.expectMaxSize && !updatedVS.Status.ReachedMaxSize {
 				t.Error("Expected VolumeScaler to be marked as reached max size, but it wasn't")
 			}
@@ -241,15 +220,10 @@ func TestReconcilePVC(t *testing.T) {
 func TestNewVolumeScalerController(t *testing.T) {
 	config := NewDefaultConfig()
 	clientset := kfake.NewSimpleClientset()
-	dynClient := dfake.NewSimpleDynamicClient(runtime.NewScheme())
+	vsClient := vsfake.NewSimpleClientset()
 	recorder := record.NewFakeRecorder(100)
-	gvr := schema.GroupVersionResource{
-		Group:    "autoscaling.storage.k8s.io",
-		Version:  "v1alpha1",
-		Resource: "volumescalers",
-	}
 
-	controller := NewVolumeScalerController(config, clientset, dynClient, recorder, gvr)
+	controller := NewVolumeScalerController(config, clientset, vsClient, recorder)
 
 	if controller == nil {
 		t.Fatal("Expected non-nil controller")
@@ -260,15 +234,12 @@ func TestNewVolumeScalerController(t *testing.T) {
 	if controller.clientset != clientset {
 		t.Error("Expected clientset to match")
 	}
-	if controller.dynClient != dynClient {
-		t.Error("Expected dynClient to match")
+	if controller.vsClient != vsClient {
+		t.Error("Expected vsClient to match")
 	}
 	if controller.recorder != recorder {
 		t.Error("Expected recorder to match")
 	}
-	if controller.gvr != gvr {
-		t.Error("Expected gvr to match")
-	}
 }
 
 func TestReconcileLoop_WithMockedUsage(t *testing.T) {
@@ -303,37 +274,27 @@ func TestReconcileLoop_WithMockedUsage(t *testing.T) {
 	}
 
 	// Create test VolumeScaler
-	vs := &VolumeScaler{
+	vs := &v1alpha1.VolumeScaler{
 		TypeMeta:   metav1.TypeMeta{APIVersion: "autoscaling.storage.k8s.io/v1alpha1", Kind: "VolumeScaler"},
 		ObjectMeta: metav1.ObjectMeta{Name: "test-vs", Namespace: "default"},
-		Spec:       VolumeScalerSpec{PVCName: "test-pvc", Threshold: "70%", Scale: "2Gi", ScaleType: "fixed", CooldownPeriod: "5m", MaxSize: "10Gi"},
-	}
-
-	unstr, err := runtime.DefaultUnstructuredConverter.ToUnstructured(vs)
-	if err != nil {
-		t.Fatalf("Failed to convert VolumeScaler to unstructured: %v", err)
+		Spec:       v1alpha1.VolumeScalerSpec{PVCName: "test-pvc", Threshold: "70%", Scale: "2Gi", ScaleType: "fixed", CooldownPeriod: "5m", MaxSize: "10Gi"},
 	}
 
 	clientset := kfake.NewSimpleClientset(pvc)
-	dynClient := dfake.NewSimpleDynamicClient(runtime.NewScheme(), &unstructured.Unstructured{Object: unstr})
+	vsClient := vsfake.NewSimpleClientset(vs)
 	recorder := record.NewFakeRecorder(100)
 
 	controller := &VolumeScalerController{
 		config:    NewDefaultConfig(),
 		clientset: clientset,
-		dynClient: dynClient,
+		vsClient:  vsClient,
 		recorder:  recorder,
-		gvr: schema.GroupVersionResource{
-			Group:    "autoscaling.storage.k8s.io",
-			Version:  "v1alpha1",
-			Resource: "volumescalers",
-		},
 	}
 
 	// Set NODE_NAME_ENV for the reconcile loop
 	t.Setenv("NODE_NAME_ENV", "test-node")
 
-	err = controller.reconcileLoop(context.Background())
+	err := controller.reconcileLoop(context.Background())
 	if err != nil {
 		t.Errorf("reconcileLoop() error = %v", err)
 	}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	"github.com/zghanem/sample-volumeScaler/api/v1beta1"
+)
+
+const (
+	// webhookConvertPath serves CRD conversion between VolumeScaler versions
+	webhookConvertPath = "/convert"
+)
+
+// serveConversion answers a ConversionReview by converting every object to the
+// desired API version.
+func (w *WebhookServer) serveConversion(rw http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxAdmissionBody))
+	if err != nil {
+		http.Error(rw, fmt.Sprintf("reading body: %v", err), http.StatusBadRequest)
+		return
+	}
+	review := &apiextensionsv1.ConversionReview{}
+	if err := json.Unmarshal(body, review); err != nil || review.Request == nil {
+		http.Error(rw, "malformed ConversionReview", http.StatusBadRequest)
+		return
+	}
+
+	resp := &apiextensionsv1.ConversionResponse{
+		UID:    review.Request.UID,
+		Result: metav1.Status{Status: metav1.StatusSuccess},
+	}
+	for _, obj := range review.Request.Objects {
+		converted, err := convertVolumeScaler(obj.Raw, review.Request.DesiredAPIVersion)
+		if err != nil {
+			resp.ConvertedObjects = nil
+			resp.Result = metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}
+			break
+		}
+		resp.ConvertedObjects = append(resp.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+	review.Request = nil
+	review.Response = resp
+
+	out, err := json.Marshal(review)
+	if err != nil {
+		http.Error(rw, fmt.Sprintf("encoding response: %v", err), http.StatusInternalServerError)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	_, _ = rw.Write(out)
+}
+
+// convertVolumeScaler converts a single serialized VolumeScaler to desiredAPIVersion.
+func convertVolumeScaler(raw []byte, desiredAPIVersion string) ([]byte, error) {
+	typeMeta := metav1.TypeMeta{}
+	if err := json.Unmarshal(raw, &typeMeta); err != nil {
+		return nil, fmt.Errorf("decoding object: %v", err)
+	}
+	if typeMeta.Kind != "VolumeScaler" {
+		return nil, fmt.Errorf("unsupported kind '%s'", typeMeta.Kind)
+	}
+	if typeMeta.APIVersion == desiredAPIVersion {
+		return raw, nil
+	}
+
+	alphaVersion := v1alpha1.SchemeGroupVersion.String()
+	betaVersion := v1beta1.SchemeGroupVersion.String()
+	switch {
+	case typeMeta.APIVersion == alphaVersion && desiredAPIVersion == betaVersion:
+		src := &v1alpha1.VolumeScaler{}
+		if err := json.Unmarshal(raw, src); err != nil {
+			return nil, fmt.Errorf("decoding %s VolumeScaler: %v", alphaVersion, err)
+		}
+		dst := &v1beta1.VolumeScaler{}
+		if err := v1beta1.ConvertFromV1alpha1(src, dst); err != nil {
+			return nil, err
+		}
+		dst.TypeMeta = metav1.TypeMeta{APIVersion: betaVersion, Kind: "VolumeScaler"}
+		return json.Marshal(dst)
+	case typeMeta.APIVersion == betaVersion && desiredAPIVersion == alphaVersion:
+		src := &v1beta1.VolumeScaler{}
+		if err := json.Unmarshal(raw, src); err != nil {
+			return nil, fmt.Errorf("decoding %s VolumeScaler: %v", betaVersion, err)
+		}
+		dst := &v1alpha1.VolumeScaler{}
+		if err := v1beta1.ConvertToV1alpha1(src, dst); err != nil {
+			return nil, err
+		}
+		dst.TypeMeta = metav1.TypeMeta{APIVersion: alphaVersion, Kind: "VolumeScaler"}
+		return json.Marshal(dst)
+	default:
+		return nil, fmt.Errorf("cannot convert VolumeScaler from '%s' to '%s'", typeMeta.APIVersion, desiredAPIVersion)
+	}
+}
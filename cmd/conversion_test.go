@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	"github.com/zghanem/sample-volumeScaler/api/v1beta1"
+	vsfake "github.com/zghanem/sample-volumeScaler/pkg/generated/clientset/versioned/fake"
+)
+
+// sendConversionReview posts objects to the conversion webhook and returns the
+// decoded response.
+func sendConversionReview(t *testing.T, handler http.Handler, desiredAPIVersion string, objects ...interface{}) *apiextensionsv1.ConversionResponse {
+	t.Helper()
+	review := apiextensionsv1.ConversionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apiextensions.k8s.io/v1", Kind: "ConversionReview"},
+		Request:  &apiextensionsv1.ConversionRequest{UID: "conv-1", DesiredAPIVersion: desiredAPIVersion},
+	}
+	for _, obj := range objects {
+		raw, err := json.Marshal(obj)
+		if err != nil {
+			t.Fatalf("Failed to encode object: %v", err)
+		}
+		review.Request.Objects = append(review.Request.Objects, runtime.RawExtension{Raw: raw})
+	}
+	body, _ := json.Marshal(review)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, webhookConvertPath, bytes.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Unexpected HTTP status %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var out apiextensionsv1.ConversionReview
+	if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if out.Response == nil || out.Response.UID != "conv-1" {
+		t.Fatalf("Expected response for request conv-1, got %+v", out.Response)
+	}
+	return out.Response
+}
+
+func TestConversion_V1alpha1ToV1beta1(t *testing.T) {
+	server := NewWebhookServer(NewDefaultConfig(), kfake.NewSimpleClientset(), vsfake.NewSimpleClientset())
+	alpha := &v1alpha1.VolumeScaler{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "autoscaling.storage.k8s.io/v1alpha1", Kind: "VolumeScaler"},
+		ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default"},
+		Spec:       v1alpha1.VolumeScalerSpec{PVCName: "data", Threshold: "70%", Scale: "2Gi", ScaleType: "fixed", CooldownPeriod: "10m", MaxSize: "50Gi"},
+		Status:     v1alpha1.VolumeScalerStatus{CurrentSizeGi: "10Gi", ResizeInProgress: true},
+	}
+
+	resp := sendConversionReview(t, server.Handler(), "autoscaling.storage.k8s.io/v1beta1", alpha)
+	if resp.Result.Status != metav1.StatusSuccess || len(resp.ConvertedObjects) != 1 {
+		t.Fatalf("Expected one converted object, got %+v", resp)
+	}
+	beta := &v1beta1.VolumeScaler{}
+	if err := json.Unmarshal(resp.ConvertedObjects[0].Raw, beta); err != nil {
+		t.Fatalf("Failed to decode converted object: %v", err)
+	}
+
+	if beta.APIVersion != "autoscaling.storage.k8s.io/v1beta1" {
+		t.Errorf("apiVersion = %s, want autoscaling.storage.k8s.io/v1beta1", beta.APIVersion)
+	}
+	spec := beta.Spec
+	if spec.ThresholdPercent != 70 {
+		t.Errorf("thresholdPercent = %d, want 70", spec.ThresholdPercent)
+	}
+	if spec.Scale.Policy != v1beta1.ScalePolicyFixed || spec.Scale.Increment == nil || spec.Scale.Increment.Cmp(resource.MustParse("2Gi")) != 0 {
+		t.Errorf("scale = %+v, want a Fixed increment of 2Gi", spec.Scale)
+	}
+	if spec.CooldownPeriod.Duration != 10*time.Minute {
+		t.Errorf("cooldownPeriod = %s, want 10m", spec.CooldownPeriod.Duration)
+	}
+	if spec.MaxSize.Cmp(resource.MustParse("50Gi")) != 0 {
+		t.Errorf("maxSize = %s, want 50Gi", spec.MaxSize.String())
+	}
+	if !beta.Status.ResizeInProgress || beta.Status.CurrentSizeGi != "10Gi" {
+		t.Errorf("Expected status to be carried over, got %+v", beta.Status)
+	}
+	if _, ok := beta.Annotations[v1beta1.V1alpha1SpecAnnotation]; ok {
+		t.Error("Expected no saved v1alpha1 spec for a lossless conversion")
+	}
+}
+
+func TestConversion_RoundTrip(t *testing.T) {
+	server := NewWebhookServer(NewDefaultConfig(), kfake.NewSimpleClientset(), vsfake.NewSimpleClientset())
+
+	tests := []struct {
+		name string
+		spec v1alpha1.VolumeScalerSpec
+	}{
+		{
+			name: "fixed",
+			spec: v1alpha1.VolumeScalerSpec{PVCName: "data", Threshold: "80%", Scale: "5Gi", ScaleType: "fixed", CooldownPeriod: "1h", MaxSize: "100Gi", Priority: 3},
+		},
+		{
+			name: "approval policy",
+			spec: v1alpha1.VolumeScalerSpec{
+				PVCName: "data", Threshold: "80%", Scale: "100Gi", ScaleType: "fixed", CooldownPeriod: "1h", MaxSize: "2Ti",
+				ApprovalPolicy: &v1alpha1.ApprovalPolicy{SizeAbove: "1Ti", IncrementAbove: "500Gi", Expiry: "24h"},
+			},
+		},
+		{
+			name: "schedules, critical level and breach settings",
+			spec: v1alpha1.VolumeScalerSpec{
+				PVCName: "data", Threshold: "80%", Scale: "5Gi", ScaleType: "fixed", CooldownPeriod: "1h", MaxSize: "1Ti",
+				Schedules:         []v1alpha1.ScaleSchedule{{Name: "month-end", Schedule: "0 0 28 * *", MinSize: "800Gi"}},
+				BlackoutWindows:   []v1alpha1.BlackoutWindow{{Name: "peak", Start: "0 8 * * 1", Duration: "4h"}},
+				CriticalThreshold: "95%",
+				CriticalScale:     "50%",
+				BreachDuration:    "10m",
+				ResetThreshold:    "65%",
+				GrowthAnomaly:     &v1alpha1.GrowthAnomalyPolicy{MaxGrowthPerHour: "50Gi", BaselineMultiple: 5},
+				GrowthBudget:      &v1alpha1.GrowthBudget{MaxExpansionsPerDay: 4, MaxGrowthPerDay: "200Gi"},
+				SnapshotBeforeResize: &v1alpha1.SnapshotPolicy{
+					VolumeSnapshotClassName: "csi-snapclass", ReadyTimeout: "5m", Retain: 2,
+				},
+				Shrink: &v1alpha1.ShrinkPolicy{
+					BelowUsage: "20%", SustainedFor: "72h", TargetUsage: "50%", MinSize: "100Gi",
+					Workload:          v1alpha1.WorkloadReference{Kind: "StatefulSet", Name: "db"},
+					MaintenanceWindow: &v1alpha1.BlackoutWindow{Start: "0 2 * * 0", Duration: "2h"},
+					CopyImage:         "busybox:1.36",
+				},
+			},
+		},
+		{
+			name: "percentage without cooldown",
+			spec: v1alpha1.VolumeScalerSpec{PVCName: "data", Threshold: "70%", Scale: "30%", ScaleType: "percentage", MaxSize: "20Gi"},
+		},
+		{
+			name: "legacy scale type and unusual formats",
+			spec: v1alpha1.VolumeScalerSpec{PVCName: "data", Threshold: "70%", Scale: "30%", ScaleType: "VolumeScaler", CooldownPeriod: "600s", MaxSize: "20Gi"},
+		},
+		{
+			name: "unparsable values",
+			spec: v1alpha1.VolumeScalerSpec{PVCName: "data", Threshold: "high", Scale: "lots", ScaleType: "fixed", CooldownPeriod: "soon", MaxSize: "big"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alpha := &v1alpha1.VolumeScaler{
+				TypeMeta: metav1.TypeMeta{APIVersion: "autoscaling.storage.k8s.io/v1alpha1", Kind: "VolumeScaler"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "data", Namespace: "default",
+					Annotations: map[string]string{"team": "storage"},
+				},
+				Spec: tt.spec,
+			}
+
+			toBeta := sendConversionReview(t, server.Handler(), "autoscaling.storage.k8s.io/v1beta1", alpha)
+			if toBeta.Result.Status != metav1.StatusSuccess {
+				t.Fatalf("Conversion to v1beta1 failed: %s", toBeta.Result.Message)
+			}
+			back := sendConversionReview(t, server.Handler(), "autoscaling.storage.k8s.io/v1alpha1", toBeta.ConvertedObjects[0])
+			if back.Result.Status != metav1.StatusSuccess {
+				t.Fatalf("Conversion to v1alpha1 failed: %s", back.Result.Message)
+			}
+
+			result := &v1alpha1.VolumeScaler{}
+			if err := json.Unmarshal(back.ConvertedObjects[0].Raw, result); err != nil {
+				t.Fatalf("Failed to decode converted object: %v", err)
+			}
+			if !reflect.DeepEqual(result.Spec, alpha.Spec) {
+				t.Errorf("Round-tripped spec = %+v, want %+v", result.Spec, alpha.Spec)
+			}
+			if !reflect.DeepEqual(result.Annotations, alpha.Annotations) {
+				t.Errorf("Round-tripped annotations = %v, want %v", result.Annotations, alpha.Annotations)
+			}
+		})
+	}
+}
+
+func TestConversion_EditedV1beta1SpecWins(t *testing.T) {
+	alpha := &v1alpha1.VolumeScaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default"},
+		Spec:       v1alpha1.VolumeScalerSpec{PVCName: "data", Threshold: "70%", Scale: "30%", ScaleType: "VolumeScaler", MaxSize: "20Gi"},
+	}
+	beta := &v1beta1.VolumeScaler{}
+	if err := v1beta1.ConvertFromV1alpha1(alpha, beta); err != nil {
+		t.Fatalf("ConvertFromV1alpha1() error = %v", err)
+	}
+	if _, ok := beta.Annotations[v1beta1.V1alpha1SpecAnnotation]; !ok {
+		t.Fatal("Expected the legacy spec to be saved in an annotation")
+	}
+
+	beta.Spec.ThresholdPercent = 85
+	result := &v1alpha1.VolumeScaler{}
+	if err := v1beta1.ConvertToV1alpha1(beta, result); err != nil {
+		t.Fatalf("ConvertToV1alpha1() error = %v", err)
+	}
+	if result.Spec.Threshold != "85%" || result.Spec.ScaleType != scaleTypePercentage {
+		t.Errorf("Expected the edited v1beta1 spec to win, got %+v", result.Spec)
+	}
+	if _, ok := result.Annotations[v1beta1.V1alpha1SpecAnnotation]; ok {
+		t.Error("Expected the saved spec annotation to be dropped")
+	}
+}
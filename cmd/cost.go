@@ -0,0 +1,296 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+const (
+	// hoursPerMonth is the month cloud providers bill GiB-months by
+	hoursPerMonth = 730
+
+	// costAccrualInterval is how often the cumulative cost is written to the
+	// status; size and price changes are written immediately
+	costAccrualInterval = time.Hour
+
+	// Price table ConfigMap keys
+	priceKeyDefault            = "default"
+	priceKeyCurrency           = "currency"
+	priceKeyBaseline           = "baseline"
+	priceKeyStorageClassPrefix = "storageClass."
+	priceKeyProvisionerPrefix  = "provisioner."
+
+	defaultBaselinePercent = 100
+)
+
+// Cost metrics, one series per managed PVC
+var (
+	costLabels = []string{"namespace", "volumescaler", "pvc", "storageclass"}
+
+	monthlyCostGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "volumescaler_monthly_cost",
+		Help: "Monthly cost of the PVC at its current size under the price table.",
+	}, costLabels)
+	monthlySavingsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "volumescaler_monthly_savings",
+		Help: "Monthly cost of the static baseline minus the monthly cost of the PVC.",
+	}, costLabels)
+	cumulativeCostGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "volumescaler_cumulative_cost",
+		Help: "Cost of the PVC accrued since cost tracking started.",
+	}, costLabels)
+	cumulativeSavingsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "volumescaler_cumulative_savings",
+		Help: "Savings against the static baseline accrued since cost tracking started.",
+	}, costLabels)
+	expansionCostCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "volumescaler_expansion_cost_total",
+		Help: "Monthly cost added by the expansions this controller started.",
+	}, costLabels)
+)
+
+func init() {
+	metricsRegistry.MustRegister(monthlyCostGauge, monthlySavingsGauge, cumulativeCostGauge, cumulativeSavingsGauge, expansionCostCounter)
+}
+
+// priceTable holds per-GiB-month prices read from the price table ConfigMap.
+type priceTable struct {
+	storageClasses map[string]float64
+	provisioners   map[string]float64
+	defaultPrice   float64
+	hasDefault     bool
+	currency       string
+	// baselinePercent sizes the static baseline as a percentage of maxSize
+	baselinePercent float64
+}
+
+// parsePriceTable reads a price table from ConfigMap data:
+//
+//	default: "0.10"
+//	storageClass.gp3: "0.08"
+//	provisioner.ebs.csi.aws.com: "0.10"
+//	currency: USD
+//	baseline: "100%"
+func parsePriceTable(data map[string]string) (*priceTable, error) {
+	table := &priceTable{
+		storageClasses:  make(map[string]float64),
+		provisioners:    make(map[string]float64),
+		baselinePercent: defaultBaselinePercent,
+	}
+	parsePrice := func(key, value string) (float64, error) {
+		price, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || price < 0 {
+			return 0, fmt.Errorf("invalid price '%s' for '%s'", value, key)
+		}
+		return price, nil
+	}
+	for key, value := range data {
+		var err error
+		switch {
+		case key == priceKeyCurrency:
+			table.currency = strings.TrimSpace(value)
+		case key == priceKeyBaseline:
+			table.baselinePercent, err = Percentage(strings.TrimSpace(value)).ToFloat()
+			if err != nil || table.baselinePercent <= 0 {
+				return nil, fmt.Errorf("invalid baseline '%s': want a percentage of maxSize, e.g. \"100%%\"", value)
+			}
+		case key == priceKeyDefault:
+			table.defaultPrice, err = parsePrice(key, value)
+			table.hasDefault = true
+		case strings.HasPrefix(key, priceKeyStorageClassPrefix):
+			table.storageClasses[strings.TrimPrefix(key, priceKeyStorageClassPrefix)], err = parsePrice(key, value)
+		case strings.HasPrefix(key, priceKeyProvisionerPrefix):
+			table.provisioners[strings.TrimPrefix(key, priceKeyProvisionerPrefix)], err = parsePrice(key, value)
+		default:
+			err = fmt.Errorf("unknown key '%s'", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return table, nil
+}
+
+// loadPriceTable reads the price table ConfigMap named by
+// --price-table-configmap, in the controller's own namespace unless the flag
+// gives one. A table that can't be read keeps the previous one.
+func (c *VolumeScalerController) loadPriceTable(ctx context.Context) {
+	ref := c.config.PriceTableConfigMap
+	if ref == "" {
+		return
+	}
+	namespace, name := defaultLeaseNamespace(), ref
+	if parts := strings.SplitN(ref, "/", 2); len(parts) == 2 {
+		namespace, name = parts[0], parts[1]
+	}
+	cm, err := c.clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		fmt.Printf("[WARN] reading price table ConfigMap '%s/%s': %v\n", namespace, name, err)
+		return
+	}
+	table, err := parsePriceTable(cm.Data)
+	if err != nil {
+		fmt.Printf("[WARN] price table ConfigMap '%s/%s': %v\n", namespace, name, err)
+		return
+	}
+	c.prices = table
+}
+
+// priceFor returns the per-GiB-month price of pvc: the price of its
+// StorageClass, else of the StorageClass's provisioner, else the default.
+func (c *VolumeScalerController) priceFor(ctx context.Context, pvc *corev1.PersistentVolumeClaim) (float64, bool) {
+	className := ""
+	if pvc.Spec.StorageClassName != nil {
+		className = *pvc.Spec.StorageClassName
+	}
+	return c.priceForClass(ctx, className)
+}
+
+// priceForClass returns the per-GiB-month price of volumes of a StorageClass.
+// The provisioner of each StorageClass is looked up once per loop.
+func (c *VolumeScalerController) priceForClass(ctx context.Context, className string) (float64, bool) {
+	if c.prices == nil {
+		return 0, false
+	}
+	if price, ok := c.prices.storageClasses[className]; ok && className != "" {
+		return price, true
+	}
+	if className != "" && len(c.prices.provisioners) > 0 {
+		provisioner, cached := c.classProvisioners[className]
+		if !cached {
+			sc, err := c.clientset.StorageV1().StorageClasses().Get(ctx, className, metav1.GetOptions{})
+			if err == nil {
+				provisioner = sc.Provisioner
+				if c.classProvisioners == nil {
+					c.classProvisioners = make(map[string]string)
+				}
+				c.classProvisioners[className] = provisioner
+			}
+		}
+		if price, ok := c.prices.provisioners[provisioner]; ok && provisioner != "" {
+			return price, true
+		}
+	}
+	return c.prices.defaultPrice, c.prices.hasDefault
+}
+
+func formatMoney(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+// formatAccrued keeps sub-cent precision so hourly accruals of small volumes
+// aren't rounded away.
+func formatAccrued(v float64) string {
+	return strconv.FormatFloat(v, 'f', 4, 64)
+}
+
+func parseMoney(s string) float64 {
+	v, _ := strconv.ParseFloat(s, 64)
+	return v
+}
+
+// costSeriesLabels returns the metric labels of a managed PVC.
+func costSeriesLabels(vsName types.NamespacedName, pvc *corev1.PersistentVolumeClaim) prometheus.Labels {
+	className := ""
+	if pvc.Spec.StorageClassName != nil {
+		className = *pvc.Spec.StorageClassName
+	}
+	return prometheus.Labels{"namespace": vsName.Namespace, "volumescaler": vsName.Name, "pvc": pvc.Name, "storageclass": className}
+}
+
+// deleteCostSeries removes the cost series of a PVC no longer on this node.
+func deleteCostSeries(labels prometheus.Labels) {
+	monthlyCostGauge.Delete(labels)
+	monthlySavingsGauge.Delete(labels)
+	cumulativeCostGauge.Delete(labels)
+	cumulativeSavingsGauge.Delete(labels)
+}
+
+// trackCost prices the PVC at its current size and accrues its cost, and the
+// savings against a static volume of baseline percent of maxSize, into
+// status.cost. The cumulative amounts are written at most once per
+// costAccrualInterval unless the size or price changed.
+func (c *VolumeScalerController) trackCost(ctx context.Context, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, pvc *corev1.PersistentVolumeClaim, specSizeGi, maxSizeGi float64) error {
+	price, ok := c.priceFor(ctx, pvc)
+	if !ok {
+		return nil
+	}
+	now := time.Now().UTC()
+	monthly := specSizeGi * price
+	savings := maxSizeGi*c.prices.baselinePercent/100*price - monthly
+
+	cost := &v1alpha1.CostStatus{Since: formatStatusTime(now)}
+	if vsObj.Status.Cost != nil {
+		cost = vsObj.Status.Cost.DeepCopy()
+	}
+	cumulativeCost, cumulativeSavings := parseMoney(cost.CumulativeCost), parseMoney(cost.CumulativeSavings)
+	changed := cost.MonthlyCost != formatMoney(monthly) || cost.MonthlySavings != formatMoney(savings) ||
+		cost.PricePerGiBMonth != strconv.FormatFloat(price, 'f', -1, 64) || cost.Currency != c.prices.currency
+	if last, err := time.Parse(time.RFC3339, cost.LastAccrued); err == nil {
+		elapsed := now.Sub(last)
+		if elapsed < costAccrualInterval && !changed {
+			c.setCostMetrics(vsName, pvc, monthly, savings, cumulativeCost, cumulativeSavings)
+			return nil
+		}
+		// The previous amounts applied until now
+		months := elapsed.Hours() / hoursPerMonth
+		cumulativeCost += parseMoney(cost.MonthlyCost) * months
+		cumulativeSavings += parseMoney(cost.MonthlySavings) * months
+	}
+
+	cost.Currency = c.prices.currency
+	cost.PricePerGiBMonth = strconv.FormatFloat(price, 'f', -1, 64)
+	cost.MonthlyCost = formatMoney(monthly)
+	cost.MonthlySavings = formatMoney(savings)
+	cost.CumulativeCost = formatAccrued(cumulativeCost)
+	cost.CumulativeSavings = formatAccrued(cumulativeSavings)
+	cost.LastAccrued = formatStatusTime(now)
+	c.setCostMetrics(vsName, pvc, monthly, savings, cumulativeCost, cumulativeSavings)
+
+	patch, err := json.Marshal(map[string]interface{}{"status": map[string]interface{}{"cost": cost}})
+	if err != nil {
+		return fmt.Errorf("encoding cost patch: %v", err)
+	}
+	_, err = c.vsClient.AutoscalingV1alpha1().VolumeScalers(vsName.Namespace).
+		Patch(ctx, vsName.Name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+	if err != nil {
+		return fmt.Errorf("patching cost status: %v", err)
+	}
+	vsObj.Status.Cost = cost
+	return nil
+}
+
+func (c *VolumeScalerController) setCostMetrics(vsName types.NamespacedName, pvc *corev1.PersistentVolumeClaim, monthly, savings, cumulativeCost, cumulativeSavings float64) {
+	labels := costSeriesLabels(vsName, pvc)
+	monthlyCostGauge.With(labels).Set(monthly)
+	monthlySavingsGauge.With(labels).Set(savings)
+	cumulativeCostGauge.With(labels).Set(cumulativeCost)
+	cumulativeSavingsGauge.With(labels).Set(cumulativeSavings)
+	if c.costSeries == nil {
+		c.costSeries = make(map[string]prometheus.Labels)
+	}
+	c.costSeries[vsName.Namespace+"/"+pvc.Name] = labels
+}
+
+// expansionCost returns the monthly cost of growing pvc by increaseGi and
+// counts it in volumescaler_expansion_cost_total, or "" without a price.
+func (c *VolumeScalerController) expansionCost(ctx context.Context, vsName types.NamespacedName, pvc *corev1.PersistentVolumeClaim, increaseGi float64) string {
+	price, ok := c.priceFor(ctx, pvc)
+	if !ok {
+		return ""
+	}
+	added := increaseGi * price
+	expansionCostCounter.With(costSeriesLabels(vsName, pvc)).Add(added)
+	return formatMoney(added)
+}
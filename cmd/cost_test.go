@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+// withPrices loads a price table from a ConfigMap in the controller's namespace.
+func (f *scalerFixture) withPrices(t *testing.T, data map[string]string) {
+	t.Helper()
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "prices", Namespace: "default"}, Data: data}
+	if _, err := f.clientset.CoreV1().ConfigMaps("default").Create(context.TODO(), cm, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create ConfigMap: %v", err)
+	}
+	f.controller.config.PriceTableConfigMap = "default/prices"
+	f.controller.loadPriceTable(context.TODO())
+	if f.controller.prices == nil {
+		t.Fatalf("Expected the price table to load")
+	}
+}
+
+func TestLoadPriceTable_DefaultsToControllerNamespace(t *testing.T) {
+	t.Setenv("POD_NAMESPACE", "volumescaler")
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {})
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "prices", Namespace: "volumescaler"}, Data: map[string]string{"default": "0.10"}}
+	if _, err := f.clientset.CoreV1().ConfigMaps("volumescaler").Create(context.TODO(), cm, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create ConfigMap: %v", err)
+	}
+	f.controller.config.ResizeLeaseNamespace = "leases"
+	f.controller.config.PriceTableConfigMap = "prices"
+
+	f.controller.loadPriceTable(context.TODO())
+	if f.controller.prices == nil || f.controller.prices.defaultPrice != 0.10 {
+		t.Errorf("Expected the price table to load from the controller's namespace, got %+v", f.controller.prices)
+	}
+}
+
+func TestParsePriceTable(t *testing.T) {
+	table, err := parsePriceTable(map[string]string{
+		"default": "0.10", "storageClass.gp3": "0.08", "provisioner.ebs.csi.aws.com": "0.12", "currency": "USD", "baseline": "50%",
+	})
+	if err != nil {
+		t.Fatalf("parsePriceTable() error = %v", err)
+	}
+	if table.defaultPrice != 0.10 || table.storageClasses["gp3"] != 0.08 || table.provisioners["ebs.csi.aws.com"] != 0.12 ||
+		table.currency != "USD" || table.baselinePercent != 50 {
+		t.Errorf("parsePriceTable() = %+v", table)
+	}
+
+	for _, data := range []map[string]string{
+		{"default": "cheap"},
+		{"storageClass.gp3": "-1"},
+		{"baseline": "0%"},
+		{"gp3": "0.08"},
+	} {
+		if _, err := parsePriceTable(data); err == nil {
+			t.Errorf("parsePriceTable(%v) expected an error", data)
+		}
+	}
+}
+
+func TestCost_AccruesCostAndSavings(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		vs.Status.Cost = &v1alpha1.CostStatus{
+			PricePerGiBMonth: "0.1", MonthlyCost: "0.50", MonthlySavings: "0.50",
+			CumulativeCost: "1.0000", CumulativeSavings: "2.0000",
+			LastAccrued: time.Now().Add(-73 * time.Hour).UTC().Format(time.RFC3339),
+		}
+	})
+	f.withPrices(t, map[string]string{"default": "0.10"})
+	f.setUsedGi(1)
+
+	f.reconcile(t)
+	cost := f.status(t).Cost
+	// 73h at 0.50 a month is 0.05
+	if cost.MonthlyCost != "0.50" || cost.CumulativeCost != "1.0500" || cost.CumulativeSavings != "2.0500" {
+		t.Errorf("Expected 0.05 accrued over 73h, got %+v", cost)
+	}
+	labels := prometheus.Labels{"namespace": "default", "volumescaler": "data", "pvc": "data", "storageclass": ""}
+	if got := testutil.ToFloat64(monthlyCostGauge.With(labels)); got != 0.5 {
+		t.Errorf("volumescaler_monthly_cost = %v, want 0.5", got)
+	}
+	// A static 10Gi maxSize would cost 1.00 a month
+	if got := testutil.ToFloat64(monthlySavingsGauge.With(labels)); got != 0.5 {
+		t.Errorf("volumescaler_monthly_savings = %v, want 0.5", got)
+	}
+
+	f.reconcile(t)
+	if got := f.status(t).Cost.CumulativeCost; got != "1.0500" {
+		t.Errorf("Expected no accrual within the hour, got %s", got)
+	}
+}
+
+func TestCost_ExpansionCostFromProvisionerPrice(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {})
+	className := "fast"
+	pvc, _ := f.clientset.CoreV1().PersistentVolumeClaims("default").Get(context.TODO(), "data", metav1.GetOptions{})
+	pvc.Spec.StorageClassName = &className
+	if _, err := f.clientset.CoreV1().PersistentVolumeClaims("default").Update(context.TODO(), pvc, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to update PVC: %v", err)
+	}
+	sc := &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "fast"}, Provisioner: "ebs.csi.aws.com"}
+	if _, err := f.clientset.StorageV1().StorageClasses().Create(context.TODO(), sc, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create StorageClass: %v", err)
+	}
+	f.withPrices(t, map[string]string{"default": "0.01", "provisioner.ebs.csi.aws.com": "0.10", "currency": "USD"})
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "7Gi" {
+		t.Fatalf("Expected the PVC to expand, got %s", size)
+	}
+	st := f.status(t)
+	if st.Cost.Currency != "USD" || st.Cost.PricePerGiBMonth != "0.1" || st.Cost.LastExpansionCost != "0.20" {
+		t.Errorf("Expected the provisioner price and a 0.20 expansion, got %+v", st.Cost)
+	}
+	if n := len(st.ExpansionLedger); n == 0 || st.ExpansionLedger[n-1].CostAdded != "0.20" {
+		t.Errorf("Expected the ledger record to carry the cost, got %+v", st.ExpansionLedger)
+	}
+	labels := prometheus.Labels{"namespace": "default", "volumescaler": "data", "pvc": "data", "storageclass": "fast"}
+	if got := testutil.ToFloat64(expansionCostCounter.With(labels)); got < 0.2-1e-9 || got > 0.2+1e-9 {
+		t.Errorf("volumescaler_expansion_cost_total = %v, want 0.2", got)
+	}
+	gets := 0
+	for _, a := range f.clientset.Actions() {
+		if a.GetResource().Resource == "storageclasses" && a.GetVerb() == "get" {
+			gets++
+		}
+	}
+	if gets != 1 {
+		t.Errorf("Expected the StorageClass fetched once per loop, got %d gets", gets)
+	}
+}
+
+func TestCost_NoPriceTable(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {})
+
+	f.reconcile(t)
+	st := f.status(t)
+	if st.Cost != nil || st.ExpansionLedger[len(st.ExpansionLedger)-1].CostAdded != "" {
+		t.Errorf("Expected no cost without a price table, got %+v", st.Cost)
+	}
+}
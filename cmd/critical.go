@@ -0,0 +1,30 @@
+package main
+
+import (
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+const (
+	// Event reasons
+	eventReasonCriticalUsage = "CriticalUsage"
+)
+
+// criticalUsage reports whether usagePercent has reached the VolumeScaler's
+// criticalThreshold. Critical expansions skip the VolumeScaler's cooldown and
+// blackout windows, but not maxSize or VolumeScalerLimits.
+func criticalUsage(vsObj *v1alpha1.VolumeScaler, usagePercent int) bool {
+	if vsObj.Spec.CriticalThreshold == "" {
+		return false
+	}
+	critical, err := Percentage(vsObj.Spec.CriticalThreshold).ToFloat()
+	return err == nil && usagePercent >= int(critical)
+}
+
+// expansionScale returns the scale and scale type of the next expansion:
+// criticalScale at critical usage when it is set, the regular scale otherwise.
+func expansionScale(vsObj *v1alpha1.VolumeScaler, critical bool) (string, string) {
+	if critical && vsObj.Spec.CriticalScale != "" {
+		return vsObj.Spec.CriticalScale, inferScaleType(vsObj.Spec.CriticalScale)
+	}
+	return vsObj.Spec.Scale, vsObj.Spec.ScaleType
+}
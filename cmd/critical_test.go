@@ -0,0 +1,91 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+// critical sets a criticalThreshold below the fixture's 80% usage and a
+// criticalScale larger than its regular 2Gi increment.
+func critical(vs *v1alpha1.VolumeScaler) {
+	vs.Spec.CriticalThreshold = "75%"
+	vs.Spec.CriticalScale = "4Gi"
+}
+
+func TestCritical_BypassesCooldownWithLargerIncrement(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		inCooldown(vs)
+		critical(vs)
+	})
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "9Gi" {
+		t.Fatalf("Expected a critical expansion by 4Gi to 9Gi, got %s", size)
+	}
+	events := strings.Join(drainEvents(f.recorder), "\n")
+	if !strings.Contains(events, corev1.EventTypeWarning+" "+eventReasonCriticalUsage) {
+		t.Errorf("Expected a Warning %s event, got %s", eventReasonCriticalUsage, events)
+	}
+}
+
+func TestCritical_BelowCriticalThresholdKeepsCooldown(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		inCooldown(vs)
+		critical(vs)
+		vs.Spec.CriticalThreshold = "90%"
+	})
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "5Gi" {
+		t.Errorf("Expected the cooldown to hold below criticalThreshold, got %s", size)
+	}
+}
+
+func TestCritical_DefaultsToRegularScale(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		inCooldown(vs)
+		vs.Spec.CriticalThreshold = "75%"
+	})
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "7Gi" {
+		t.Errorf("Expected the regular 2Gi increment without criticalScale, got %s", size)
+	}
+}
+
+func TestCritical_RespectsMaxSize(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		inCooldown(vs)
+		critical(vs)
+		vs.Spec.MaxSize = "6Gi"
+	})
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "6Gi" {
+		t.Errorf("Expected the critical expansion to stop at maxSize 6Gi, got %s", size)
+	}
+}
+
+func TestCritical_RespectsLimitMinCooldown(t *testing.T) {
+	limit := &v1alpha1.VolumeScalerLimit{
+		ObjectMeta: metav1.ObjectMeta{Name: "ebs-modification-rate"},
+		Spec:       v1alpha1.VolumeScalerLimitSpec{MinCooldownPeriod: "6h"},
+	}
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		inCooldown(vs)
+		critical(vs)
+	}, limit)
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "5Gi" {
+		t.Fatalf("Expected the VolumeScalerLimit cooldown to hold the critical expansion, got %s", size)
+	}
+	if events := strings.Join(drainEvents(f.recorder), "\n"); !strings.Contains(events, "ebs-modification-rate") {
+		t.Errorf("Expected the event to name the limit, got %s", events)
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const defaultEventRepeatInterval = 30 * time.Minute
+
+// repeatedEvent tracks an event reported every loop while a state lasts.
+type repeatedEvent struct {
+	emitted  time.Time // when the event was last emitted
+	lastSeen time.Time // when the state was last reported
+	count    int       // occurrences since the event was last emitted
+}
+
+// stateEvent emits an event for a state that is reported every loop, such as
+// an ongoing resize or cooldown. The first occurrence is emitted right away;
+// repeats at most once per EventRepeatInterval with the number of occurrences
+// since the last one. A state not reported for two loops has ended, so its
+// next occurrence is emitted right away again.
+func (c *VolumeScalerController) stateEvent(ref *corev1.ObjectReference, eventtype, reason, msg string) {
+	interval := c.config.EventRepeatInterval
+	if interval <= 0 {
+		c.recorder.Event(ref, eventtype, reason, msg)
+		return
+	}
+	now := time.Now()
+	key := ref.Kind + "/" + ref.Namespace + "/" + ref.Name + "/" + reason
+	if c.stateEvents == nil {
+		c.stateEvents = make(map[string]*repeatedEvent)
+	}
+	st, ok := c.stateEvents[key]
+	if !ok || now.Sub(st.lastSeen) > 2*c.config.PollInterval {
+		c.stateEvents[key] = &repeatedEvent{emitted: now, lastSeen: now}
+		c.recorder.Event(ref, eventtype, reason, msg)
+		return
+	}
+	st.lastSeen = now
+	st.count++
+	if now.Sub(st.emitted) < interval {
+		return
+	}
+	c.recorder.Event(ref, eventtype, reason,
+		fmt.Sprintf("%s (%d occurrences since %s)", msg, st.count, formatStatusTime(st.emitted)))
+	st.emitted, st.count = now, 0
+}
+
+// pruneStateEvents drops the repeated events whose state has ended.
+func (c *VolumeScalerController) pruneStateEvents(now time.Time) {
+	for key, st := range c.stateEvents {
+		if now.Sub(st.lastSeen) > 2*c.config.PollInterval {
+			delete(c.stateEvents, key)
+		}
+	}
+}
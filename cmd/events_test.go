@@ -0,0 +1,70 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+func TestStateEvent_EmitsTransitionsThenEveryInterval(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {})
+	ref := &corev1.ObjectReference{Kind: "VolumeScaler", Namespace: "default", Name: "data"}
+	key := "VolumeScaler/default/data/" + eventReasonStillResizing
+	emit := func() []string {
+		f.controller.stateEvent(ref, corev1.EventTypeWarning, eventReasonStillResizing, "still resizing")
+		return drainEvents(f.recorder)
+	}
+
+	if events := emit(); len(events) != 1 {
+		t.Fatalf("Expected the first occurrence emitted, got %v", events)
+	}
+	for i := 0; i < 3; i++ {
+		if events := emit(); len(events) != 0 {
+			t.Fatalf("Expected repeats within the interval suppressed, got %v", events)
+		}
+	}
+
+	f.controller.stateEvents[key].emitted = time.Now().Add(-defaultEventRepeatInterval)
+	events := emit()
+	if len(events) != 1 || !strings.Contains(events[0], "still resizing (4 occurrences since ") {
+		t.Fatalf("Expected a repeat with the occurrence count after the interval, got %v", events)
+	}
+
+	// Not reported for two loops: the state ended, and starts again
+	f.controller.stateEvents[key].lastSeen = time.Now().Add(-3 * defaultPollInterval)
+	if events := emit(); len(events) != 1 || strings.Contains(events[0], "occurrences") {
+		t.Errorf("Expected a new occurrence emitted right away, got %v", events)
+	}
+	f.controller.pruneStateEvents(time.Now().Add(3 * defaultPollInterval))
+	if len(f.controller.stateEvents) != 0 {
+		t.Errorf("Expected ended states pruned, got %v", f.controller.stateEvents)
+	}
+}
+
+func TestStateEvent_CooldownEmittedOnce(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		vs.Status.ScaledAt = time.Now().UTC().Format(time.RFC3339)
+	})
+
+	f.reconcile(t)
+	f.reconcile(t)
+	count := 0
+	for _, e := range drainEvents(f.recorder) {
+		if strings.Contains(e, eventReasonCooldownActive) {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("Expected one %s event over two loops, got %d", eventReasonCooldownActive, count)
+	}
+
+	f.controller.config.EventRepeatInterval = 0
+	f.reconcile(t)
+	if events := drainEvents(f.recorder); len(events) != 1 {
+		t.Errorf("Expected the event every loop without rate limiting, got %v", events)
+	}
+}
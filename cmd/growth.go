@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+const (
+	// annotationAcknowledgeGrowth on a VolumeScaler lets expansions resume
+	// while its growth is still anomalous. The controller removes it once the
+	// rate normalizes.
+	annotationAcknowledgeGrowth = annotationPrefix + "acknowledge-growth"
+
+	conditionTypeAnomalousGrowth = "AnomalousGrowth"
+
+	// baselineWeight is how much each normal sample moves the baseline
+	baselineWeight = 0.1
+	// minAnomalousRateGi keeps slow volumes with a near-zero baseline from
+	// being flagged for ordinary noise
+	minAnomalousRateGi = 1.0
+
+	// Event reasons
+	eventReasonAnomalousGrowth  = "AnomalousGrowth"
+	eventReasonGrowthNormalized = "GrowthNormalized"
+	eventReasonGrowthHeld       = "GrowthHeld"
+)
+
+// growthSample is the used space of a PVC at one reconcile loop.
+type growthSample struct {
+	usedGi float64
+	at     time.Time
+}
+
+// growthAnomaly describes why ratePerHour is anomalous under policy, or
+// returns "" when it is not.
+func growthAnomaly(policy *v1alpha1.GrowthAnomalyPolicy, ratePerHour, baselinePerHour float64) (string, error) {
+	if policy == nil {
+		return "", nil
+	}
+	if policy.MaxGrowthPerHour != "" {
+		maxGi, err := convertToGi(policy.MaxGrowthPerHour)
+		if err != nil {
+			return "", fmt.Errorf("growthAnomaly.maxGrowthPerHour '%s' invalid: %v", policy.MaxGrowthPerHour, err)
+		}
+		if ratePerHour > maxGi {
+			return fmt.Sprintf("usage grows %.1fGi/h, above maxGrowthPerHour %s", ratePerHour, policy.MaxGrowthPerHour), nil
+		}
+	}
+	if policy.BaselineMultiple > 0 && baselinePerHour > 0 && ratePerHour >= minAnomalousRateGi &&
+		ratePerHour > float64(policy.BaselineMultiple)*baselinePerHour {
+		return fmt.Sprintf("usage grows %.1fGi/h, more than %d times the baseline of %.2fGi/h",
+			ratePerHour, policy.BaselineMultiple, baselinePerHour), nil
+	}
+	return "", nil
+}
+
+// parseRate parses a "1.25Gi" rate from the status, returning 0 when unset.
+func parseRate(s string) float64 {
+	f, _ := strconv.ParseFloat(strings.TrimSuffix(s, "Gi"), 64)
+	return f
+}
+
+// trackGrowth measures the growth rate since the previous sample of the PVC,
+// updates the baseline and the AnomalousGrowth condition, and reports whether
+// threshold-triggered expansions are held. Samples are kept in memory; the
+// baseline is kept in the status so it survives restarts.
+func (c *VolumeScalerController) trackGrowth(ctx context.Context, invRef *corev1.ObjectReference, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, pvcKey string, usedGi float64) (bool, error) {
+	if c.growth == nil {
+		c.growth = make(map[string]growthSample)
+	}
+	now := time.Now()
+	prev, seen := c.growth[pvcKey]
+	c.growth[pvcKey] = growthSample{usedGi: usedGi, at: now}
+
+	cond := meta.FindStatusCondition(vsObj.Status.Conditions, conditionTypeAnomalousGrowth)
+	held := cond != nil && cond.Status == metav1.ConditionTrue
+	if vsObj.Spec.GrowthAnomaly == nil {
+		return false, c.clearCondition(ctx, vsName, vsObj, conditionTypeAnomalousGrowth)
+	}
+	if !seen || !now.After(prev.at) {
+		return held, nil
+	}
+
+	// Deleted data doesn't count as negative growth
+	rate := (usedGi - prev.usedGi) / now.Sub(prev.at).Hours()
+	if rate < 0 {
+		rate = 0
+	}
+	baseline := parseRate(vsObj.Status.BaselineGrowthPerHour)
+	reason, err := growthAnomaly(vsObj.Spec.GrowthAnomaly, rate, baseline)
+	if err != nil {
+		c.recorder.Eventf(invRef, corev1.EventTypeWarning, "InvalidGrowthAnomaly", "%v", err)
+		return held, err
+	}
+	if reason == "" {
+		// Only normal samples feed the baseline, so an anomaly can't become the norm
+		if baseline == 0 {
+			baseline = rate
+		} else {
+			baseline += baselineWeight * (rate - baseline)
+		}
+	}
+	if err := c.patchGrowthRates(ctx, vsName, vsObj, rate, baseline); err != nil {
+		fmt.Printf("[WARN] %v\n", err)
+	}
+
+	_, acked := vsObj.Annotations[annotationAcknowledgeGrowth]
+	switch {
+	case reason == "":
+		if cond == nil {
+			return false, nil
+		}
+		msg := fmt.Sprintf("Growth of VolumeScaler '%s/%s' is back to %.1fGi/h; expansions resume.", vsName.Namespace, vsName.Name, rate)
+		c.recorder.Event(invRef, corev1.EventTypeNormal, eventReasonGrowthNormalized, msg)
+		fmt.Printf("[INFO] %s\n", msg)
+		if acked {
+			if err := c.removeGrowthAcknowledgement(ctx, vsName); err != nil {
+				fmt.Printf("[WARN] %v\n", err)
+			}
+		}
+		return false, c.clearCondition(ctx, vsName, vsObj, conditionTypeAnomalousGrowth)
+	case cond != nil && acked:
+		return false, c.setCondition(ctx, vsName, vsObj, metav1.Condition{
+			Type:    conditionTypeAnomalousGrowth,
+			Status:  metav1.ConditionFalse,
+			Reason:  "Acknowledged",
+			Message: "Anomalous growth was acknowledged; expansions continue until the rate normalizes",
+		})
+	case held:
+		return true, nil
+	}
+
+	msg := fmt.Sprintf("Holding expansions of PVC '%s': %s. Annotate the VolumeScaler with %s to resume.",
+		pvcKey, reason, annotationAcknowledgeGrowth)
+	c.recorder.Event(invRef, corev1.EventTypeWarning, eventReasonAnomalousGrowth, msg)
+	fmt.Printf("[WARNING] %s\n", msg)
+	return true, c.setCondition(ctx, vsName, vsObj, metav1.Condition{
+		Type:    conditionTypeAnomalousGrowth,
+		Status:  metav1.ConditionTrue,
+		Reason:  "RateAnomalous",
+		Message: reason,
+	})
+}
+
+// patchGrowthRates publishes the current and baseline growth rates when
+// either changed.
+func (c *VolumeScalerController) patchGrowthRates(ctx context.Context, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, rate, baseline float64) error {
+	rateStr, baselineStr := fmt.Sprintf("%.2fGi", rate), fmt.Sprintf("%.2fGi", baseline)
+	if vsObj.Status.GrowthRatePerHour == rateStr && vsObj.Status.BaselineGrowthPerHour == baselineStr {
+		return nil
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"growthRatePerHour":     rateStr,
+			"baselineGrowthPerHour": baselineStr,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("encoding growth rate patch: %v", err)
+	}
+	_, err = c.vsClient.AutoscalingV1alpha1().VolumeScalers(vsName.Namespace).
+		Patch(ctx, vsName.Name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+	if err != nil {
+		return fmt.Errorf("patching growth rates: %v", err)
+	}
+	return nil
+}
+
+// removeGrowthAcknowledgement drops the acknowledgement annotation so the
+// next anomaly holds expansions again.
+func (c *VolumeScalerController) removeGrowthAcknowledgement(ctx context.Context, vsName types.NamespacedName) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{annotationAcknowledgeGrowth: nil},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("encoding acknowledgement patch: %v", err)
+	}
+	_, err = c.vsClient.AutoscalingV1alpha1().VolumeScalers(vsName.Namespace).
+		Patch(ctx, vsName.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("removing %s: %v", annotationAcknowledgeGrowth, err)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+// runawayPolicy flags growth faster than 10Gi/h.
+func runawayPolicy(vs *v1alpha1.VolumeScaler) {
+	vs.Spec.GrowthAnomaly = &v1alpha1.GrowthAnomalyPolicy{MaxGrowthPerHour: "10Gi"}
+}
+
+// lastSample makes the controller remember usedGi as the PVC's usage ago.
+func (f *scalerFixture) lastSample(usedGi float64, ago time.Duration) {
+	if f.controller.growth == nil {
+		f.controller.growth = make(map[string]growthSample)
+	}
+	f.controller.growth["default/data"] = growthSample{usedGi: usedGi, at: time.Now().Add(-ago)}
+}
+
+func (f *scalerFixture) growthCondition(t *testing.T) *metav1.Condition {
+	t.Helper()
+	st := f.status(t)
+	return meta.FindStatusCondition(st.Conditions, conditionTypeAnomalousGrowth)
+}
+
+func TestGrowthAnomaly(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   *v1alpha1.GrowthAnomalyPolicy
+		rate     float64
+		baseline float64
+		want     bool
+	}{
+		{name: "no policy", rate: 100},
+		{name: "above absolute rate", policy: &v1alpha1.GrowthAnomalyPolicy{MaxGrowthPerHour: "10Gi"}, rate: 50, want: true},
+		{name: "below absolute rate", policy: &v1alpha1.GrowthAnomalyPolicy{MaxGrowthPerHour: "10Gi"}, rate: 5},
+		{name: "above baseline multiple", policy: &v1alpha1.GrowthAnomalyPolicy{BaselineMultiple: 5}, rate: 6, baseline: 1, want: true},
+		{name: "within baseline multiple", policy: &v1alpha1.GrowthAnomalyPolicy{BaselineMultiple: 5}, rate: 4, baseline: 1},
+		{name: "no baseline yet", policy: &v1alpha1.GrowthAnomalyPolicy{BaselineMultiple: 5}, rate: 6},
+		{name: "noise on an idle volume", policy: &v1alpha1.GrowthAnomalyPolicy{BaselineMultiple: 5}, rate: 0.5, baseline: 0.01},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, err := growthAnomaly(tt.policy, tt.rate, tt.baseline)
+			if err != nil {
+				t.Fatalf("growthAnomaly() error = %v", err)
+			}
+			if (reason != "") != tt.want {
+				t.Errorf("growthAnomaly() = %q, want anomalous %v", reason, tt.want)
+			}
+		})
+	}
+}
+
+func TestGrowth_HoldsExpansionOnRunawayGrowth(t *testing.T) {
+	f := newScalerFixture(t, runawayPolicy)
+	f.lastSample(3, time.Minute) // 1Gi in a minute
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "5Gi" {
+		t.Fatalf("Expected runaway growth to hold the expansion, got %s", size)
+	}
+	if cond := f.growthCondition(t); cond == nil || cond.Status != metav1.ConditionTrue {
+		t.Errorf("Expected a true %s condition, got %+v", conditionTypeAnomalousGrowth, cond)
+	}
+	if st := f.status(t); st.GrowthRatePerHour != "60.00Gi" {
+		t.Errorf("Expected a growth rate of 60Gi/h, got %q", st.GrowthRatePerHour)
+	}
+	events := strings.Join(drainEvents(f.recorder), "\n")
+	if !strings.Contains(events, "Warning "+eventReasonAnomalousGrowth) {
+		t.Errorf("Expected a Warning %s event, got %s", eventReasonAnomalousGrowth, events)
+	}
+}
+
+func TestGrowth_AcknowledgementResumesExpansion(t *testing.T) {
+	f := newScalerFixture(t, runawayPolicy)
+	f.lastSample(3, time.Minute)
+	f.reconcile(t)
+
+	vs, err := f.vsClient.AutoscalingV1alpha1().VolumeScalers("default").Get(context.TODO(), "data", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get VolumeScaler: %v", err)
+	}
+	vs.Annotations = map[string]string{annotationAcknowledgeGrowth: "alice"}
+	if _, err := f.vsClient.AutoscalingV1alpha1().VolumeScalers("default").Update(context.TODO(), vs, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to annotate VolumeScaler: %v", err)
+	}
+
+	f.lastSample(3, time.Minute)
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "7Gi" {
+		t.Fatalf("Expected the acknowledged anomaly to allow the expansion, got %s", size)
+	}
+	if cond := f.growthCondition(t); cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "Acknowledged" {
+		t.Errorf("Expected an acknowledged condition, got %+v", cond)
+	}
+}
+
+func TestGrowth_ResumesWhenRateNormalizes(t *testing.T) {
+	f := newScalerFixture(t, runawayPolicy)
+	f.lastSample(3, time.Minute)
+	f.reconcile(t)
+	drainEvents(f.recorder)
+
+	f.lastSample(4, time.Hour)
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "7Gi" {
+		t.Fatalf("Expected expansions to resume once growth normalized, got %s", size)
+	}
+	if cond := f.growthCondition(t); cond != nil {
+		t.Errorf("Expected the condition to be cleared, got %+v", cond)
+	}
+	if events := strings.Join(drainEvents(f.recorder), "\n"); !strings.Contains(events, eventReasonGrowthNormalized) {
+		t.Errorf("Expected a %s event, got %s", eventReasonGrowthNormalized, events)
+	}
+}
+
+func TestGrowth_BaselineMultiple(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		vs.Spec.GrowthAnomaly = &v1alpha1.GrowthAnomalyPolicy{BaselineMultiple: 5}
+		vs.Status.BaselineGrowthPerHour = "0.50Gi"
+	})
+	f.lastSample(3, time.Hour/4) // 4Gi/h
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "5Gi" {
+		t.Errorf("Expected growth above 5x the baseline to hold the expansion, got %s", size)
+	}
+	if st := f.status(t); st.BaselineGrowthPerHour != "0.50Gi" {
+		t.Errorf("Expected the anomalous sample to leave the baseline alone, got %q", st.BaselineGrowthPerHour)
+	}
+}
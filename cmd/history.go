@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+const (
+	defaultHistoryFlushInterval = 5 * time.Minute
+
+	// Buckets kept in each tier of the usage history
+	historyMinutes = 120
+	historyHours   = 7 * 24
+	historyDays    = 90
+)
+
+// usageHistory is the in-memory copy of a PVC's VolumeUsageHistory status.
+// Samples are folded in every loop and written every HistoryFlushInterval.
+type usageHistory struct {
+	status  v1alpha1.VolumeUsageHistoryStatus
+	flushed time.Time
+}
+
+// addRollupSample folds a sample taken at now into its bucket of the given
+// width, starting a new bucket when needed, and drops the buckets older than
+// keep widths.
+func addRollupSample(buckets []v1alpha1.UsageRollup, now time.Time, width time.Duration, keep int, usedBytes, capacityBytes int64) []v1alpha1.UsageRollup {
+	start := now.UTC().Truncate(width)
+	oldest := start.Add(-time.Duration(keep-1) * width)
+	kept := make([]v1alpha1.UsageRollup, 0, len(buckets)+1)
+	for _, b := range buckets {
+		if t, err := time.Parse(time.RFC3339, b.Time); err == nil && !t.Before(oldest) {
+			kept = append(kept, b)
+		}
+	}
+
+	startStr := start.Format(time.RFC3339)
+	if n := len(kept); n > 0 && kept[n-1].Time == startStr {
+		b := &kept[n-1]
+		b.UsedBytes = (b.UsedBytes*int64(b.Samples) + usedBytes) / int64(b.Samples+1)
+		if usedBytes > b.MaxUsedBytes {
+			b.MaxUsedBytes = usedBytes
+		}
+		b.CapacityBytes = capacityBytes
+		b.Samples++
+		return kept
+	}
+	return append(kept, v1alpha1.UsageRollup{
+		Time:          startStr,
+		UsedBytes:     usedBytes,
+		MaxUsedBytes:  usedBytes,
+		CapacityBytes: capacityBytes,
+		Samples:       1,
+	})
+}
+
+// getOrCreateUsageHistory returns the VolumeUsageHistory of pvc, creating it
+// owned by the PVC when missing.
+func (c *VolumeScalerController) getOrCreateUsageHistory(ctx context.Context, pvc *corev1.PersistentVolumeClaim) (*v1alpha1.VolumeUsageHistory, error) {
+	histories := c.vsClient.AutoscalingV1alpha1().VolumeUsageHistories(pvc.Namespace)
+	hist, err := histories.Get(ctx, pvc.Name, metav1.GetOptions{})
+	if !apierrors.IsNotFound(err) {
+		return hist, err
+	}
+	return histories.Create(ctx, &v1alpha1.VolumeUsageHistory{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pvc.Name,
+			Namespace: pvc.Namespace,
+			// Deleted together with its PVC
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "v1",
+				Kind:       "PersistentVolumeClaim",
+				Name:       pvc.Name,
+				UID:        pvc.UID,
+			}},
+		},
+		Spec: v1alpha1.VolumeUsageHistorySpec{PVCName: pvc.Name},
+	}, metav1.CreateOptions{})
+}
+
+// recordUsageHistory folds the usage of pvc into its minute, hour and day
+// rollups. The history is loaded from its VolumeUsageHistory the first time
+// the PVC is seen on this node, which also seeds the growth rate after a
+// restart, and written back every HistoryFlushInterval.
+func (c *VolumeScalerController) recordUsageHistory(ctx context.Context, pvc *corev1.PersistentVolumeClaim, usageInfo *PVCUsageInfo) error {
+	key := pvc.Namespace + "/" + pvc.Name
+	if c.histories == nil {
+		c.histories = make(map[string]*usageHistory)
+	}
+	h, ok := c.histories[key]
+	if !ok {
+		hist, err := c.getOrCreateUsageHistory(ctx, pvc)
+		if err != nil {
+			return err
+		}
+		h = &usageHistory{status: *hist.Status.DeepCopy()}
+		c.histories[key] = h
+		c.seedGrowthSample(key, &h.status)
+	}
+
+	now := time.Now().UTC()
+	used, capacity := int64(usageInfo.UsedBytes), int64(usageInfo.CapacityBytes)
+	h.status.Minutes = addRollupSample(h.status.Minutes, now, time.Minute, historyMinutes, used, capacity)
+	h.status.Hours = addRollupSample(h.status.Hours, now, time.Hour, historyHours, used, capacity)
+	h.status.Days = addRollupSample(h.status.Days, now, 24*time.Hour, historyDays, used, capacity)
+	h.status.LastSampled = formatStatusTime(now)
+	if now.Sub(h.flushed) < c.config.HistoryFlushInterval {
+		return nil
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{"status": h.status})
+	if err != nil {
+		return fmt.Errorf("encoding VolumeUsageHistory status patch: %v", err)
+	}
+	_, err = c.vsClient.AutoscalingV1alpha1().VolumeUsageHistories(pvc.Namespace).
+		Patch(ctx, pvc.Name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+	if err != nil {
+		return fmt.Errorf("patching VolumeUsageHistory status: %v", err)
+	}
+	h.flushed = now
+	return nil
+}
+
+// seedGrowthSample restores the previous usage sample of a PVC from its
+// history, so the growth rate is known on the first loop after a restart.
+func (c *VolumeScalerController) seedGrowthSample(key string, st *v1alpha1.VolumeUsageHistoryStatus) {
+	if _, ok := c.growth[key]; ok || len(st.Minutes) == 0 {
+		return
+	}
+	last := st.Minutes[len(st.Minutes)-1]
+	at, err := time.Parse(time.RFC3339, last.Time)
+	if err != nil {
+		return
+	}
+	if c.growth == nil {
+		c.growth = make(map[string]growthSample)
+	}
+	c.growth[key] = growthSample{usedGi: float64(last.UsedBytes) / (1 << 30), at: at}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+func TestAddRollupSample(t *testing.T) {
+	base := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	var buckets []v1alpha1.UsageRollup
+	buckets = addRollupSample(buckets, base.Add(5*time.Minute), time.Hour, 3, 100, 1000)
+	buckets = addRollupSample(buckets, base.Add(35*time.Minute), time.Hour, 3, 300, 1000)
+	if len(buckets) != 1 {
+		t.Fatalf("Expected one bucket, got %+v", buckets)
+	}
+	if b := buckets[0]; b.Time != "2024-05-01T10:00:00Z" || b.UsedBytes != 200 || b.MaxUsedBytes != 300 || b.Samples != 2 {
+		t.Errorf("Expected the average and max of both samples, got %+v", b)
+	}
+
+	buckets = addRollupSample(buckets, base.Add(time.Hour), time.Hour, 3, 400, 2000)
+	if len(buckets) != 2 || buckets[1].CapacityBytes != 2000 || buckets[1].Samples != 1 {
+		t.Fatalf("Expected a second bucket, got %+v", buckets)
+	}
+
+	// Two hours later the 10:00 bucket is more than 3 buckets old
+	buckets = addRollupSample(buckets, base.Add(3*time.Hour), time.Hour, 3, 500, 2000)
+	if len(buckets) != 2 || buckets[0].Time != "2024-05-01T11:00:00Z" || buckets[1].Time != "2024-05-01T13:00:00Z" {
+		t.Errorf("Expected only the last 3 hours kept, got %+v", buckets)
+	}
+}
+
+func TestUsageHistory_FlushesEveryInterval(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {})
+	pvc, _ := f.clientset.CoreV1().PersistentVolumeClaims("default").Get(context.TODO(), "data", metav1.GetOptions{})
+	history := func() *v1alpha1.VolumeUsageHistory {
+		t.Helper()
+		hist, err := f.vsClient.AutoscalingV1alpha1().VolumeUsageHistories("default").Get(context.TODO(), "data", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Failed to get VolumeUsageHistory: %v", err)
+		}
+		return hist
+	}
+
+	if err := f.controller.recordUsageHistory(context.TODO(), pvc, f.usage); err != nil {
+		t.Fatalf("recordUsageHistory() error = %v", err)
+	}
+	hist := history()
+	if hist.Spec.PVCName != "data" || len(hist.OwnerReferences) != 1 || hist.OwnerReferences[0].Kind != "PersistentVolumeClaim" {
+		t.Errorf("Expected a history owned by the PVC, got %+v", hist.ObjectMeta)
+	}
+	if len(hist.Status.Days) != 1 || hist.Status.Days[0].UsedBytes != 4<<30 || hist.Status.Days[0].CapacityBytes != 5<<30 {
+		t.Fatalf("Expected the first sample written, got %+v", hist.Status)
+	}
+
+	f.setUsedGi(4.5)
+	if err := f.controller.recordUsageHistory(context.TODO(), pvc, f.usage); err != nil {
+		t.Fatalf("recordUsageHistory() error = %v", err)
+	}
+	if got := history().Status.Days[0].Samples; got != 1 {
+		t.Errorf("Expected no write within the flush interval, got %d samples", got)
+	}
+
+	f.controller.histories["default/data"].flushed = time.Now().Add(-defaultHistoryFlushInterval)
+	f.setUsedGi(5)
+	if err := f.controller.recordUsageHistory(context.TODO(), pvc, f.usage); err != nil {
+		t.Fatalf("recordUsageHistory() error = %v", err)
+	}
+	if day := history().Status.Days[0]; day.Samples != 3 || day.MaxUsedBytes != 5<<30 {
+		t.Errorf("Expected all three samples written, got %+v", day)
+	}
+}
+
+func TestUsageHistory_SeedsGrowthAfterRestart(t *testing.T) {
+	sampled := time.Now().Add(-10 * time.Minute).UTC().Truncate(time.Minute)
+	hist := &v1alpha1.VolumeUsageHistory{
+		ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default"},
+		Spec:       v1alpha1.VolumeUsageHistorySpec{PVCName: "data"},
+		Status: v1alpha1.VolumeUsageHistoryStatus{Minutes: []v1alpha1.UsageRollup{
+			{Time: sampled.Format(time.RFC3339), UsedBytes: 3 << 30, MaxUsedBytes: 3 << 30, CapacityBytes: 5 << 30, Samples: 1},
+		}},
+	}
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {}, hist)
+	pvc, _ := f.clientset.CoreV1().PersistentVolumeClaims("default").Get(context.TODO(), "data", metav1.GetOptions{})
+
+	if err := f.controller.recordUsageHistory(context.TODO(), pvc, f.usage); err != nil {
+		t.Fatalf("recordUsageHistory() error = %v", err)
+	}
+	prev, ok := f.controller.growth["default/data"]
+	if !ok || prev.usedGi != 3 || !prev.at.Equal(sampled) {
+		t.Errorf("Expected the growth sample seeded from the history, got %+v", prev)
+	}
+	if got := len(f.controller.histories["default/data"].status.Minutes); got != 2 {
+		t.Errorf("Expected the new sample appended to the loaded history, got %d minutes", got)
+	}
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// labelSizeGroup puts PVCs that should start at the same size in a group
+	labelSizeGroup = annotationPrefix + "size-group"
+
+	// Annotations explaining a raised initial size
+	annotationOriginalSize      = annotationPrefix + "original-size"
+	annotationInitialSizeReason = annotationPrefix + "initial-size-reason"
+
+	// Initial size strategies
+	initialSizeLargest = "largest"
+	initialSizeP95     = "p95"
+)
+
+// ordinalSuffix matches the <template>-<statefulset>-<ordinal> names of
+// StatefulSet claims.
+var ordinalSuffix = regexp.MustCompile(`^(.+)-[0-9]+$`)
+
+// sizeGroup describes the group of pvc and returns a matcher for its other
+// members: PVCs with the same volumescaler.io/size-group label, or else the
+// claims of the same volumeClaimTemplate of one of statefulSets. A name that
+// merely ends in digits, such as backup-2024, doesn't make a group. It
+// returns a nil matcher for PVCs outside any group.
+func sizeGroup(pvc *corev1.PersistentVolumeClaim, statefulSets []appsv1.StatefulSet) (string, func(*corev1.PersistentVolumeClaim) bool) {
+	if group := pvc.Labels[labelSizeGroup]; group != "" {
+		return fmt.Sprintf("size group '%s'", group), func(other *corev1.PersistentVolumeClaim) bool {
+			return other.Labels[labelSizeGroup] == group
+		}
+	}
+	m := ordinalSuffix.FindStringSubmatch(pvc.Name)
+	if m == nil || !claimTemplatePrefix(m[1], statefulSets) {
+		return "", nil
+	}
+	return fmt.Sprintf("'%s-<ordinal>'", m[1]), func(other *corev1.PersistentVolumeClaim) bool {
+		o := ordinalSuffix.FindStringSubmatch(other.Name)
+		return o != nil && o[1] == m[1]
+	}
+}
+
+// claimTemplatePrefix reports whether prefix is the <template>-<statefulset>
+// part of the claim names of one of statefulSets.
+func claimTemplatePrefix(prefix string, statefulSets []appsv1.StatefulSet) bool {
+	for _, sts := range statefulSets {
+		for _, tmpl := range sts.Spec.VolumeClaimTemplates {
+			if tmpl.Name+"-"+sts.Name == prefix {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// percentileSize returns the nearest-rank percentile p of sizes.
+func percentileSize(sizes []resource.Quantity, p int) resource.Quantity {
+	sorted := append([]resource.Quantity{}, sizes...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Cmp(sorted[j]) < 0 })
+	rank := (len(sorted)*p + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// suggestInitialSize raises the size requested by a new PVC to that of the
+// members of its group managed by a VolumeScaler, so it doesn't climb the
+// expansion ladder they already went through. The size is the largest of
+// theirs, or their 95th percentile with --initial-size-from=p95, capped at
+// the smallest maxSize of their VolumeScalers. The PVC is annotated with its
+// original size and the reason.
+func (w *WebhookServer) suggestInitialSize(ctx context.Context, req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	if req.Operation != admissionv1.Create {
+		return allowed()
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := json.Unmarshal(req.Object.Raw, pvc); err != nil {
+		return denied("cannot decode PersistentVolumeClaim: %v", err)
+	}
+	requested, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	if !ok {
+		return allowed()
+	}
+	if pvc.Name == "" {
+		pvc.Name = req.Name
+	}
+	// Never block PVC creation on a lookup failure
+	var statefulSets []appsv1.StatefulSet
+	if pvc.Labels[labelSizeGroup] == "" && ordinalSuffix.MatchString(pvc.Name) {
+		list, err := w.clientset.AppsV1().StatefulSets(req.Namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			fmt.Printf("[WARN] webhook: listing StatefulSets in '%s': %v\n", req.Namespace, err)
+			return allowed()
+		}
+		statefulSets = list.Items
+	}
+	group, inGroup := sizeGroup(pvc, statefulSets)
+	if inGroup == nil {
+		return allowed()
+	}
+
+	pvcs, err := w.clientset.CoreV1().PersistentVolumeClaims(req.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		fmt.Printf("[WARN] webhook: listing PVCs in '%s': %v\n", req.Namespace, err)
+		return allowed()
+	}
+	vsList, err := w.vsClient.AutoscalingV1alpha1().VolumeScalers(req.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		fmt.Printf("[WARN] webhook: listing VolumeScalers in '%s': %v\n", req.Namespace, err)
+		return allowed()
+	}
+	maxSizes := make(map[string]string, len(vsList.Items))
+	for _, vs := range vsList.Items {
+		maxSizes[vs.Spec.PVCName] = vs.Spec.MaxSize
+	}
+
+	var sizes []resource.Quantity
+	var maxSize *resource.Quantity
+	capAt := func(s string) {
+		q, err := resource.ParseQuantity(s)
+		if err == nil && (maxSize == nil || q.Cmp(*maxSize) < 0) {
+			maxSize = &q
+		}
+	}
+	for i := range pvcs.Items {
+		other := &pvcs.Items[i]
+		if other.Name == pvc.Name || !inGroup(other) {
+			continue
+		}
+		maxSizeStr, managed := maxSizes[other.Name]
+		if !managed {
+			continue
+		}
+		sizes = append(sizes, other.Spec.Resources.Requests[corev1.ResourceStorage])
+		capAt(maxSizeStr)
+	}
+	if len(sizes) == 0 {
+		return allowed()
+	}
+	if hasScalingAnnotations(pvc) {
+		capAt(pvc.Annotations[annotationMaxSize])
+	}
+
+	how := "largest size"
+	target := percentileSize(sizes, 100)
+	if w.config.InitialSizeFrom == initialSizeP95 {
+		how = "95th percentile size"
+		target = percentileSize(sizes, 95)
+	}
+	capped := ""
+	if maxSize != nil && target.Cmp(*maxSize) > 0 {
+		target, capped = *maxSize, ", capped at maxSize"
+	}
+	if target.Cmp(requested) <= 0 {
+		return allowed()
+	}
+	reason := fmt.Sprintf("Raised from %s to %s: %s of %d managed PVCs matching %s%s",
+		requested.String(), target.String(), how, len(sizes), group, capped)
+
+	ops := []jsonPatchOp{{Op: "add", Path: "/spec/resources/requests/storage", Value: target.String()}}
+	annotations := map[string]string{
+		annotationOriginalSize:      requested.String(),
+		annotationInitialSizeReason: reason,
+	}
+	if pvc.Annotations == nil {
+		ops = append(ops, jsonPatchOp{Op: "add", Path: "/metadata/annotations", Value: annotations})
+	} else {
+		for _, key := range []string{annotationOriginalSize, annotationInitialSizeReason} {
+			ops = append(ops, jsonPatchOp{
+				Op:    "add",
+				Path:  "/metadata/annotations/" + strings.ReplaceAll(key, "/", "~1"),
+				Value: annotations[key],
+			})
+		}
+	}
+	patch, err := json.Marshal(ops)
+	if err != nil {
+		return denied("cannot encode patch: %v", err)
+	}
+	fmt.Printf("[INFO] webhook: PVC '%s/%s': %s\n", req.Namespace, pvc.Name, reason)
+	patchType := admissionv1.PatchTypeJSONPatch
+	resp := allowed()
+	resp.Patch = patch
+	resp.PatchType = &patchType
+	return resp
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	vsfake "github.com/zghanem/sample-volumeScaler/pkg/generated/clientset/versioned/fake"
+)
+
+func sizedPVC(name, size string, labels map[string]string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+			},
+		},
+	}
+}
+
+// statefulSet returns a StatefulSet with volumeClaimTemplates named templates.
+func statefulSet(name string, templates ...string) *appsv1.StatefulSet {
+	sts := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	for _, tmpl := range templates {
+		sts.Spec.VolumeClaimTemplates = append(sts.Spec.VolumeClaimTemplates,
+			corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: tmpl}})
+	}
+	return sts
+}
+
+func managedBy(pvcName, maxSize string) *v1alpha1.VolumeScaler {
+	return &v1alpha1.VolumeScaler{
+		ObjectMeta: metav1.ObjectMeta{Name: pvcName, Namespace: "default"},
+		Spec:       v1alpha1.VolumeScalerSpec{PVCName: pvcName, Threshold: "70%", Scale: "10Gi", ScaleType: "fixed", MaxSize: maxSize},
+	}
+}
+
+// admitPVC sends the creation of pvc to the PVC webhook and returns the
+// patched values by path.
+func admitPVC(t *testing.T, config *ControllerConfig, pvc *corev1.PersistentVolumeClaim, pvcs []runtime.Object, scalers ...runtime.Object) map[string]interface{} {
+	t.Helper()
+	server := NewWebhookServer(config, kfake.NewSimpleClientset(pvcs...), vsfake.NewSimpleClientset(scalers...))
+	resp := sendAdmissionReview(t, server.Handler(), webhookPVCPath, admissionv1.Create, pvc)
+	if !resp.Allowed {
+		t.Fatalf("Expected PVC creation to be allowed, got %+v", resp.Result)
+	}
+	values := map[string]interface{}{}
+	if len(resp.Patch) == 0 {
+		return values
+	}
+	var ops []jsonPatchOp
+	if err := json.Unmarshal(resp.Patch, &ops); err != nil {
+		t.Fatalf("Failed to decode patch: %v", err)
+	}
+	for _, op := range ops {
+		values[op.Path] = op.Value
+	}
+	return values
+}
+
+func TestInitialSize_StatefulSetClaimJoinsSiblings(t *testing.T) {
+	pvcs := []runtime.Object{sizedPVC("data-db-0", "50Gi", nil), sizedPVC("data-db-1", "30Gi", nil), sizedPVC("data-cache-0", "200Gi", nil), statefulSet("db", "data")}
+	patch := admitPVC(t, NewDefaultConfig(), sizedPVC("data-db-2", "10Gi", nil), pvcs,
+		managedBy("data-db-0", "100Gi"), managedBy("data-db-1", "100Gi"), managedBy("data-cache-0", "500Gi"))
+
+	if got := patch["/spec/resources/requests/storage"]; got != "50Gi" {
+		t.Errorf("Expected the largest sibling size 50Gi, got %v", got)
+	}
+	annotations, _ := patch["/metadata/annotations"].(map[string]interface{})
+	if annotations[annotationOriginalSize] != "10Gi" {
+		t.Errorf("Expected the original size annotated, got %v", annotations)
+	}
+	if reason, _ := annotations[annotationInitialSizeReason].(string); !strings.Contains(reason, "2 managed PVCs matching 'data-db-<ordinal>'") {
+		t.Errorf("Expected the reason to name the group, got %q", reason)
+	}
+}
+
+func TestInitialSize_CappedAtMaxSize(t *testing.T) {
+	pvc := sizedPVC("data-db-1", "10Gi", nil)
+	pvc.Annotations = map[string]string{"team": "storage"}
+	patch := admitPVC(t, NewDefaultConfig(), pvc, []runtime.Object{sizedPVC("data-db-0", "50Gi", nil), statefulSet("db", "data")}, managedBy("data-db-0", "40Gi"))
+
+	if got := patch["/spec/resources/requests/storage"]; got != "40Gi" {
+		t.Errorf("Expected the size capped at maxSize 40Gi, got %v", got)
+	}
+	if reason, _ := patch["/metadata/annotations/volumescaler.io~1initial-size-reason"].(string); !strings.Contains(reason, "capped at maxSize") {
+		t.Errorf("Expected the cap in the reason, got %q", reason)
+	}
+}
+
+func TestInitialSize_SizeGroupLabel(t *testing.T) {
+	group := map[string]string{labelSizeGroup: "cache"}
+	patch := admitPVC(t, NewDefaultConfig(), sizedPVC("cache-b", "5Gi", group),
+		[]runtime.Object{sizedPVC("cache-a", "20Gi", group)}, managedBy("cache-a", "100Gi"))
+
+	if got := patch["/spec/resources/requests/storage"]; got != "20Gi" {
+		t.Errorf("Expected the size of the labeled group, got %v", got)
+	}
+}
+
+func TestInitialSize_LeavesPVCsAlone(t *testing.T) {
+	tests := []struct {
+		name          string
+		pvc           *corev1.PersistentVolumeClaim
+		scalers       []runtime.Object
+		noStatefulSet bool
+	}{
+		{name: "no group", pvc: sizedPVC("scratch", "10Gi", nil), scalers: []runtime.Object{managedBy("data-db-0", "100Gi")}},
+		{name: "unmanaged siblings", pvc: sizedPVC("data-db-1", "10Gi", nil)},
+		{name: "already larger", pvc: sizedPVC("data-db-1", "80Gi", nil), scalers: []runtime.Object{managedBy("data-db-0", "100Gi")}},
+		{name: "no StatefulSet", pvc: sizedPVC("data-db-1", "10Gi", nil), scalers: []runtime.Object{managedBy("data-db-0", "100Gi")}, noStatefulSet: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pvcs := []runtime.Object{sizedPVC("data-db-0", "50Gi", nil)}
+			if !tt.noStatefulSet {
+				pvcs = append(pvcs, statefulSet("db", "data"))
+			}
+			patch := admitPVC(t, NewDefaultConfig(), tt.pvc, pvcs, tt.scalers...)
+			if len(patch) != 0 {
+				t.Errorf("Expected no patch, got %v", patch)
+			}
+		})
+	}
+}
+
+func TestInitialSize_UnrelatedNumberedPVCs(t *testing.T) {
+	// backup-1 belongs to another app; backup-2024 only ends in digits
+	pvcs := []runtime.Object{sizedPVC("backup-1", "500Gi", nil), statefulSet("db", "data")}
+	patch := admitPVC(t, NewDefaultConfig(), sizedPVC("backup-2024", "10Gi", nil), pvcs, managedBy("backup-1", "1Ti"))
+
+	if len(patch) != 0 {
+		t.Errorf("Expected PVCs outside a StatefulSet left alone, got %v", patch)
+	}
+}
+
+func TestPercentileSize(t *testing.T) {
+	var sizes []resource.Quantity
+	for i := 20; i >= 1; i-- {
+		sizes = append(sizes, *resource.NewQuantity(int64(i)<<30, resource.BinarySI))
+	}
+	if got := percentileSize(sizes, 95); got.String() != "19Gi" {
+		t.Errorf("percentileSize(95) = %s, want 19Gi", got.String())
+	}
+	if got := percentileSize(sizes, 100); got.String() != "20Gi" {
+		t.Errorf("percentileSize(100) = %s, want 20Gi", got.String())
+	}
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+const (
+	// Event reasons
+	eventReasonLimitClamped          = "LimitClamped"
+	eventReasonNamespaceLimitReached = "NamespaceLimitReached"
+)
+
+// effectiveLimits is the most restrictive combination of every VolumeScalerLimit
+// matching a PVC. Zero values mean "no limit"; the *From fields name the
+// VolumeScalerLimit that set each value so clamps can be reported.
+type effectiveLimits struct {
+	MaxSizeGi        float64
+	MaxSizeFrom      string
+	MinCooldown      time.Duration
+	MinCooldownFrom  string
+	MaxIncrementGi   float64
+	MaxIncrementFrom string
+	MaxNamespaceGi   float64
+	MaxNamespaceFrom string
+
+	// Approval policy; the lowest thresholds and shortest expiry win
+	ApprovalSizeAboveGi      float64
+	ApprovalIncrementAboveGi float64
+	ApprovalExpiry           time.Duration
+	ApprovalFrom             string
+
+	// Namespace growth budget over the last 24 hours
+	NamespaceMaxExpansions int32
+	NamespaceMaxGrowthGi   float64
+	NamespaceBudgetFrom    string
+
+	// Monthly spend ceilings; the lowest win, each with its limit's action
+	NamespaceSpendLimit  float64
+	NamespaceSpendAction string
+	NamespaceSpendFrom   string
+	ClusterSpendLimit    float64
+	ClusterSpendAction   string
+	ClusterSpendFrom     string
+
+	// SpendApproval is set by applySpendBudget when the expansion being
+	// evaluated needs approval to exceed a spend ceiling
+	SpendApproval string
+}
+
+// limitMatches reports whether a limit applies to a PVC with the given
+// namespace labels and StorageClass. An unset selector or class list matches all.
+func limitMatches(limit *v1alpha1.VolumeScalerLimit, nsLabels map[string]string, storageClass string) (bool, error) {
+	if limit.Spec.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(limit.Spec.NamespaceSelector)
+		if err != nil {
+			return false, fmt.Errorf("invalid namespaceSelector on VolumeScalerLimit '%s': %v", limit.Name, err)
+		}
+		if !selector.Matches(labels.Set(nsLabels)) {
+			return false, nil
+		}
+	}
+	if len(limit.Spec.StorageClassNames) > 0 {
+		for _, sc := range limit.Spec.StorageClassNames {
+			if sc == storageClass {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+// mergeLimits folds the given limits into their most restrictive combination.
+func mergeLimits(limits []v1alpha1.VolumeScalerLimit) (*effectiveLimits, error) {
+	eff := &effectiveLimits{}
+	for i := range limits {
+		l := &limits[i]
+		if l.Spec.MaxSize != "" {
+			v, err := convertToGi(l.Spec.MaxSize)
+			if err != nil {
+				return nil, fmt.Errorf("VolumeScalerLimit '%s' maxSize: %v", l.Name, err)
+			}
+			if eff.MaxSizeGi == 0 || v < eff.MaxSizeGi {
+				eff.MaxSizeGi, eff.MaxSizeFrom = v, l.Name
+			}
+		}
+		if l.Spec.MinCooldownPeriod != "" {
+			v, err := time.ParseDuration(l.Spec.MinCooldownPeriod)
+			if err != nil {
+				return nil, fmt.Errorf("VolumeScalerLimit '%s' minCooldownPeriod: %v", l.Name, err)
+			}
+			if v > eff.MinCooldown {
+				eff.MinCooldown, eff.MinCooldownFrom = v, l.Name
+			}
+		}
+		if l.Spec.MaxIncrement != "" {
+			v, err := convertToGi(l.Spec.MaxIncrement)
+			if err != nil {
+				return nil, fmt.Errorf("VolumeScalerLimit '%s' maxIncrement: %v", l.Name, err)
+			}
+			if eff.MaxIncrementGi == 0 || v < eff.MaxIncrementGi {
+				eff.MaxIncrementGi, eff.MaxIncrementFrom = v, l.Name
+			}
+		}
+		if l.Spec.MaxNamespaceStorage != "" {
+			v, err := convertToGi(l.Spec.MaxNamespaceStorage)
+			if err != nil {
+				return nil, fmt.Errorf("VolumeScalerLimit '%s' maxNamespaceStorage: %v", l.Name, err)
+			}
+			if eff.MaxNamespaceGi == 0 || v < eff.MaxNamespaceGi {
+				eff.MaxNamespaceGi, eff.MaxNamespaceFrom = v, l.Name
+			}
+		}
+		if l.Spec.ApprovalPolicy != nil {
+			if err := eff.mergeApprovalPolicy(l.Spec.ApprovalPolicy, "VolumeScalerLimit '"+l.Name+"'"); err != nil {
+				return nil, err
+			}
+		}
+		if l.Spec.NamespaceGrowthBudget != nil {
+			if err := eff.mergeGrowthBudget(l.Spec.NamespaceGrowthBudget, l.Name); err != nil {
+				return nil, err
+			}
+		}
+		if l.Spec.SpendBudget != nil {
+			if err := eff.mergeSpendBudget(l.Spec.SpendBudget, l.Name); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return eff, nil
+}
+
+// loadEffectiveLimits lists the VolumeScalerLimits and merges the ones that
+// match the PVC's namespace and StorageClass.
+func (c *VolumeScalerController) loadEffectiveLimits(ctx context.Context, pvc *corev1.PersistentVolumeClaim) (*effectiveLimits, error) {
+	list, err := c.vsClient.AutoscalingV1alpha1().VolumeScalerLimits().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing VolumeScalerLimits: %v", err)
+	}
+	if list == nil || len(list.Items) == 0 {
+		return &effectiveLimits{}, nil
+	}
+
+	storageClass := ""
+	if pvc.Spec.StorageClassName != nil {
+		storageClass = *pvc.Spec.StorageClassName
+	}
+
+	var nsLabels map[string]string
+	nsFetched := false
+	var matched []v1alpha1.VolumeScalerLimit
+	for i := range list.Items {
+		limit := &list.Items[i]
+		if limit.Spec.NamespaceSelector != nil && !nsFetched {
+			ns, err := c.clientset.CoreV1().Namespaces().Get(ctx, pvc.Namespace, metav1.GetOptions{})
+			if err != nil && !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("fetching namespace '%s': %v", pvc.Namespace, err)
+			}
+			if err == nil {
+				nsLabels = ns.Labels
+			}
+			nsFetched = true
+		}
+		ok, err := limitMatches(limit, nsLabels, storageClass)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matched = append(matched, *limit)
+		}
+	}
+	return mergeLimits(matched)
+}
+
+// namespaceManagedGi sums the requested size of every PVC in the namespace
+// that is targeted by a VolumeScaler.
+func (c *VolumeScalerController) namespaceManagedGi(ctx context.Context, namespace string) (float64, error) {
+	vsList, err := c.vsClient.AutoscalingV1alpha1().VolumeScalers(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("listing VolumeScalers in '%s': %v", namespace, err)
+	}
+	managed := make(map[string]bool)
+	for _, vs := range vsList.Items {
+		managed[vs.Spec.PVCName] = true
+	}
+
+	pvcList, err := c.clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("listing PVCs in '%s': %v", namespace, err)
+	}
+	total := 0.0
+	for i := range pvcList.Items {
+		pvc := &pvcList.Items[i]
+		if !managed[pvc.Name] {
+			continue
+		}
+		sizeGi, err := convertToGi(pvc.Spec.Resources.Requests.Storage().String())
+		if err != nil {
+			continue
+		}
+		total += sizeGi
+	}
+	return total, nil
+}
+
+// clampToLimits applies the increment and namespace guardrails to a proposed
+// size. It returns the allowed size and a description of every clamp applied.
+func (c *VolumeScalerController) clampToLimits(ctx context.Context, limits *effectiveLimits, namespace string, currentGi, newSizeGi float64) (float64, []string, error) {
+	var clamps []string
+	if limits.MaxIncrementGi > 0 && newSizeGi-currentGi > limits.MaxIncrementGi {
+		clamped := currentGi + limits.MaxIncrementGi
+		clamps = append(clamps, fmt.Sprintf("increment %.0fGi -> %.0fGi (VolumeScalerLimit '%s')",
+			newSizeGi-currentGi, limits.MaxIncrementGi, limits.MaxIncrementFrom))
+		newSizeGi = clamped
+	}
+	if limits.MaxNamespaceGi > 0 {
+		usedGi, err := c.namespaceManagedGi(ctx, namespace)
+		if err != nil {
+			return 0, nil, err
+		}
+		headroom := limits.MaxNamespaceGi - usedGi
+		if headroom < 0 {
+			headroom = 0
+		}
+		if newSizeGi-currentGi > headroom {
+			clamps = append(clamps, fmt.Sprintf("namespace storage %.0fGi/%.0fGi allows +%.0fGi (VolumeScalerLimit '%s')",
+				usedGi, limits.MaxNamespaceGi, headroom, limits.MaxNamespaceFrom))
+			newSizeGi = currentGi + headroom
+		}
+	}
+	return newSizeGi, clamps, nil
+}
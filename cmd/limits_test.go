@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	vsfake "github.com/zghanem/sample-volumeScaler/pkg/generated/clientset/versioned/fake"
+)
+
+func TestLimitMatches(t *testing.T) {
+	tests := []struct {
+		name         string
+		spec         v1alpha1.VolumeScalerLimitSpec
+		nsLabels     map[string]string
+		storageClass string
+		expected     bool
+	}{
+		{
+			name:     "empty limit matches everything",
+			spec:     v1alpha1.VolumeScalerLimitSpec{},
+			expected: true,
+		},
+		{
+			name:     "namespace selector matches",
+			spec:     v1alpha1.VolumeScalerLimitSpec{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}},
+			nsLabels: map[string]string{"team": "a"},
+			expected: true,
+		},
+		{
+			name:     "namespace selector does not match",
+			spec:     v1alpha1.VolumeScalerLimitSpec{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}},
+			nsLabels: map[string]string{"team": "b"},
+			expected: false,
+		},
+		{
+			name:         "storage class matches",
+			spec:         v1alpha1.VolumeScalerLimitSpec{StorageClassNames: []string{"gp3", "io2"}},
+			storageClass: "io2",
+			expected:     true,
+		},
+		{
+			name:         "storage class does not match",
+			spec:         v1alpha1.VolumeScalerLimitSpec{StorageClassNames: []string{"gp3"}},
+			storageClass: "io2",
+			expected:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit := &v1alpha1.VolumeScalerLimit{ObjectMeta: metav1.ObjectMeta{Name: "limit"}, Spec: tt.spec}
+			got, err := limitMatches(limit, tt.nsLabels, tt.storageClass)
+			if err != nil {
+				t.Fatalf("limitMatches() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("limitMatches() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMergeLimits(t *testing.T) {
+	limits := []v1alpha1.VolumeScalerLimit{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-default"},
+			Spec:       v1alpha1.VolumeScalerLimitSpec{MaxSize: "1Ti", MinCooldownPeriod: "10m", MaxIncrement: "100Gi"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-a"},
+			Spec:       v1alpha1.VolumeScalerLimitSpec{MaxSize: "200Gi", MinCooldownPeriod: "5m", MaxNamespaceStorage: "2Ti"},
+		},
+	}
+
+	got, err := mergeLimits(limits)
+	if err != nil {
+		t.Fatalf("mergeLimits() error = %v", err)
+	}
+	if got.MaxSizeGi != 200 || got.MaxSizeFrom != "team-a" {
+		t.Errorf("MaxSize = %v from %q, want 200 from team-a", got.MaxSizeGi, got.MaxSizeFrom)
+	}
+	if got.MinCooldown != 10*time.Minute || got.MinCooldownFrom != "cluster-default" {
+		t.Errorf("MinCooldown = %v from %q, want 10m from cluster-default", got.MinCooldown, got.MinCooldownFrom)
+	}
+	if got.MaxIncrementGi != 100 {
+		t.Errorf("MaxIncrement = %v, want 100", got.MaxIncrementGi)
+	}
+	if got.MaxNamespaceGi != 2048 {
+		t.Errorf("MaxNamespace = %v, want 2048", got.MaxNamespaceGi)
+	}
+
+	if _, err := mergeLimits([]v1alpha1.VolumeScalerLimit{{Spec: v1alpha1.VolumeScalerLimitSpec{MaxSize: "lots"}}}); err == nil {
+		t.Error("Expected error for invalid maxSize")
+	}
+}
+
+func TestReconcilePVC_Limits(t *testing.T) {
+	tests := []struct {
+		name         string
+		limit        v1alpha1.VolumeScalerLimitSpec
+		otherPVCSize string
+		expectedSize string
+		expectClamp  string
+	}{
+		{
+			name:         "increment clamped",
+			limit:        v1alpha1.VolumeScalerLimitSpec{MaxIncrement: "3Gi"},
+			expectedSize: "13Gi",
+			expectClamp:  "increment",
+		},
+		{
+			name:         "max size clamped",
+			limit:        v1alpha1.VolumeScalerLimitSpec{MaxSize: "12Gi"},
+			expectedSize: "12Gi",
+			expectClamp:  "maxSize",
+		},
+		{
+			name:         "namespace storage exhausted",
+			limit:        v1alpha1.VolumeScalerLimitSpec{MaxNamespaceStorage: "30Gi"},
+			otherPVCSize: "20Gi",
+			expectedSize: "10Gi",
+			expectClamp:  "namespace storage",
+		},
+		{
+			name:         "no limit applies",
+			limit:        v1alpha1.VolumeScalerLimitSpec{StorageClassNames: []string{"other"}, MaxSize: "11Gi"},
+			expectedSize: "15Gi",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			pvc := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default"},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+					},
+				},
+				Status: corev1.PersistentVolumeClaimStatus{
+					Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+				},
+			}
+			clientset := kfake.NewSimpleClientset(pvc)
+
+			vs := &v1alpha1.VolumeScaler{
+				ObjectMeta: metav1.ObjectMeta{Name: "data-vs", Namespace: "default"},
+				Spec:       v1alpha1.VolumeScalerSpec{PVCName: "data", Threshold: "70%", Scale: "50%", ScaleType: "percentage", MaxSize: "100Gi"},
+			}
+			objects := []runtime.Object{vs, &v1alpha1.VolumeScalerLimit{
+				ObjectMeta: metav1.ObjectMeta{Name: "guardrail"},
+				Spec:       tt.limit,
+			}}
+			if tt.otherPVCSize != "" {
+				other := pvc.DeepCopy()
+				other.Name = "other"
+				other.Spec.Resources.Requests[corev1.ResourceStorage] = resource.MustParse(tt.otherPVCSize)
+				if _, err := clientset.CoreV1().PersistentVolumeClaims("default").Create(ctx, other, metav1.CreateOptions{}); err != nil {
+					t.Fatalf("Failed to create PVC: %v", err)
+				}
+				objects = append(objects, &v1alpha1.VolumeScaler{
+					ObjectMeta: metav1.ObjectMeta{Name: "other-vs", Namespace: "default"},
+					Spec:       v1alpha1.VolumeScalerSpec{PVCName: "other", Threshold: "70%", Scale: "2Gi", ScaleType: "fixed", MaxSize: "100Gi"},
+				})
+			}
+
+			vsClient := vsfake.NewSimpleClientset(objects...)
+			controller := NewVolumeScalerController(NewDefaultConfig(), clientset, vsClient, record.NewFakeRecorder(100))
+
+			usage := &PVCUsageInfo{UsedGi: 9.0, UsagePercent: 90}
+			vsName := types.NamespacedName{Namespace: "default", Name: "data-vs"}
+			if err := controller.reconcilePVC(ctx, pvc, vs, vsName, usage); err != nil {
+				t.Fatalf("reconcilePVC() error = %v", err)
+			}
+
+			updated, _ := clientset.CoreV1().PersistentVolumeClaims("default").Get(ctx, "data", metav1.GetOptions{})
+			if size := updated.Spec.Resources.Requests[corev1.ResourceStorage]; size.String() != tt.expectedSize {
+				t.Errorf("Expected PVC size %s, got %s", tt.expectedSize, size.String())
+			}
+
+			result, err := vsClient.AutoscalingV1alpha1().VolumeScalers("default").Get(ctx, "data-vs", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Failed to get VolumeScaler: %v", err)
+			}
+			clamp := result.Status.LimitClamp
+			if tt.expectClamp == "" && clamp != "" {
+				t.Errorf("Expected no limitClamp, got %q", clamp)
+			}
+			if tt.expectClamp != "" && !strings.Contains(clamp, tt.expectClamp) {
+				t.Errorf("Expected limitClamp to mention %q, got %q", tt.expectClamp, clamp)
+			}
+		})
+	}
+}
@@ -3,16 +3,17 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
@@ -20,6 +21,9 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/record"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	"github.com/zghanem/sample-volumeScaler/pkg/generated/clientset/versioned"
 )
 
 const (
@@ -39,42 +43,10 @@ const (
 	// Scale types
 	scaleTypeFixed      = "fixed"
 	scaleTypePercentage = "percentage"
+	// scaleTypeLegacyPercentage is the original name of the percentage scale type
+	scaleTypeLegacyPercentage = "VolumeScaler"
 )
 
-// VolumeScalerSpec defines the desired state of VolumeScaler
-type VolumeScalerSpec struct {
-	PVCName        string `json:"pvcName"`
-	Threshold      string `json:"threshold"`      // e.g., "70%"
-	Scale          string `json:"scale"`          // e.g., "2Gi" or "30%"
-	ScaleType      string `json:"scaleType"`      // "fixed" or "percentage"
-	CooldownPeriod string `json:"cooldownPeriod"` // e.g. "10m"
-	MaxSize        string `json:"maxSize"`        // e.g., "15Gi"
-}
-
-// VolumeScalerStatus defines the observed state of VolumeScaler
-type VolumeScalerStatus struct {
-	ScaledAt            string `json:"scaledAt,omitempty"`
-	ReachedMaxSize      bool   `json:"reachedMaxSize,omitempty"`
-	ResizeInProgress    bool   `json:"resizeInProgress,omitempty"`
-	LastRequestedSize   string `json:"lastRequestedSize,omitempty"`
-	CurrentUsagePercent int    `json:"currentUsagePercent,omitempty"`
-	CurrentUsedGi       string `json:"currentUsedGi,omitempty"`
-	CurrentSizeGi       string `json:"currentSizeGi,omitempty"`
-}
-
-// VolumeScaler is the Schema for the volumescalers API
-type VolumeScaler struct {
-	metav1.TypeMeta   `json:",inline"`
-	metav1.ObjectMeta `json:"metadata,omitempty"`
-
-	Spec   VolumeScalerSpec   `json:"spec"`
-	Status VolumeScalerStatus `json:"status,omitempty"`
-
-	// Additional fields for Kubernetes API compatibility
-	APIVersion string `json:"apiVersion,omitempty"`
-	Kind       string `json:"kind,omitempty"`
-}
-
 // StorageSize represents a storage size with unit
 type StorageSize string
 
@@ -97,6 +69,53 @@ type ControllerConfig struct {
 	PollInterval time.Duration
 	MaxRetries   int
 	Timeout      time.Duration
+
+	// Admission webhooks are served only when WebhookCertDir is set
+	WebhookPort    int
+	WebhookCertDir string
+
+	// RecommendOnly runs every Enforce-mode VolumeScaler in Recommend mode
+	RecommendOnly bool
+
+	// MaxConcurrentResizes caps resizes in flight across the cluster; 0 means
+	// unlimited. Slots are Leases in ResizeLeaseNamespace.
+	MaxConcurrentResizes int
+	ResizeLeaseNamespace string
+
+	// VolumeRecommendations publishes a VolumeRecommendation for every mounted
+	// PVC, sized for its peak usage over RecommendationWindow at
+	// RecommendationTargetUsage percent
+	VolumeRecommendations     bool
+	RecommendationWindow      time.Duration
+	RecommendationTargetUsage int
+
+	// InitialSizeFrom picks the size the PVC webhook gives new members of a
+	// managed group: "largest" or "p95"
+	InitialSizeFrom string
+
+	// PriceTableConfigMap ("name" or "namespace/name") enables cost tracking;
+	// metrics are served on MetricsAddr unless it is empty
+	PriceTableConfigMap string
+	MetricsAddr         string
+
+	// UsageHistory keeps a VolumeUsageHistory for every managed PVC, written
+	// every HistoryFlushInterval
+	UsageHistory         bool
+	HistoryFlushInterval time.Duration
+
+	// AuditTrail records every scaling decision as a VolumeScaleEvent, kept
+	// for AuditRetention and at most AuditMaxEvents per VolumeScaler
+	AuditTrail     bool
+	AuditRetention time.Duration
+	AuditMaxEvents int
+
+	// EventRepeatInterval is how often an event for an ongoing state, such
+	// as StillResizing or CooldownActive, is repeated; 0 emits it every loop
+	EventRepeatInterval time.Duration
+
+	// Shrink lets spec.shrink migrate PVCs, which needs RBAC to delete PVCs,
+	// update workloads and create Jobs. Without it shrinks are only recommended.
+	Shrink bool
 }
 
 // NewDefaultConfig returns a default controller configuration with predefined values
@@ -106,6 +125,24 @@ func NewDefaultConfig() *ControllerConfig {
 		PollInterval: defaultPollInterval,
 		MaxRetries:   defaultMaxRetries,
 		Timeout:      defaultTimeout,
+		WebhookPort:  defaultWebhookPort,
+
+		ResizeLeaseNamespace: defaultLeaseNamespace(),
+
+		RecommendationWindow:      defaultRecommendationWindow,
+		RecommendationTargetUsage: defaultRecommendationTargetUsage,
+
+		InitialSizeFrom: initialSizeLargest,
+		MetricsAddr:     defaultMetricsAddr,
+
+		UsageHistory:         true,
+		HistoryFlushInterval: defaultHistoryFlushInterval,
+
+		AuditTrail:     true,
+		AuditRetention: defaultAuditRetention,
+		AuditMaxEvents: defaultAuditMaxEvents,
+
+		EventRepeatInterval: defaultEventRepeatInterval,
 	}
 }
 
@@ -316,10 +353,10 @@ func checkAndHandleResizeFailedEvents(ctx context.Context, clientset kubernetes.
 }
 
 // makeInvolvedObjectRef creates an ObjectReference so events appear on the CR
-func makeInvolvedObjectRef(vsName types.NamespacedName, vsObj *VolumeScaler) *corev1.ObjectReference {
+func makeInvolvedObjectRef(vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler) *corev1.ObjectReference {
 	return &corev1.ObjectReference{
-		APIVersion: vsObj.APIVersion,
-		Kind:       vsObj.Kind,
+		APIVersion: v1alpha1.SchemeGroupVersion.String(),
+		Kind:       "VolumeScaler",
 		Namespace:  vsName.Namespace,
 		Name:       vsName.Name,
 		UID:        vsObj.ObjectMeta.UID,
@@ -355,17 +392,17 @@ func canScaleNow(lastScaledAtStr string, cooldown time.Duration) (bool, error) {
 // computeNewSize calculates the new PVC size based on the current size and scaling policy.
 func computeNewSize(scale, scaleType string, currentSizeGi float64) (float64, error) {
 	switch scaleType {
-	case "fixed":
+	case scaleTypeFixed:
 		fixedInc, err := convertToGi(scale)
 		if err != nil {
 			return 0, fmt.Errorf("invalid fixed scale '%s': %v", scale, err)
 		}
 		return currentSizeGi + fixedInc, nil
-	case "VolumeScaler":
+	case scaleTypePercentage, scaleTypeLegacyPercentage:
 		scaleStr := strings.TrimSuffix(scale, "%")
 		scaleF, err := strconv.ParseFloat(scaleStr, 64)
 		if err != nil {
-			return 0, fmt.Errorf("invalid VolumeScaler scale '%s': %v", scale, err)
+			return 0, fmt.Errorf("invalid percentage scale '%s': %v", scale, err)
 		}
 		inc := currentSizeGi * (scaleF / 100.0)
 		return currentSizeGi + inc, nil
@@ -391,19 +428,44 @@ func computeNewSize(scale, scaleType string, currentSizeGi float64) (float64, er
 type VolumeScalerController struct {
 	config    *ControllerConfig
 	clientset kubernetes.Interface
-	dynClient dynamic.Interface
+	vsClient  versioned.Interface
 	recorder  record.EventRecorder
-	gvr       schema.GroupVersionResource
+
+	// breaches maps a PVC key to when its usage went above the threshold
+	breaches map[string]time.Time
+	// growth holds the previous usage sample of each PVC
+	growth map[string]growthSample
+
+	// dynamicClient reaches APIs without a typed client, such as VolumeSnapshots
+	dynamicClient dynamic.Interface
+
+	// prices is the price table, reloaded every loop; nil disables cost tracking
+	prices *priceTable
+	// costSeries holds the cost metric labels of each PVC on this node
+	costSeries map[string]prometheus.Labels
+	// classProvisioners caches the provisioner of each StorageClass for a loop
+	classProvisioners map[string]string
+	// histories holds the usage history of each PVC on this node
+	histories map[string]*usageHistory
+	// lastDecisions holds the last audited decision of each PVC, and
+	// auditPruned when the VolumeScaleEvents of each namespace were pruned
+	lastDecisions map[string]string
+	auditPruned   map[string]time.Time
+	// stateEvents holds the events repeated every loop while a state lasts
+	stateEvents map[string]*repeatedEvent
+	// slotWaiters holds the PVCs left waiting for a resize slot this loop, and
+	// queued the VolumeScaler of each PVC this node put in the resize queue
+	slotWaiters map[string]bool
+	queued      map[string]types.NamespacedName
 }
 
 // NewVolumeScalerController creates a new instance of VolumeScalerController.
-func NewVolumeScalerController(config *ControllerConfig, clientset kubernetes.Interface, dynClient dynamic.Interface, recorder record.EventRecorder, gvr schema.GroupVersionResource) *VolumeScalerController {
+func NewVolumeScalerController(config *ControllerConfig, clientset kubernetes.Interface, vsClient versioned.Interface, recorder record.EventRecorder) *VolumeScalerController {
 	return &VolumeScalerController{
 		config:    config,
 		clientset: clientset,
-		dynClient: dynClient,
+		vsClient:  vsClient,
 		recorder:  recorder,
-		gvr:       gvr,
 	}
 }
 
@@ -439,57 +501,96 @@ func (c *VolumeScalerController) reconcileLoop(ctx context.Context) error {
 		return fmt.Errorf("fetching PVC usage from node '%s': %v", nodeName, err)
 	}
 
+	c.pruneVolumeState(pvcUsageMap)
+	c.leaveResizeQueue(ctx, pvcUsageMap)
+	c.pruneStateEvents(time.Now())
+	c.loadPriceTable(ctx)
+	c.classProvisioners = nil
+
+	// (B) List all VolumeScalers
+	vsList, err := c.vsClient.AutoscalingV1alpha1().VolumeScalers("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("listing VolumeScalers: %v", err)
+	}
+
+	// Shrink migrations keep going while their PVC is unmounted and reports no usage
+	c.reconcileShrinks(ctx, nodeName, vsList)
+
 	if len(pvcUsageMap) == 0 {
 		fmt.Println("[INFO] No PVC usage data found on this node. Sleeping...")
 		return nil
 	}
 
-	// (B) List all VolumeScalers
-	vsList, err := c.dynClient.Resource(c.gvr).List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return fmt.Errorf("listing VolumeScalers: %v", err)
+	vsCandidates := make(map[string][]scalerCandidate)
+	for i := range vsList.Items {
+		vsObj := &vsList.Items[i]
+		key := vsObj.Namespace + "/" + vsObj.Spec.PVCName
+		vsCandidates[key] = append(vsCandidates[key], scalerCandidate{
+			obj: vsObj,
+			name: types.NamespacedName{
+				Namespace: vsObj.Namespace,
+				Name:      vsObj.Name,
+			},
+		})
 	}
 
-	vsMap := make(map[string]*VolumeScaler)
+	// Several VolumeScalers may target the same PVC; pick one deterministically
+	// and flag the conflict on all of them.
+	vsMap := make(map[string]*v1alpha1.VolumeScaler)
 	vsUnstructMap := make(map[string]types.NamespacedName)
-	if vsList != nil && len(vsList.Items) > 0 {
-		for _, unstr := range vsList.Items {
-			vsObj := &VolumeScaler{}
-			err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstr.Object, vsObj)
-			if err != nil {
-				fmt.Printf("[ERROR] converting VolumeScaler: %v\n", err)
-				continue
-			}
-			key := unstr.GetNamespace() + "/" + vsObj.Spec.PVCName
-			vsMap[key] = vsObj
-			vsUnstructMap[key] = types.NamespacedName{
-				Namespace: unstr.GetNamespace(),
-				Name:      unstr.GetName(),
-			}
+	for key, cands := range vsCandidates {
+		sortScalerCandidates(cands)
+		vsMap[key] = cands[0].obj
+		vsUnstructMap[key] = cands[0].name
+		if _, onNode := pvcUsageMap[key]; onNode {
+			c.reportScalerConflict(ctx, key, cands)
 		}
 	}
 
-	// (C) For each PVC with usage data and a VolumeScaler, reconcile
+	// (C) For each PVC with usage data and a VolumeScaler (or scaling annotations), reconcile
+	pvcs := c.fetchNodePVCs(ctx, pvcUsageMap, vsMap)
 	for pvcKey, usageInfo := range pvcUsageMap {
 		vsObj, hasScaler := vsMap[pvcKey]
-		if !hasScaler {
+		vsName := vsUnstructMap[pvcKey]
+		pvc, ok := pvcs[pvcKey]
+		if !ok {
 			continue
 		}
 
-		// Fetch the actual PVC object
-		parts := strings.SplitN(pvcKey, "/", 2)
-		if len(parts) != 2 {
-			continue
+		if c.config.VolumeRecommendations {
+			if err := c.recordVolumeRecommendation(ctx, pvc, usageInfo, vsObj); err != nil {
+				fmt.Printf("[ERROR] updating VolumeRecommendation for PVC '%s': %v\n", pvcKey, err)
+			}
 		}
-		ns, pvcName := parts[0], parts[1]
 
-		pvc, err := c.clientset.CoreV1().PersistentVolumeClaims(ns).Get(ctx, pvcName, metav1.GetOptions{})
-		if err != nil {
-			fmt.Printf("[ERROR] fetching PVC '%s': %v\n", pvcKey, err)
-			continue
+		// PVCs without a VolumeScaler may opt in through volumescaler.io/* annotations
+		if !hasScaler {
+			vsObj, vsName, err = c.ensureAnnotationScaler(ctx, pvc)
+			if err != nil {
+				fmt.Printf("[ERROR] %v\n", err)
+				continue
+			}
+			if vsObj == nil {
+				continue
+			}
+		} else if isAnnotationManaged(vsObj) {
+			vsObj, err = c.syncAnnotationScaler(ctx, pvc, vsObj, vsName)
+			if err != nil {
+				fmt.Printf("[ERROR] %v\n", err)
+				continue
+			}
+			if vsObj == nil {
+				continue
+			}
+		}
+
+		if c.config.UsageHistory {
+			if err := c.recordUsageHistory(ctx, pvc, usageInfo); err != nil {
+				fmt.Printf("[ERROR] updating VolumeUsageHistory for PVC '%s': %v\n", pvcKey, err)
+			}
 		}
 
-		if err := c.reconcilePVC(ctx, pvc, vsObj, vsUnstructMap[pvcKey], usageInfo); err != nil {
+		if err := c.reconcilePVC(ctx, pvc, vsObj, vsName, usageInfo); err != nil {
 			fmt.Printf("[ERROR] reconciling PVC '%s': %v\n", pvcKey, err)
 		}
 	}
@@ -497,15 +598,73 @@ func (c *VolumeScalerController) reconcileLoop(ctx context.Context) error {
 	return nil
 }
 
+// fetchNodePVCs returns the PVCs reporting usage on this node. PVCs with a
+// VolumeScaler are fetched one by one; the others, which only matter for
+// scaling annotations and recommendations, with one List per namespace.
+func (c *VolumeScalerController) fetchNodePVCs(ctx context.Context, pvcUsageMap map[string]*PVCUsageInfo, vsMap map[string]*v1alpha1.VolumeScaler) map[string]*corev1.PersistentVolumeClaim {
+	pvcs := make(map[string]*corev1.PersistentVolumeClaim)
+	listed := make(map[string]bool)
+	for pvcKey := range pvcUsageMap {
+		ns, _, ok := strings.Cut(pvcKey, "/")
+		if _, managed := vsMap[pvcKey]; managed || !ok || listed[ns] {
+			continue
+		}
+		listed[ns] = true
+		list, err := c.clientset.CoreV1().PersistentVolumeClaims(ns).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			fmt.Printf("[ERROR] listing PVCs in '%s': %v\n", ns, err)
+			continue
+		}
+		for i := range list.Items {
+			key := ns + "/" + list.Items[i].Name
+			if _, onNode := pvcUsageMap[key]; onNode {
+				pvcs[key] = &list.Items[i]
+			}
+		}
+	}
+
+	for pvcKey := range pvcUsageMap {
+		if _, managed := vsMap[pvcKey]; !managed || pvcs[pvcKey] != nil {
+			continue
+		}
+		ns, name, _ := strings.Cut(pvcKey, "/")
+		pvc, err := c.clientset.CoreV1().PersistentVolumeClaims(ns).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			fmt.Printf("[ERROR] fetching PVC '%s': %v\n", pvcKey, err)
+			continue
+		}
+		pvcs[pvcKey] = pvc
+	}
+	return pvcs
+}
+
 // reconcilePVC evaluates a single PVC against its VolumeScaler configuration and
 // triggers scaling operations when thresholds are exceeded.
 //
 // Instead of relying on host-level mount paths and the "df" command, this function
 // receives pre-computed usage information from the kubelet stats/summary API,
 // making it portable across all Kubernetes environments.
-func (c *VolumeScalerController) reconcilePVC(ctx context.Context, pvc *corev1.PersistentVolumeClaim, vsObj *VolumeScaler, vsName types.NamespacedName, usageInfo *PVCUsageInfo) error {
+func (c *VolumeScalerController) reconcilePVC(ctx context.Context, pvc *corev1.PersistentVolumeClaim, vsObj *v1alpha1.VolumeScaler, vsName types.NamespacedName, usageInfo *PVCUsageInfo) error {
 	invRef := makeInvolvedObjectRef(vsName, vsObj)
 
+	// A volume stays in the resize queue only while it waits for a slot; any
+	// other outcome of this loop, such as suspension or a cooldown, leaves it
+	// so that it doesn't hold back the volumes that do wait.
+	pvcKey := vsName.Namespace + "/" + pvc.Name
+	delete(c.slotWaiters, pvcKey)
+	defer func() {
+		if c.slotWaiters[pvcKey] {
+			return
+		}
+		delete(c.queued, pvcKey)
+		if vsObj.Status.QueuedSince == "" {
+			return
+		}
+		if err := c.patchQueuePosition(ctx, vsName, vsObj, 0); err != nil {
+			fmt.Printf("[WARN] %v\n", err)
+		}
+	}()
+
 	// 1) parse threshold
 	thresholdF, err := strconv.ParseFloat(strings.TrimSuffix(vsObj.Spec.Threshold, "%"), 64)
 	if err != nil {
@@ -551,62 +710,203 @@ func (c *VolumeScalerController) reconcilePVC(ctx context.Context, pvc *corev1.P
 	usagePatch := []byte(fmt.Sprintf(
 		`{"status":{"currentUsagePercent":%d,"currentUsedGi":"%.1fGi","currentSizeGi":"%.0fGi"}}`,
 		displayUsagePercent, displayUsedGi, specSizeGi))
-	_, err = c.dynClient.Resource(c.gvr).Namespace(vsName.Namespace).
+	_, err = c.vsClient.AutoscalingV1alpha1().VolumeScalers(vsName.Namespace).
 		Patch(ctx, vsName.Name, types.MergePatchType, usagePatch, metav1.PatchOptions{}, "status")
 	if err != nil {
 		fmt.Printf("[WARN] failed to patch usage status for '%s/%s': %v\n", vsName.Namespace, vsName.Name, err)
 	}
+	vsObj.Status.CurrentUsagePercent = displayUsagePercent
+	vsObj.Status.CurrentUsedGi = fmt.Sprintf("%.1fGi", displayUsedGi)
+	vsObj.Status.CurrentSizeGi = fmt.Sprintf("%.0fGi", specSizeGi)
+
+	// 4b0) price the PVC and accrue its cost
+	if err := c.trackCost(ctx, vsName, vsObj, pvc, specSizeGi, maxSizeGi); err != nil {
+		fmt.Printf("[WARN] %v\n", err)
+	}
+
+	// 4b') track how long usage has stayed above the threshold
+	breachingSince, err := c.trackBreach(ctx, vsName, vsObj, pvcKey, usagePercent, thresholdF)
+	if err != nil {
+		fmt.Printf("[WARN] %v\n", err)
+	}
+
+	// 4b'') measure the growth rate; runaway growth holds threshold expansions
+	growthHeld, err := c.trackGrowth(ctx, invRef, vsName, vsObj, pvcKey, usageInfo.UsedGi)
+	if err != nil {
+		fmt.Printf("[WARN] %v\n", err)
+	}
+
+	// 4b''') sustained low usage starts a shrink; expansions wait while it migrates the PVC
+	if err := c.trackLowUsage(ctx, invRef, vsName, vsObj, pvc, usageInfo.UsedGi, specSizeGi, usagePercent); err != nil {
+		fmt.Printf("[WARN] %v\n", err)
+	}
+	if shrinkMigrating(vsObj.Status.Shrink) {
+		fmt.Printf("[INFO] PVC '%s' is being shrunk (%s); skipping expansion.\n", pvcKey, vsObj.Status.Shrink.Phase)
+		return nil
+	}
 
 	// 5) is a resize in progress?
 	inProgress := statusSizeGi < specSizeGi
 
-	// 6) if was in progress but now complete
+	// 6) if was in progress but now complete; recorded before requests and
+	// schedules can start the next expansion, which the cooldown from the
+	// new scaledAt holds back for threshold scaling
 	if vsObj.Status.ResizeInProgress && !inProgress {
 		reachedMax := specSizeGi >= maxSizeGi
 		msg := fmt.Sprintf("PVC '%s/%s' expansion complete. Capacity=%.0fGi, usage=%d%%.",
 			vsName.Namespace, pvc.Name, statusSizeGi, usagePercent)
-		c.recorder.Event(invRef, corev1.EventTypeNormal, eventReasonResizeComplete, msg)
+		c.eventWithPVC(invRef, pvc, corev1.EventTypeNormal, eventReasonResizeComplete, msg)
 		fmt.Printf("[INFO] %s\n", msg)
+		c.recordDecision(ctx, vsName, vsObj, pvc.Name, scaleDecision{
+			decision:    decisionCompleted,
+			currentSize: fmt.Sprintf("%.0fGi", statusSizeGi),
+			message:     msg,
+		})
 
 		nowStr := time.Now().UTC().Format(time.RFC3339)
 		patchDone := []byte(fmt.Sprintf(
 			`{"status":{"resizeInProgress":false,"scaledAt":"%s","reachedMaxSize":%t}}`,
 			nowStr, reachedMax))
-		_, err = c.dynClient.Resource(c.gvr).Namespace(vsName.Namespace).
+		_, err = c.vsClient.AutoscalingV1alpha1().VolumeScalers(vsName.Namespace).
 			Patch(ctx, vsName.Name, types.MergePatchType, patchDone, metav1.PatchOptions{}, "status")
 		if err != nil {
 			return fmt.Errorf("patching resize completion: %v", err)
 		}
+		if err := c.releaseResizeSlot(ctx, pvcKey); err != nil {
+			fmt.Printf("[WARN] %v\n", err)
+		}
+		vsObj.Status.ResizeInProgress, vsObj.Status.ScaledAt, vsObj.Status.ReachedMaxSize = false, nowStr, reachedMax
+	}
+
+	// 4c) on-demand VolumeScaleRequests take precedence over threshold scaling
+	requested, err := c.reconcileScaleRequests(ctx, invRef, vsName, vsObj, pvc, usageInfo.UsedGi, specSizeGi, statusSizeGi, maxSizeGi)
+	if err != nil {
+		c.recorder.Eventf(invRef, corev1.EventTypeWarning, eventReasonScaleRequestFailed,
+			"Failed processing VolumeScaleRequests: %v", err)
+		return fmt.Errorf("processing VolumeScaleRequests: %v", err)
+	}
+	if requested {
+		return nil
+	}
+
+	// 4d) scheduled raises, and the blackout window deferring threshold expansions
+	scheduled, blackoutUntil, err := c.reconcileSchedules(ctx, invRef, vsName, vsObj, pvc, specSizeGi, statusSizeGi, maxSizeGi)
+	if err != nil {
+		return fmt.Errorf("evaluating schedules: %v", err)
+	}
+	if scheduled {
 		return nil
 	}
 
 	// 7) if still in progress, log an event
 	if inProgress {
+		if err := c.renewResizeSlot(ctx, pvcKey); err != nil {
+			fmt.Printf("[WARN] %v\n", err)
+		}
 		pvcErrMsg := checkAndHandleResizeFailedEvents(ctx, c.clientset, pvc.Name, vsName.Namespace)
 		if pvcErrMsg != "" {
 			logMsg := fmt.Sprintf(
 				"PVC '%s/%s' still resizing (Spec=%.0fGi, Status=%.0fGi) due to '%s'. usage=%dGi (%d%%).",
 				vsName.Namespace, pvc.Name, specSizeGi, statusSizeGi, pvcErrMsg, usedGi, usagePercent)
-			c.recorder.Event(invRef, corev1.EventTypeWarning, eventReasonStillResizing, logMsg)
+			c.stateEvent(invRef, corev1.EventTypeWarning, eventReasonStillResizing, logMsg)
 			fmt.Printf("[ERROR] %s\n", logMsg)
+			c.recordDecision(ctx, vsName, vsObj, pvc.Name, scaleDecision{
+				decision:     decisionFailed,
+				currentSize:  fmt.Sprintf("%.0fGi", statusSizeGi),
+				computedSize: fmt.Sprintf("%.0fGi", specSizeGi),
+				message:      logMsg,
+			})
 		} else {
 			logMsg := fmt.Sprintf(
 				"PVC '%s/%s' still resizing (Spec=%.0fGi, Status=%.0fGi). usage=%dGi (%d%%).",
 				vsName.Namespace, pvc.Name, specSizeGi, statusSizeGi, usedGi, usagePercent)
-			c.recorder.Event(invRef, corev1.EventTypeWarning, eventReasonStillResizing, logMsg)
+			c.stateEvent(invRef, corev1.EventTypeWarning, eventReasonStillResizing, logMsg)
 			fmt.Printf("[ERROR] %s\n", logMsg)
 		}
 		return nil
 	}
 
+	// 7b) suspended scalers and Observe mode stop after reporting usage
+	if vsObj.Spec.Suspend {
+		fmt.Printf("[INFO] VolumeScaler '%s/%s' is suspended; skipping expansion.\n", vsName.Namespace, vsName.Name)
+		return nil
+	}
+	mode := c.effectiveMode(vsObj)
+	switch mode {
+	case modeObserve:
+		return nil
+	case modeRecommend, modeEnforce:
+	default:
+		c.recorder.Eventf(invRef, corev1.EventTypeWarning, eventReasonInvalidMode,
+			"Mode '%s' invalid; use Observe, Recommend or Enforce", vsObj.Spec.Mode)
+		return fmt.Errorf("invalid mode '%s'", vsObj.Spec.Mode)
+	}
+
 	// 8) usage >= threshold => attempt to expand
 	if usagePercent >= int(thresholdF) {
+		critical := criticalUsage(vsObj, usagePercent)
+		trigger := triggerThreshold
+		if critical {
+			trigger = triggerCritical
+		}
+		currentSize := fmt.Sprintf("%.0fGi", specSizeGi)
+		if growthHeld {
+			msg := fmt.Sprintf(
+				"PVC '%s/%s' usage=%d%% >= threshold=%s, but expansions are held for anomalous growth. Annotate the VolumeScaler with %s to resume.",
+				vsName.Namespace, pvc.Name, usagePercent, vsObj.Spec.Threshold, annotationAcknowledgeGrowth)
+			fmt.Printf("[WARNING] %s\n", msg)
+			c.stateEvent(invRef, corev1.EventTypeWarning, eventReasonGrowthHeld, msg)
+			c.recordDecision(ctx, vsName, vsObj, pvc.Name, scaleDecision{
+				decision: decisionHeld, trigger: trigger, currentSize: currentSize, message: msg,
+			})
+			return nil
+		}
+		if c.deferForBlackout(invRef, vsName, vsObj, pvc, blackoutUntil, usagePercent) {
+			return nil
+		}
+
+		// Short spikes don't expand the PVC: the breach has to last breachDuration
+		if vsObj.Spec.BreachDuration != "" && !critical {
+			breachDuration, err := time.ParseDuration(vsObj.Spec.BreachDuration)
+			if err != nil {
+				c.recorder.Eventf(invRef, corev1.EventTypeWarning, "InvalidBreachDuration",
+					"BreachDuration '%s' invalid: %v", vsObj.Spec.BreachDuration, err)
+				return fmt.Errorf("invalid breach duration: %v", err)
+			}
+			if time.Since(breachingSince) < breachDuration {
+				msg := fmt.Sprintf(
+					"PVC '%s/%s' usage=%d%% >= threshold=%s since %s; expanding once the breach lasts %s.",
+					vsName.Namespace, pvc.Name, usagePercent, vsObj.Spec.Threshold, formatStatusTime(breachingSince), breachDuration)
+				fmt.Printf("[INFO] %s\n", msg)
+				c.stateEvent(invRef, corev1.EventTypeNormal, eventReasonBreachPending, msg)
+				return nil
+			}
+		}
+
+		// Admin guardrails from matching VolumeScalerLimits
+		limits, err := c.loadEffectiveLimits(ctx, pvc)
+		if err != nil {
+			c.recorder.Eventf(invRef, corev1.EventTypeWarning, "LimitLookupFailed",
+				"Failed to evaluate VolumeScalerLimits: %v", err)
+			return fmt.Errorf("evaluating limits: %v", err)
+		}
+		var clamps []string
+
 		cd, err := parseCooldownDuration(vsObj.Spec.CooldownPeriod)
 		if err != nil {
 			c.recorder.Eventf(invRef, corev1.EventTypeWarning, "InvalidCooldown",
 				"CooldownPeriod '%s' invalid: %v", vsObj.Spec.CooldownPeriod, err)
 			return fmt.Errorf("invalid cooldown period: %v", err)
 		}
+		if critical {
+			// Critical usage skips the VolumeScaler's cooldown, but not the
+			// minimum an administrator set for the storage provider
+			cd = limits.MinCooldown
+		} else if limits.MinCooldown > cd {
+			clamps = append(clamps, fmt.Sprintf("cooldownPeriod %s -> %s (VolumeScalerLimit '%s')",
+				cd, limits.MinCooldown, limits.MinCooldownFrom))
+			cd = limits.MinCooldown
+		}
 
 		okToScale, err := canScaleNow(vsObj.Status.ScaledAt, cd)
 		if err != nil {
@@ -615,30 +915,51 @@ func (c *VolumeScalerController) reconcilePVC(ctx context.Context, pvc *corev1.P
 			return fmt.Errorf("checking cooldown: %v", err)
 		}
 
+		if !okToScale && critical {
+			msg := fmt.Sprintf(
+				"PVC '%s/%s' usage=%d%% >= criticalThreshold=%s, but within the %s minimum cooldown of VolumeScalerLimit '%s'. Skipping expansion.",
+				vsName.Namespace, pvc.Name, usagePercent, vsObj.Spec.CriticalThreshold, limits.MinCooldown, limits.MinCooldownFrom)
+			fmt.Printf("[WARNING] %s\n", msg)
+			c.stateEvent(invRef, corev1.EventTypeWarning, eventReasonCriticalUsage, msg)
+			c.recordDecision(ctx, vsName, vsObj, pvc.Name, scaleDecision{
+				decision: decisionSkippedCooldown, trigger: trigger, currentSize: currentSize, clamps: strings.Join(clamps, "; "), message: msg,
+			})
+			return nil
+		}
 		if !okToScale {
 			msg := fmt.Sprintf(
 				"PVC '%s/%s' usage=%d%% >= threshold=%s, but in cooldown. Skipping expansion.",
 				vsName.Namespace, pvc.Name, usagePercent, vsObj.Spec.Threshold)
 			fmt.Printf("[INFO] %s\n", msg)
-			c.recorder.Event(invRef, corev1.EventTypeNormal, eventReasonCooldownActive, msg)
+			c.stateEvent(invRef, corev1.EventTypeNormal, eventReasonCooldownActive, msg)
+			c.recordDecision(ctx, vsName, vsObj, pvc.Name, scaleDecision{
+				decision: decisionSkippedCooldown, trigger: trigger, currentSize: currentSize, clamps: strings.Join(clamps, "; "), message: msg,
+			})
 			return nil
 		}
 
 		// compute new size
-		newSizeGi, err := computeNewSize(vsObj.Spec.Scale, vsObj.Spec.ScaleType, specSizeGi)
+		scale, scaleType := expansionScale(vsObj, critical)
+		newSizeGi, err := computeNewSize(scale, scaleType, specSizeGi)
 		if err != nil {
 			c.recorder.Eventf(invRef, corev1.EventTypeWarning, "ScaleParseError",
 				"Failed parsing scale '%s' with type '%s': %v",
-				vsObj.Spec.Scale, vsObj.Spec.ScaleType, err)
+				scale, scaleType, err)
 			return fmt.Errorf("computing new size: %v", err)
 		}
 
+		if limits.MaxSizeGi > 0 && limits.MaxSizeGi < maxSizeGi {
+			clamps = append(clamps, fmt.Sprintf("maxSize %.0fGi -> %.0fGi (VolumeScalerLimit '%s')",
+				maxSizeGi, limits.MaxSizeGi, limits.MaxSizeFrom))
+			maxSizeGi = limits.MaxSizeGi
+		}
+
 		// If we can't scale up because we're at max size, mark as reached max size
 		if newSizeGi > maxSizeGi {
 			newSizeGi = maxSizeGi
 			if specSizeGi >= maxSizeGi {
 				patchData := []byte(`{"status":{"reachedMaxSize":true}}`)
-				_, err = c.dynClient.Resource(c.gvr).Namespace(vsName.Namespace).
+				_, err = c.vsClient.AutoscalingV1alpha1().VolumeScalers(vsName.Namespace).
 					Patch(ctx, vsName.Name, types.MergePatchType, patchData, metav1.PatchOptions{}, "status")
 				if err != nil {
 					return fmt.Errorf("patching reachedMaxSize: %v", err)
@@ -646,12 +967,53 @@ func (c *VolumeScalerController) reconcilePVC(ctx context.Context, pvc *corev1.P
 
 				msg := fmt.Sprintf("PVC '%s/%s' reached maxSize=%.0fGi. usage=%d%%",
 					vsName.Namespace, pvc.Name, maxSizeGi, usagePercent)
-				c.recorder.Event(invRef, corev1.EventTypeWarning, eventReasonAtMaxSize, msg)
+				c.stateEvent(invRef, corev1.EventTypeWarning, eventReasonAtMaxSize, msg)
 				fmt.Printf("[WARNING] %s\n", msg)
+				c.recordDecision(ctx, vsName, vsObj, pvc.Name, scaleDecision{
+					decision: decisionAtMaxSize, trigger: trigger, currentSize: currentSize, clamps: strings.Join(clamps, "; "), message: msg,
+				})
 				return nil
 			}
 		}
 
+		newSizeGi, sizeClamps, err := c.clampToLimits(ctx, limits, vsName.Namespace, specSizeGi, newSizeGi)
+		if err != nil {
+			c.recorder.Eventf(invRef, corev1.EventTypeWarning, "LimitLookupFailed",
+				"Failed to apply VolumeScalerLimits: %v", err)
+			return fmt.Errorf("applying limits: %v", err)
+		}
+		newSizeGi, budgetClamps, _, err := c.applyGrowthBudgets(ctx, invRef, vsName, vsObj, limits, specSizeGi, newSizeGi)
+		if err != nil {
+			return fmt.Errorf("applying growth budgets: %v", err)
+		}
+		sizeClamps = append(sizeClamps, budgetClamps...)
+		newSizeGi, spendClamps, _, err := c.applySpendBudget(ctx, invRef, vsName, vsObj, pvc, limits, specSizeGi, newSizeGi)
+		if err != nil {
+			return fmt.Errorf("applying spend budget: %v", err)
+		}
+		sizeClamps = append(sizeClamps, spendClamps...)
+		clamps = append(clamps, sizeClamps...)
+		clampMsg := strings.Join(clamps, "; ")
+		clampJSON, _ := json.Marshal(clampMsg)
+
+		if newSizeGi <= specSizeGi && len(sizeClamps) > 0 {
+			patchData := []byte(fmt.Sprintf(`{"status":{"limitClamp":%s}}`, clampJSON))
+			_, err = c.vsClient.AutoscalingV1alpha1().VolumeScalers(vsName.Namespace).
+				Patch(ctx, vsName.Name, types.MergePatchType, patchData, metav1.PatchOptions{}, "status")
+			if err != nil {
+				return fmt.Errorf("patching limitClamp: %v", err)
+			}
+
+			msg := fmt.Sprintf("PVC '%s/%s' usage=%d%% >= threshold=%s, but expansion is blocked: %s",
+				vsName.Namespace, pvc.Name, usagePercent, vsObj.Spec.Threshold, clampMsg)
+			c.stateEvent(invRef, corev1.EventTypeWarning, eventReasonNamespaceLimitReached, msg)
+			fmt.Printf("[WARNING] %s\n", msg)
+			c.recordDecision(ctx, vsName, vsObj, pvc.Name, scaleDecision{
+				decision: decisionBlocked, trigger: trigger, currentSize: currentSize, clamps: clampMsg, message: msg,
+			})
+			return nil
+		}
+
 		if newSizeGi <= specSizeGi {
 			msg := fmt.Sprintf(
 				"Computed newSize=%.0fGi <= current=%.0fGi. usage=%d%% => no net expansion.",
@@ -661,37 +1023,114 @@ func (c *VolumeScalerController) reconcilePVC(ctx context.Context, pvc *corev1.P
 		}
 
 		newSizeStr := fmt.Sprintf("%.0fGi", newSizeGi)
-		pvcPatch := []byte(fmt.Sprintf(`{"spec":{"resources":{"requests":{"storage":"%s"}}}}`, newSizeStr))
-		_, err = c.clientset.CoreV1().PersistentVolumeClaims(vsName.Namespace).Patch(
-			ctx, pvc.Name, types.MergePatchType, pvcPatch, metav1.PatchOptions{})
+		if mode == modeRecommend {
+			reason := fmt.Sprintf("usage=%d%% >= threshold=%s", usagePercent, vsObj.Spec.Threshold)
+			if clampMsg != "" {
+				reason += "; " + clampMsg
+			}
+			c.recordDecision(ctx, vsName, vsObj, pvc.Name, scaleDecision{
+				decision: decisionRecommended, trigger: trigger, currentSize: currentSize, computedSize: newSizeStr, clamps: clampMsg, message: reason,
+			})
+			return c.recordRecommendation(ctx, invRef, vsName, vsObj, pvc, specSizeGi, newSizeStr, reason)
+		}
+
+		// Expansions covered by an approval policy wait for an approved VolumeScaleRequest
+		if vsObj.Spec.ApprovalPolicy != nil {
+			if err := limits.mergeApprovalPolicy(vsObj.Spec.ApprovalPolicy, "VolumeScaler"); err != nil {
+				c.recorder.Eventf(invRef, corev1.EventTypeWarning, "InvalidApprovalPolicy", "%v", err)
+				return fmt.Errorf("invalid approval policy: %v", err)
+			}
+		}
+		var approvedReq *v1alpha1.VolumeScaleRequest
+		if reason := approvalReason(limits, specSizeGi, newSizeGi); reason != "" {
+			approvedReq, err = c.checkApproval(ctx, invRef, vsName, vsObj, pvc, limits, specSizeGi, newSizeGi, maxSizeGi, reason)
+			if err != nil {
+				return fmt.Errorf("checking approval: %v", err)
+			}
+			if approvedReq == nil {
+				return nil
+			}
+			newSizeStr = approvedReq.Spec.RequestedSize
+		} else if err := c.clearCondition(ctx, vsName, vsObj, conditionTypeApprovalRequired); err != nil {
+			return err
+		}
+
+		// Take the pre-expansion snapshot first; it may need several loops to become ready
+		snapshotReady, err := c.ensurePreResizeSnapshot(ctx, invRef, vsName, vsObj, pvc)
 		if err != nil {
-			msg := fmt.Sprintf(
-				"Failed initiating expansion from %.0fGi -> %s: %v",
-				specSizeGi, newSizeStr, err)
-			c.recorder.Event(invRef, corev1.EventTypeWarning, eventReasonResizeFailed, msg)
-			fmt.Printf("[ERROR] %s\n", msg)
-			return fmt.Errorf("patching PVC: %v", err)
+			return fmt.Errorf("taking pre-resize snapshot: %v", err)
+		}
+		if !snapshotReady {
+			return nil
+		}
+
+		// Under a cluster-wide limit on concurrent resizes, wait for a slot
+		granted, err := c.acquireResizeSlot(ctx, invRef, vsName, vsObj, pvcKey)
+		if err != nil {
+			return fmt.Errorf("acquiring resize slot: %v", err)
+		}
+		if !granted {
+			fmt.Printf("[INFO] PVC '%s' is waiting for a resize slot at position %d.\n", pvcKey, vsObj.Status.QueuePosition)
+			return nil
+		}
+
+		reason := fmt.Sprintf("usage=%d%% >= threshold=%s", usagePercent, vsObj.Spec.Threshold)
+		if critical {
+			reason = fmt.Sprintf("usage=%d%% >= criticalThreshold=%s", usagePercent, vsObj.Spec.CriticalThreshold)
+		}
+		if approvedReq != nil {
+			reason += fmt.Sprintf(", approved in VolumeScaleRequest '%s'", approvedReq.Name)
+		}
+		if err := c.startExpansion(ctx, invRef, vsName, vsObj, pvc, specSizeGi, newSizeStr, clampMsg, trigger, reason); err != nil {
+			fmt.Printf("[ERROR] %v\n", err)
+			return err
 		}
 
 		succMsg := fmt.Sprintf(
 			"Initiated resize of PVC '%s/%s' from %.0fGi -> %s. usage=%d%%, used=%dGi",
 			vsName.Namespace, pvc.Name, specSizeGi, newSizeStr, usagePercent, usedGi)
-		c.recorder.Event(invRef, corev1.EventTypeNormal, eventReasonResizeRequested, succMsg)
+		if approvedReq != nil {
+			succMsg += fmt.Sprintf(" (approved in VolumeScaleRequest '%s')", approvedReq.Name)
+			if err := c.markRequestApplied(ctx, approvedReq); err != nil {
+				fmt.Printf("[WARN] %v\n", err)
+			}
+			if err := c.clearCondition(ctx, vsName, vsObj, conditionTypeApprovalRequired); err != nil {
+				fmt.Printf("[WARN] %v\n", err)
+			}
+		}
+		if critical {
+			// Escalate so critical expansions stand out from routine ones
+			succMsg += fmt.Sprintf(" Usage reached criticalThreshold=%s; cooldown skipped.", vsObj.Spec.CriticalThreshold)
+			c.eventWithPVC(invRef, pvc, corev1.EventTypeWarning, eventReasonCriticalUsage, succMsg)
+		} else {
+			c.eventWithPVC(invRef, pvc, corev1.EventTypeNormal, eventReasonResizeRequested, succMsg)
+		}
 		fmt.Printf("[INFO] %s\n", succMsg)
+		c.recordDecision(ctx, vsName, vsObj, pvc.Name, scaleDecision{
+			decision: decisionExpanded, trigger: trigger, currentSize: currentSize, computedSize: newSizeStr, clamps: clampMsg, message: succMsg,
+		})
+		if len(clamps) > 0 {
+			c.stateEvent(invRef, corev1.EventTypeNormal, eventReasonLimitClamped, fmt.Sprintf(
+				"Expansion of PVC '%s/%s' was limited by policy: %s", vsName.Namespace, pvc.Name, clampMsg))
+		}
 
-		nowStr := time.Now().UTC().Format(time.RFC3339)
-		stPatch := []byte(fmt.Sprintf(
-			`{"status":{"resizeInProgress":true,"lastRequestedSize":"%s","scaledAt":"%s"}}`,
-			newSizeStr, nowStr))
-		_, err = c.dynClient.Resource(c.gvr).Namespace(vsName.Namespace).
-			Patch(ctx, vsName.Name, types.MergePatchType, stPatch, metav1.PatchOptions{}, "status")
-		if err != nil {
-			return fmt.Errorf("patching VolumeScaler status: %v", err)
+		if err := c.resetBreach(ctx, vsName, vsObj, pvcKey); err != nil {
+			fmt.Printf("[WARN] %v\n", err)
+		}
+		if err := c.clearRecommendation(ctx, vsName, vsObj); err != nil {
+			return err
 		}
 	} else {
 		msg := fmt.Sprintf("PVC '%s/%s' usage=%d%% < threshold=%s; no expansion needed.",
 			vsName.Namespace, pvc.Name, usagePercent, vsObj.Spec.Threshold)
 		fmt.Printf("[INFO] %s\n", msg)
+		if err := c.clearRecommendation(ctx, vsName, vsObj); err != nil {
+			return err
+		}
+		// A snapshot taken for this breach is no restore point for the next one
+		if err := c.discardPendingSnapshot(ctx, vsName, vsObj); err != nil {
+			fmt.Printf("[WARN] %v\n", err)
+		}
 	}
 
 	return nil
@@ -701,6 +1140,49 @@ func (c *VolumeScalerController) reconcilePVC(ctx context.Context, pvc *corev1.P
 // main
 // ------------------------------------------------------------
 func main() {
+	ctrlConfig := NewDefaultConfig()
+	flag.IntVar(&ctrlConfig.WebhookPort, "webhook-port", ctrlConfig.WebhookPort, "Port for the admission webhook server.")
+	flag.StringVar(&ctrlConfig.WebhookCertDir, "webhook-cert-dir", ctrlConfig.WebhookCertDir,
+		"Directory holding tls.crt and tls.key for the admission webhook server. Webhooks are disabled when empty.")
+	flag.BoolVar(&ctrlConfig.RecommendOnly, "recommend-only", ctrlConfig.RecommendOnly,
+		"Run every VolumeScaler in Recommend mode: record proposed expansions without patching PVCs.")
+	flag.BoolVar(&ctrlConfig.Shrink, "shrink", ctrlConfig.Shrink,
+		"Let spec.shrink migrate PVCs to smaller ones. Without it, shrinks are only recommended.")
+	flag.IntVar(&ctrlConfig.MaxConcurrentResizes, "max-concurrent-resizes", ctrlConfig.MaxConcurrentResizes,
+		"Maximum number of PVC resizes in flight across the cluster. 0 means unlimited.")
+	flag.StringVar(&ctrlConfig.ResizeLeaseNamespace, "resize-lease-namespace", ctrlConfig.ResizeLeaseNamespace,
+		"Namespace of the Leases that coordinate --max-concurrent-resizes. Defaults to the controller's namespace.")
+	flag.BoolVar(&ctrlConfig.VolumeRecommendations, "volume-recommendations", ctrlConfig.VolumeRecommendations,
+		"Publish a VolumeRecommendation with a right-sized capacity for every mounted PVC, with or without a VolumeScaler.")
+	flag.DurationVar(&ctrlConfig.RecommendationWindow, "recommendation-window", ctrlConfig.RecommendationWindow,
+		"How far back peak usage is tracked for VolumeRecommendations.")
+	flag.IntVar(&ctrlConfig.RecommendationTargetUsage, "recommendation-target-usage", ctrlConfig.RecommendationTargetUsage,
+		"Usage percent the peak should reach at the recommended size.")
+	flag.StringVar(&ctrlConfig.InitialSizeFrom, "initial-size-from", ctrlConfig.InitialSizeFrom,
+		"Size given to new PVCs joining a managed group by the PVC webhook: \"largest\" or \"p95\" of the group.")
+	flag.StringVar(&ctrlConfig.PriceTableConfigMap, "price-table-configmap", ctrlConfig.PriceTableConfigMap,
+		"ConfigMap (\"name\" in the controller's namespace, or \"namespace/name\") with per-GiB-month prices. Enables cost tracking.")
+	flag.StringVar(&ctrlConfig.MetricsAddr, "metrics-addr", ctrlConfig.MetricsAddr,
+		"Address to serve Prometheus metrics on. Metrics are disabled when empty.")
+	flag.BoolVar(&ctrlConfig.UsageHistory, "usage-history", ctrlConfig.UsageHistory,
+		"Keep a VolumeUsageHistory with minute, hour and day usage rollups for every managed PVC.")
+	flag.DurationVar(&ctrlConfig.HistoryFlushInterval, "usage-history-flush-interval", ctrlConfig.HistoryFlushInterval,
+		"How often usage samples are written to VolumeUsageHistories. Samples not yet written are lost on restart.")
+	flag.BoolVar(&ctrlConfig.AuditTrail, "audit-trail", ctrlConfig.AuditTrail,
+		"Record every scaling decision as a VolumeScaleEvent.")
+	flag.DurationVar(&ctrlConfig.AuditRetention, "audit-retention", ctrlConfig.AuditRetention,
+		"How long VolumeScaleEvents are kept. 0 keeps them until --audit-max-events is reached.")
+	flag.IntVar(&ctrlConfig.AuditMaxEvents, "audit-max-events", ctrlConfig.AuditMaxEvents,
+		"VolumeScaleEvents kept per VolumeScaler; the oldest are deleted first. 0 means no limit.")
+	flag.DurationVar(&ctrlConfig.EventRepeatInterval, "event-repeat-interval", ctrlConfig.EventRepeatInterval,
+		"How often events for an ongoing state, such as StillResizing or CooldownActive, are repeated with an occurrence count. 0 emits them every loop.")
+	flag.Parse()
+
+	if ctrlConfig.InitialSizeFrom != initialSizeLargest && ctrlConfig.InitialSizeFrom != initialSizeP95 {
+		fmt.Printf("[FATAL] --initial-size-from must be %q or %q, got %q\n", initialSizeLargest, initialSizeP95, ctrlConfig.InitialSizeFrom)
+		os.Exit(1)
+	}
+
 	config, err := inClusterOrKubeconfig()
 	if err != nil {
 		fmt.Printf("[FATAL] Failed to get Kubernetes config: %v\n", err)
@@ -713,9 +1195,9 @@ func main() {
 		os.Exit(1)
 	}
 
-	dynClient, err := dynamic.NewForConfig(config)
+	vsClient, err := versioned.NewForConfig(config)
 	if err != nil {
-		fmt.Printf("[FATAL] Failed to create dynamic client: %v\n", err)
+		fmt.Printf("[FATAL] Failed to create VolumeScaler client: %v\n", err)
 		os.Exit(1)
 	}
 
@@ -727,23 +1209,39 @@ func main() {
 	})
 	recorder := broadcaster.NewRecorder(scheme, corev1.EventSource{Component: "volumescaler-controller"})
 
-	// Updated GVR to use new group/version
-	gvr := schema.GroupVersionResource{
-		Group:    "autoscaling.storage.k8s.io",
-		Version:  "v1alpha1",
-		Resource: "volumescalers",
-	}
-
 	controller := NewVolumeScalerController(
-		NewDefaultConfig(),
+		ctrlConfig,
 		clientset,
-		dynClient,
+		vsClient,
 		recorder,
-		gvr,
 	)
+	controller.dynamicClient, err = dynamic.NewForConfig(config)
+	if err != nil {
+		fmt.Printf("[FATAL] Failed to create dynamic client: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	if ctrlConfig.MetricsAddr != "" {
+		go func() {
+			if err := serveMetrics(ctx, ctrlConfig.MetricsAddr); err != nil {
+				fmt.Printf("[FATAL] %v\n", err)
+				os.Exit(1)
+			}
+		}()
+	}
+	if ctrlConfig.WebhookCertDir != "" {
+		webhook := NewWebhookServer(ctrlConfig, clientset, vsClient)
+		go func() {
+			if err := webhook.Run(ctx); err != nil {
+				fmt.Printf("[FATAL] %v\n", err)
+				os.Exit(1)
+			}
+		}()
+	}
 
 	fmt.Println("Starting VolumeScaler operator...")
-	if err := controller.Run(context.Background()); err != nil {
+	if err := controller.Run(ctx); err != nil {
 		fmt.Printf("[FATAL] Controller failed: %v\n", err)
 		os.Exit(1)
 	}
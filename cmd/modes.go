@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+const (
+	// Operating modes
+	modeObserve   = "Observe"
+	modeRecommend = "Recommend"
+	modeEnforce   = "Enforce"
+
+	// Event reasons
+	eventReasonScaleRecommended = "ScaleRecommended"
+	eventReasonInvalidMode      = "InvalidMode"
+)
+
+// effectiveMode returns the mode a VolumeScaler runs in. An empty mode means
+// Enforce, and the controller-wide RecommendOnly setting turns Enforce into
+// Recommend.
+func (c *VolumeScalerController) effectiveMode(vsObj *v1alpha1.VolumeScaler) string {
+	mode := vsObj.Spec.Mode
+	if mode == "" {
+		mode = modeEnforce
+	}
+	if c.config.RecommendOnly && mode == modeEnforce {
+		return modeRecommend
+	}
+	return mode
+}
+
+// recordRecommendation stores the expansion the controller would have made in
+// the VolumeScaler status. The status and event are only written when the
+// proposed size changes so repeated loops don't flood the event stream.
+func (c *VolumeScalerController) recordRecommendation(ctx context.Context, invRef *corev1.ObjectReference, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, pvc *corev1.PersistentVolumeClaim, currentSizeGi float64, newSizeStr, reason string) error {
+	if vsObj.Status.RecommendedSize == newSizeStr {
+		return nil
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{
+			"recommendedSize":      newSizeStr,
+			"recommendationReason": reason,
+			"recommendedAt":        time.Now().UTC().Format(time.RFC3339),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("encoding recommendation patch: %v", err)
+	}
+	_, err = c.vsClient.AutoscalingV1alpha1().VolumeScalers(vsName.Namespace).
+		Patch(ctx, vsName.Name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+	if err != nil {
+		return fmt.Errorf("patching recommendation: %v", err)
+	}
+
+	msg := fmt.Sprintf("Recommend expanding PVC '%s/%s' from %.0fGi -> %s: %s",
+		vsName.Namespace, pvc.Name, currentSizeGi, newSizeStr, reason)
+	c.recorder.Event(invRef, corev1.EventTypeNormal, eventReasonScaleRecommended, msg)
+	fmt.Printf("[INFO] %s\n", msg)
+	return nil
+}
+
+// clearRecommendation removes a previously recorded recommendation once it no
+// longer applies.
+func (c *VolumeScalerController) clearRecommendation(ctx context.Context, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler) error {
+	if vsObj.Status.RecommendedSize == "" {
+		return nil
+	}
+	patch := []byte(`{"status":{"recommendedSize":null,"recommendationReason":null,"recommendedAt":null}}`)
+	_, err := c.vsClient.AutoscalingV1alpha1().VolumeScalers(vsName.Namespace).
+		Patch(ctx, vsName.Name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+	if err != nil {
+		return fmt.Errorf("clearing recommendation: %v", err)
+	}
+	return nil
+}
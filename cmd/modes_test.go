@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	vsfake "github.com/zghanem/sample-volumeScaler/pkg/generated/clientset/versioned/fake"
+)
+
+// drainEvents returns the events recorded so far.
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case e := <-recorder.Events:
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
+
+func TestReconcilePVC_Modes(t *testing.T) {
+	tests := []struct {
+		name                string
+		mode                string
+		suspend             bool
+		recommendOnly       bool
+		expectResize        bool
+		expectRecommendedGi string
+	}{
+		{name: "default mode enforces", expectResize: true},
+		{name: "enforce", mode: modeEnforce, expectResize: true},
+		{name: "observe", mode: modeObserve},
+		{name: "recommend", mode: modeRecommend, expectRecommendedGi: "7Gi"},
+		{name: "suspended", mode: modeEnforce, suspend: true},
+		{name: "recommend-only flag overrides enforce", recommendOnly: true, expectRecommendedGi: "7Gi"},
+		{name: "recommend-only flag keeps observe", mode: modeObserve, recommendOnly: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pvc := &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default"},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")},
+					},
+				},
+				Status: corev1.PersistentVolumeClaimStatus{
+					Capacity: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")},
+				},
+			}
+			vs := &v1alpha1.VolumeScaler{
+				ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default"},
+				Spec: v1alpha1.VolumeScalerSpec{
+					PVCName: "data", Threshold: "70%", Scale: "2Gi", ScaleType: "fixed", CooldownPeriod: "5m", MaxSize: "10Gi",
+					Mode: tt.mode, Suspend: tt.suspend,
+				},
+			}
+			clientset := kfake.NewSimpleClientset(pvc)
+			vsClient := vsfake.NewSimpleClientset(vs)
+			recorder := record.NewFakeRecorder(100)
+			config := NewDefaultConfig()
+			config.RecommendOnly = tt.recommendOnly
+			controller := NewVolumeScalerController(config, clientset, vsClient, recorder)
+
+			usage := &PVCUsageInfo{UsedBytes: 4 << 30, CapacityBytes: 5 << 30, AvailableBytes: 1 << 30, UsagePercent: 80, UsedGi: 4.0}
+			vsName := types.NamespacedName{Namespace: "default", Name: "data"}
+			if err := controller.reconcilePVC(context.TODO(), pvc, vs, vsName, usage); err != nil {
+				t.Fatalf("reconcilePVC() error = %v", err)
+			}
+
+			updatedPVC, _ := clientset.CoreV1().PersistentVolumeClaims("default").Get(context.TODO(), "data", metav1.GetOptions{})
+			size := updatedPVC.Spec.Resources.Requests[corev1.ResourceStorage]
+			if resized := size.Cmp(resource.MustParse("5Gi")) > 0; resized != tt.expectResize {
+				t.Errorf("PVC resized = %v, want %v (size %s)", resized, tt.expectResize, size.String())
+			}
+
+			updatedVS, _ := vsClient.AutoscalingV1alpha1().VolumeScalers("default").Get(context.TODO(), "data", metav1.GetOptions{})
+			if updatedVS.Status.RecommendedSize != tt.expectRecommendedGi {
+				t.Errorf("status.recommendedSize = %q, want %q", updatedVS.Status.RecommendedSize, tt.expectRecommendedGi)
+			}
+			if updatedVS.Status.CurrentUsagePercent != 80 {
+				t.Errorf("Expected usage to be reported in every mode, got %d%%", updatedVS.Status.CurrentUsagePercent)
+			}
+			if tt.expectRecommendedGi == "" {
+				return
+			}
+
+			events := drainEvents(recorder)
+			if len(events) != 1 || !strings.Contains(events[0], eventReasonScaleRecommended) {
+				t.Fatalf("Expected one %s event, got %v", eventReasonScaleRecommended, events)
+			}
+
+			// The same recommendation on the next loop is not repeated
+			if err := controller.reconcilePVC(context.TODO(), pvc, updatedVS, vsName, usage); err != nil {
+				t.Fatalf("reconcilePVC() error = %v", err)
+			}
+			if events := drainEvents(recorder); len(events) != 0 {
+				t.Errorf("Expected no further events, got %v", events)
+			}
+
+			// Usage below the threshold clears the recommendation
+			low := &PVCUsageInfo{UsedBytes: 1 << 30, CapacityBytes: 5 << 30, AvailableBytes: 4 << 30, UsagePercent: 20, UsedGi: 1.0}
+			if err := controller.reconcilePVC(context.TODO(), pvc, updatedVS, vsName, low); err != nil {
+				t.Fatalf("reconcilePVC() error = %v", err)
+			}
+			cleared, _ := vsClient.AutoscalingV1alpha1().VolumeScalers("default").Get(context.TODO(), "data", metav1.GetOptions{})
+			if cleared.Status.RecommendedSize != "" || cleared.Status.RecommendedAt != "" {
+				t.Errorf("Expected the recommendation to be cleared, got %+v", cleared.Status)
+			}
+		})
+	}
+}
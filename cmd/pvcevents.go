@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// Annotations stamped on a PVC the controller resizes, readable without
+	// access to the VolumeScaler CRDs
+	annotationLastResizedAt = annotationPrefix + "last-resized-at"
+	annotationPreviousSize  = annotationPrefix + "previous-size"
+	annotationResizedBy     = annotationPrefix + "resized-by"
+	annotationResizeReason  = annotationPrefix + "reason"
+)
+
+// makePVCRef returns the reference for events emitted on pvc.
+func makePVCRef(pvc *corev1.PersistentVolumeClaim) *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "PersistentVolumeClaim",
+		Namespace:  pvc.Namespace,
+		Name:       pvc.Name,
+		UID:        pvc.UID,
+	}
+}
+
+// eventWithPVC emits an event on the VolumeScaler and repeats it on pvc, so
+// kubectl describe pvc explains why its size changed.
+func (c *VolumeScalerController) eventWithPVC(invRef *corev1.ObjectReference, pvc *corev1.PersistentVolumeClaim, eventtype, reason, msg string) {
+	c.recorder.Event(invRef, eventtype, reason, msg)
+	c.recorder.Event(makePVCRef(pvc), eventtype, reason, msg)
+}
+
+// resizeAnnotations returns the annotations recording that the VolumeScaler
+// vsName resized a PVC from specSizeGi at now, and why.
+func resizeAnnotations(vsName string, specSizeGi float64, reason string, now time.Time) map[string]string {
+	return map[string]string{
+		annotationLastResizedAt: now.UTC().Format(time.RFC3339),
+		annotationPreviousSize:  fmt.Sprintf("%.0fGi", specSizeGi),
+		annotationResizedBy:     vsName,
+		annotationResizeReason:  reason,
+	}
+}
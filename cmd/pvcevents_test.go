@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+func TestExpansion_AnnotatesPVCAndEmitsEventOnIt(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {})
+	f.recorder.IncludeObject = true
+
+	f.reconcile(t)
+	pvc, err := f.clientset.CoreV1().PersistentVolumeClaims("default").Get(context.TODO(), "data", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get PVC: %v", err)
+	}
+	ann := pvc.Annotations
+	if ann[annotationPreviousSize] != "5Gi" || ann[annotationResizedBy] != "data" || ann[annotationResizeReason] != "usage=80% >= threshold=70%" {
+		t.Errorf("Expected the resize annotations, got %v", ann)
+	}
+	if at, err := time.Parse(time.RFC3339, ann[annotationLastResizedAt]); err != nil || time.Since(at) > time.Minute {
+		t.Errorf("Expected %s to be the time of the resize, got %q", annotationLastResizedAt, ann[annotationLastResizedAt])
+	}
+
+	var onVS, onPVC bool
+	for _, e := range drainEvents(f.recorder) {
+		if !strings.Contains(e, eventReasonResizeRequested) {
+			continue
+		}
+		onVS = onVS || strings.Contains(e, "kind=VolumeScaler")
+		onPVC = onPVC || strings.Contains(e, "kind=PersistentVolumeClaim")
+	}
+	if !onVS || !onPVC {
+		t.Errorf("Expected %s on both the VolumeScaler (%t) and the PVC (%t)", eventReasonResizeRequested, onVS, onPVC)
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+const (
+	defaultRecommendationWindow      = 14 * 24 * time.Hour
+	defaultRecommendationTargetUsage = 60
+
+	// minRecommendationHistory is how long a PVC is observed before a size is
+	// recommended for it
+	minRecommendationHistory = 24 * time.Hour
+
+	peakDateLayout = "2006-01-02"
+)
+
+// recordDailyPeak folds usedBytes into today's peak and drops the days that
+// fell out of the window.
+func recordDailyPeak(peaks []v1alpha1.UsagePeak, now time.Time, usedBytes int64, window time.Duration) []v1alpha1.UsagePeak {
+	today := now.UTC().Format(peakDateLayout)
+	oldest := now.Add(-window).UTC().Format(peakDateLayout)
+	kept := make([]v1alpha1.UsagePeak, 0, len(peaks)+1)
+	for _, p := range peaks {
+		if p.Date >= oldest {
+			kept = append(kept, p)
+		}
+	}
+	if n := len(kept); n > 0 && kept[n-1].Date == today {
+		if usedBytes > kept[n-1].UsedBytes {
+			kept[n-1].UsedBytes = usedBytes
+		}
+		return kept
+	}
+	return append(kept, v1alpha1.UsagePeak{Date: today, UsedBytes: usedBytes})
+}
+
+// recommendedSizeGi returns the whole-Gi size at which peakGi reaches
+// targetUsage percent.
+func recommendedSizeGi(peakGi, targetUsage float64) float64 {
+	return math.Max(math.Ceil(peakGi/(targetUsage/100)), 1)
+}
+
+// recordVolumeRecommendation tracks the peak usage of pvc in its
+// VolumeRecommendation and recommends the size that peak needs. vsObj is the
+// VolumeScaler managing the PVC, if any; its threshold caps the target usage
+// so the recommended size doesn't trigger an expansion. The status is written only when it
+// changes, which is at most a few times a day for a steady PVC.
+func (c *VolumeScalerController) recordVolumeRecommendation(ctx context.Context, pvc *corev1.PersistentVolumeClaim, usageInfo *PVCUsageInfo, vsObj *v1alpha1.VolumeScaler) error {
+	recs := c.vsClient.AutoscalingV1alpha1().VolumeRecommendations(pvc.Namespace)
+	rec, err := recs.Get(ctx, pvc.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		rec, err = recs.Create(ctx, &v1alpha1.VolumeRecommendation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      pvc.Name,
+				Namespace: pvc.Namespace,
+				// Deleted together with its PVC
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "v1",
+					Kind:       "PersistentVolumeClaim",
+					Name:       pvc.Name,
+					UID:        pvc.UID,
+				}},
+			},
+			Spec: v1alpha1.VolumeRecommendationSpec{PVCName: pvc.Name},
+		}, metav1.CreateOptions{})
+	}
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	st := rec.Status.DeepCopy()
+	if st.ObservedSince == "" {
+		st.ObservedSince = formatStatusTime(now)
+	}
+	st.DailyPeaks = recordDailyPeak(st.DailyPeaks, now, int64(usageInfo.UsedBytes), c.config.RecommendationWindow)
+	var peakBytes int64
+	for _, p := range st.DailyPeaks {
+		if p.UsedBytes > peakBytes {
+			peakBytes = p.UsedBytes
+		}
+	}
+	peakGi := float64(peakBytes) / (1 << 30)
+	st.PeakUsage = fmt.Sprintf("%.1fGi", peakGi)
+
+	size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	currentGi := float64(size.Value()) / (1 << 30)
+	st.CurrentSize = size.String()
+
+	targetUsage := float64(c.config.RecommendationTargetUsage)
+	st.VolumeScalerName = ""
+	if vsObj != nil {
+		st.VolumeScalerName = vsObj.Name
+		if threshold, err := Percentage(vsObj.Spec.Threshold).ToFloat(); err == nil && threshold > 0 && threshold < targetUsage {
+			targetUsage = threshold
+		}
+	}
+
+	st.RecommendedSize, st.Savings = "", ""
+	since, err := time.Parse(time.RFC3339, st.ObservedSince)
+	if err != nil || now.Sub(since) < minRecommendationHistory {
+		st.Message = "Collecting usage; a size is recommended after a day of observation"
+	} else {
+		recommendedGi := recommendedSizeGi(peakGi, targetUsage)
+		st.RecommendedSize = fmt.Sprintf("%.0fGi", recommendedGi)
+		switch {
+		case recommendedGi < currentGi:
+			st.Savings = fmt.Sprintf("%.0fGi", currentGi-recommendedGi)
+			st.Message = fmt.Sprintf("Over-provisioned: peak usage %s fits in %s at %.0f%% usage", st.PeakUsage, st.RecommendedSize, targetUsage)
+			if vsObj != nil && vsObj.Spec.Shrink == nil {
+				st.Message += fmt.Sprintf("; set spec.shrink on VolumeScaler '%s' to migrate it", vsObj.Name)
+			}
+		case recommendedGi > currentGi:
+			st.Message = fmt.Sprintf("Under-provisioned: peak usage %s needs %s at %.0f%% usage", st.PeakUsage, st.RecommendedSize, targetUsage)
+		default:
+			st.Message = "Sized for its peak usage"
+		}
+	}
+
+	if equality.Semantic.DeepEqual(*st, rec.Status) {
+		return nil
+	}
+	patch, err := json.Marshal(map[string]interface{}{"status": st})
+	if err != nil {
+		return fmt.Errorf("encoding VolumeRecommendation status patch: %v", err)
+	}
+	_, err = recs.Patch(ctx, rec.Name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+	return err
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+// observedRecommendation returns a VolumeRecommendation for the fixture PVC
+// observed for three days with the given daily peaks.
+func observedRecommendation(peaks ...v1alpha1.UsagePeak) *v1alpha1.VolumeRecommendation {
+	return &v1alpha1.VolumeRecommendation{
+		ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default"},
+		Spec:       v1alpha1.VolumeRecommendationSpec{PVCName: "data"},
+		Status: v1alpha1.VolumeRecommendationStatus{
+			ObservedSince: time.Now().Add(-72 * time.Hour).UTC().Format(time.RFC3339),
+			DailyPeaks:    peaks,
+		},
+	}
+}
+
+func daysAgo(days int) string {
+	return time.Now().Add(-time.Duration(days) * 24 * time.Hour).UTC().Format(peakDateLayout)
+}
+
+// recommend records the fixture usage for the PVC, managed by vsObj if set.
+func (f *scalerFixture) recommend(t *testing.T, vsObj *v1alpha1.VolumeScaler) v1alpha1.VolumeRecommendationStatus {
+	t.Helper()
+	pvc, err := f.clientset.CoreV1().PersistentVolumeClaims("default").Get(context.TODO(), "data", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get PVC: %v", err)
+	}
+	if err := f.controller.recordVolumeRecommendation(context.TODO(), pvc, f.usage, vsObj); err != nil {
+		t.Fatalf("recordVolumeRecommendation() error = %v", err)
+	}
+	rec, err := f.vsClient.AutoscalingV1alpha1().VolumeRecommendations("default").Get(context.TODO(), "data", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get VolumeRecommendation: %v", err)
+	}
+	return rec.Status
+}
+
+func TestVolumeRecommendation_CollectsBeforeRecommending(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {})
+
+	st := f.recommend(t, nil)
+	if st.RecommendedSize != "" || !strings.Contains(st.Message, "Collecting") {
+		t.Errorf("Expected no recommendation on the first day, got %+v", st)
+	}
+	if len(st.DailyPeaks) != 1 || st.DailyPeaks[0].UsedBytes != 4<<30 || st.PeakUsage != "4.0Gi" {
+		t.Errorf("Expected today's peak of 4Gi, got %+v", st)
+	}
+	rec, _ := f.vsClient.AutoscalingV1alpha1().VolumeRecommendations("default").Get(context.TODO(), "data", metav1.GetOptions{})
+	if refs := rec.OwnerReferences; len(refs) != 1 || refs[0].Kind != "PersistentVolumeClaim" || refs[0].Name != "data" {
+		t.Errorf("Expected the VolumeRecommendation to be owned by its PVC, got %+v", refs)
+	}
+}
+
+func TestVolumeRecommendation_OverProvisionedUnmanagedPVC(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {}, observedRecommendation(
+		v1alpha1.UsagePeak{Date: daysAgo(20), UsedBytes: 9 << 29},
+		v1alpha1.UsagePeak{Date: daysAgo(2), UsedBytes: 1 << 30},
+	))
+	f.setUsedGi(0.5)
+
+	st := f.recommend(t, nil)
+	if len(st.DailyPeaks) != 2 {
+		t.Errorf("Expected the peak older than the window to be dropped, got %+v", st.DailyPeaks)
+	}
+	if st.PeakUsage != "1.0Gi" || st.RecommendedSize != "2Gi" || st.Savings != "3Gi" {
+		t.Errorf("Expected 1.0Gi peak, 2Gi recommended and 3Gi saved, got %+v", st)
+	}
+	if st.VolumeScalerName != "" || !strings.HasPrefix(st.Message, "Over-provisioned") {
+		t.Errorf("Expected an over-provisioned unmanaged PVC, got %+v", st)
+	}
+}
+
+func TestVolumeRecommendation_ThresholdCapsTargetUsage(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		vs.Spec.Threshold = "40%"
+	}, observedRecommendation(v1alpha1.UsagePeak{Date: daysAgo(1), UsedBytes: 1 << 30}))
+	f.setUsedGi(0.5)
+	vs, _ := f.vsClient.AutoscalingV1alpha1().VolumeScalers("default").Get(context.TODO(), "data", metav1.GetOptions{})
+
+	st := f.recommend(t, vs)
+	if st.RecommendedSize != "3Gi" || st.Savings != "2Gi" {
+		t.Errorf("Expected 3Gi to keep the peak below the 40%% threshold, got %+v", st)
+	}
+	if st.VolumeScalerName != "data" || !strings.Contains(st.Message, "spec.shrink") {
+		t.Errorf("Expected the VolumeScaler named with a hint to shrink, got %+v", st)
+	}
+}
+
+func TestVolumeRecommendation_UnchangedStatusIsNotWritten(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {})
+	f.recommend(t, nil)
+	f.setUsedGi(3)
+	f.vsClient.ClearActions()
+
+	f.recommend(t, nil)
+	for _, action := range f.vsClient.Actions() {
+		if action.GetVerb() == "patch" {
+			t.Errorf("Expected no status write below today's peak, got %s %s", action.GetVerb(), action.GetResource().Resource)
+		}
+	}
+}
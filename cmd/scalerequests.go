@@ -0,0 +1,373 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+const (
+	// Phases of on-demand VolumeScaleRequests
+	requestPhaseFulfilled = "Fulfilled"
+	requestPhaseFailed    = "Failed"
+
+	// Event reasons
+	eventReasonScaleRequestApplied   = "ScaleRequestApplied"
+	eventReasonScaleRequestFulfilled = "ScaleRequestFulfilled"
+	eventReasonScaleRequestFailed    = "ScaleRequestFailed"
+)
+
+// isOnDemandRequest reports whether a VolumeScaleRequest was created by a
+// workload asking for free space rather than by the approval workflow.
+func isOnDemandRequest(req *v1alpha1.VolumeScaleRequest) bool {
+	return req.Spec.MinFreeSpace != ""
+}
+
+// reconcileScaleRequests works through the on-demand VolumeScaleRequests that
+// target vsObj. It expands the PVC for at most one request per loop and
+// reports whether it did, so reconcilePVC can stop there. The VolumeScaler's
+// cooldown is skipped for these requests, but maxSize, VolumeScalerLimits and
+// the approval policy still apply.
+func (c *VolumeScalerController) reconcileScaleRequests(ctx context.Context, invRef *corev1.ObjectReference, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, pvc *corev1.PersistentVolumeClaim, usedGi, specSizeGi, statusSizeGi, maxSizeGi float64) (bool, error) {
+	list, err := c.vsClient.AutoscalingV1alpha1().VolumeScaleRequests(vsName.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return false, fmt.Errorf("listing VolumeScaleRequests: %v", err)
+	}
+
+	now := time.Now().UTC()
+	inProgress := statusSizeGi < specSizeGi
+	for i := range list.Items {
+		req := &list.Items[i]
+		if !isOnDemandRequest(req) || req.Spec.VolumeScalerName != vsName.Name {
+			continue
+		}
+		if err := c.syncAnnotationDecision(ctx, req); err != nil {
+			return false, err
+		}
+
+		switch req.Status.Phase {
+		case "", requestPhasePending, requestPhaseApproved:
+		case requestPhaseApplied:
+			if err := c.checkFulfillment(ctx, invRef, req, pvc, usedGi, statusSizeGi, inProgress, now); err != nil {
+				return false, err
+			}
+			continue
+		default:
+			continue
+		}
+
+		minFreeGi, err := convertToGi(req.Spec.MinFreeSpace)
+		if err != nil || minFreeGi <= 0 {
+			if err := c.failRequest(ctx, invRef, req, fmt.Sprintf("minFreeSpace '%s' is not a positive size", req.Spec.MinFreeSpace)); err != nil {
+				return false, err
+			}
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, req.Spec.Deadline); req.Spec.Deadline != "" && err != nil {
+			if err := c.failRequest(ctx, invRef, req, fmt.Sprintf("deadline '%s' is not an RFC 3339 time", req.Spec.Deadline)); err != nil {
+				return false, err
+			}
+			continue
+		}
+		if deadlinePassed(req, now) {
+			if err := c.failRequest(ctx, invRef, req, "Deadline passed before the request could be applied"); err != nil {
+				return false, err
+			}
+			continue
+		}
+
+		targetGi := math.Ceil(usedGi + minFreeGi)
+		if !inProgress && statusSizeGi-usedGi >= minFreeGi {
+			if err := c.fulfillRequest(ctx, invRef, req, pvc, statusSizeGi-usedGi); err != nil {
+				return false, err
+			}
+			continue
+		}
+		if targetGi <= specSizeGi {
+			// The PVC is already being expanded far enough; wait for it to finish
+			err := c.patchRequestStatus(ctx, req, map[string]interface{}{
+				"phase":      requestPhaseApplied,
+				"appliedAt":  now.Format(time.RFC3339),
+				"targetSize": fmt.Sprintf("%.0fGi", specSizeGi),
+				"message":    "Waiting for the current resize to finish",
+			})
+			if err != nil {
+				return false, err
+			}
+			continue
+		}
+		if inProgress {
+			if err := c.setRequestMessage(ctx, req, "Waiting for the current resize to finish"); err != nil {
+				return false, err
+			}
+			continue
+		}
+		if vsObj.Spec.Suspend {
+			if err := c.setRequestMessage(ctx, req, "VolumeScaler is suspended"); err != nil {
+				return false, err
+			}
+			continue
+		}
+		if mode := c.effectiveMode(vsObj); mode != modeEnforce {
+			if err := c.setRequestMessage(ctx, req, fmt.Sprintf("VolumeScaler runs in %s mode", mode)); err != nil {
+				return false, err
+			}
+			continue
+		}
+
+		return c.applyScaleRequest(ctx, invRef, vsName, vsObj, req, pvc, specSizeGi, targetGi, maxSizeGi)
+	}
+	return false, nil
+}
+
+// applyScaleRequest clamps the target of an on-demand request to maxSize and
+// the VolumeScalerLimits, then patches the PVC.
+func (c *VolumeScalerController) applyScaleRequest(ctx context.Context, invRef *corev1.ObjectReference, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, req *v1alpha1.VolumeScaleRequest, pvc *corev1.PersistentVolumeClaim, specSizeGi, targetGi, maxSizeGi float64) (bool, error) {
+	limits, err := c.loadEffectiveLimits(ctx, pvc)
+	if err != nil {
+		return false, fmt.Errorf("evaluating limits: %v", err)
+	}
+
+	// Only an administrator's minimum cooldown holds back on-demand requests
+	if limits.MinCooldown > 0 {
+		okToScale, err := canScaleNow(vsObj.Status.ScaledAt, limits.MinCooldown)
+		if err != nil {
+			return false, fmt.Errorf("checking cooldown: %v", err)
+		}
+		if !okToScale {
+			msg := fmt.Sprintf("Waiting for the %s minimum cooldown of VolumeScalerLimit '%s'", limits.MinCooldown, limits.MinCooldownFrom)
+			return false, c.setRequestMessage(ctx, req, msg)
+		}
+	}
+
+	var clamps []string
+	newSizeGi := targetGi
+	if limits.MaxSizeGi > 0 && limits.MaxSizeGi < maxSizeGi {
+		maxSizeGi = limits.MaxSizeGi
+	}
+	if newSizeGi > maxSizeGi {
+		clamps = append(clamps, fmt.Sprintf("target %.0fGi -> maxSize %.0fGi", newSizeGi, maxSizeGi))
+		newSizeGi = maxSizeGi
+	}
+	newSizeGi, sizeClamps, err := c.clampToLimits(ctx, limits, vsName.Namespace, specSizeGi, newSizeGi)
+	if err != nil {
+		return false, fmt.Errorf("applying limits: %v", err)
+	}
+	clamps = append(clamps, sizeClamps...)
+	newSizeGi, budgetClamps, exhausted, err := c.applyGrowthBudgets(ctx, invRef, vsName, vsObj, limits, specSizeGi, newSizeGi)
+	if err != nil {
+		return false, fmt.Errorf("applying growth budgets: %v", err)
+	}
+	if exhausted {
+		return false, c.setRequestMessage(ctx, req, "Waiting for the growth budget: "+strings.Join(budgetClamps, "; "))
+	}
+	clamps = append(clamps, budgetClamps...)
+	newSizeGi, spendClamps, blocked, err := c.applySpendBudget(ctx, invRef, vsName, vsObj, pvc, limits, specSizeGi, newSizeGi)
+	if err != nil {
+		return false, fmt.Errorf("applying spend budget: %v", err)
+	}
+	if blocked {
+		return false, c.setRequestMessage(ctx, req, "Waiting for the spend budget: "+strings.Join(spendClamps, "; "))
+	}
+	clampMsg := strings.Join(clamps, "; ")
+
+	if newSizeGi <= specSizeGi {
+		return false, c.failRequest(ctx, invRef, req, "Cannot expand the PVC: "+clampMsg)
+	}
+
+	if vsObj.Spec.ApprovalPolicy != nil {
+		if err := limits.mergeApprovalPolicy(vsObj.Spec.ApprovalPolicy, "VolumeScaler"); err != nil {
+			return false, fmt.Errorf("invalid approval policy: %v", err)
+		}
+	}
+	if reason := approvalReason(limits, specSizeGi, newSizeGi); reason != "" && req.Status.Phase != requestPhaseApproved {
+		return false, c.setRequestMessage(ctx, req, "Awaiting approval: "+reason)
+	}
+
+	snapshotReady, err := c.ensurePreResizeSnapshot(ctx, invRef, vsName, vsObj, pvc)
+	if err != nil {
+		return false, fmt.Errorf("taking pre-resize snapshot: %v", err)
+	}
+	if !snapshotReady {
+		return false, c.setRequestMessage(ctx, req, fmt.Sprintf("Waiting for VolumeSnapshot '%s' before expanding", vsObj.Status.PendingSnapshot))
+	}
+	granted, err := c.acquireResizeSlot(ctx, invRef, vsName, vsObj, vsName.Namespace+"/"+pvc.Name)
+	if err != nil {
+		return false, fmt.Errorf("acquiring resize slot: %v", err)
+	}
+	if !granted {
+		return false, c.setRequestMessage(ctx, req, fmt.Sprintf("Queued for a resize slot at position %d", vsObj.Status.QueuePosition))
+	}
+
+	newSizeStr := fmt.Sprintf("%.0fGi", newSizeGi)
+	reason := fmt.Sprintf("VolumeScaleRequest '%s' (minFreeSpace %s)", req.Name, req.Spec.MinFreeSpace)
+	if err := c.startExpansion(ctx, invRef, vsName, vsObj, pvc, specSizeGi, newSizeStr, clampMsg, triggerScaleRequest, reason); err != nil {
+		return false, err
+	}
+	nowStr := time.Now().UTC().Format(time.RFC3339)
+
+	message := fmt.Sprintf("Expanding PVC to %s", newSizeStr)
+	if clampMsg != "" {
+		message += " (limited: " + clampMsg + ")"
+	}
+	err = c.patchRequestStatus(ctx, req, map[string]interface{}{
+		"phase":      requestPhaseApplied,
+		"appliedAt":  nowStr,
+		"targetSize": newSizeStr,
+		"message":    message,
+	})
+	if err != nil {
+		return true, err
+	}
+
+	msg := fmt.Sprintf("Expanding PVC '%s/%s' from %.0fGi -> %s for VolumeScaleRequest '%s' (minFreeSpace %s)",
+		vsName.Namespace, pvc.Name, specSizeGi, newSizeStr, req.Name, req.Spec.MinFreeSpace)
+	c.eventWithPVC(invRef, pvc, corev1.EventTypeNormal, eventReasonScaleRequestApplied, msg)
+	fmt.Printf("[INFO] %s\n", msg)
+	c.recordDecision(ctx, vsName, vsObj, pvc.Name, scaleDecision{
+		decision:     decisionExpanded,
+		trigger:      triggerScaleRequest,
+		currentSize:  fmt.Sprintf("%.0fGi", specSizeGi),
+		computedSize: newSizeStr,
+		clamps:       clampMsg,
+		message:      msg,
+	})
+	return true, nil
+}
+
+// startExpansion patches the PVC to newSizeStr, stamping it with the resize
+// annotations and reason, marks the resize as in progress on the VolumeScaler
+// and records it in the expansion ledger, along with the snapshot taken
+// before it. A failed PVC patch is audited under trigger.
+func (c *VolumeScalerController) startExpansion(ctx context.Context, invRef *corev1.ObjectReference, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, pvc *corev1.PersistentVolumeClaim, specSizeGi float64, newSizeStr, clampMsg, trigger, reason string) error {
+	now := time.Now().UTC()
+	pvcPatch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": resizeAnnotations(vsName.Name, specSizeGi, reason, now)},
+		"spec":     map[string]interface{}{"resources": map[string]interface{}{"requests": map[string]string{"storage": newSizeStr}}},
+	})
+	if err != nil {
+		return fmt.Errorf("encoding PVC patch: %v", err)
+	}
+	_, err = c.clientset.CoreV1().PersistentVolumeClaims(vsName.Namespace).Patch(
+		ctx, pvc.Name, types.MergePatchType, pvcPatch, metav1.PatchOptions{})
+	if err != nil {
+		msg := fmt.Sprintf("Failed initiating expansion from %.0fGi -> %s: %v", specSizeGi, newSizeStr, err)
+		c.eventWithPVC(invRef, pvc, corev1.EventTypeWarning, eventReasonResizeFailed, msg)
+		c.recordDecision(ctx, vsName, vsObj, pvc.Name, scaleDecision{
+			decision:     decisionFailed,
+			trigger:      trigger,
+			currentSize:  fmt.Sprintf("%.0fGi", specSizeGi),
+			computedSize: newSizeStr,
+			clamps:       clampMsg,
+			message:      msg,
+		})
+		return fmt.Errorf("patching PVC: %v", err)
+	}
+
+	newSizeGi, _ := convertToGi(newSizeStr)
+	ledger := appendLedger(vsObj.Status.ExpansionLedger, now, newSizeGi-specSizeGi)
+	status := map[string]interface{}{
+		"resizeInProgress":  true,
+		"lastRequestedSize": newSizeStr,
+		"scaledAt":          now.Format(time.RFC3339),
+		"limitClamp":        clampMsg,
+		"expansionLedger":   ledger,
+	}
+	if added := c.expansionCost(ctx, vsName, pvc, newSizeGi-specSizeGi); added != "" {
+		ledger[len(ledger)-1].CostAdded = added
+		status["cost"] = map[string]interface{}{"lastExpansionCost": added}
+	}
+	var pruned []string
+	if vsObj.Spec.SnapshotBeforeResize != nil && vsObj.Status.PendingSnapshot != "" {
+		status["snapshots"], pruned = retainSnapshots(vsObj, vsObj.Status.PendingSnapshot)
+		status["pendingSnapshot"] = nil
+	}
+	stPatch, err := json.Marshal(map[string]interface{}{"status": status})
+	if err != nil {
+		return fmt.Errorf("encoding VolumeScaler status patch: %v", err)
+	}
+	_, err = c.vsClient.AutoscalingV1alpha1().VolumeScalers(vsName.Namespace).
+		Patch(ctx, vsName.Name, types.MergePatchType, stPatch, metav1.PatchOptions{}, "status")
+	if err != nil {
+		return fmt.Errorf("patching VolumeScaler status: %v", err)
+	}
+	c.pruneSnapshots(ctx, vsName.Namespace, pruned)
+	return nil
+}
+
+// checkFulfillment resolves an Applied on-demand request once its resize has
+// finished, or fails it when the deadline passes first.
+func (c *VolumeScalerController) checkFulfillment(ctx context.Context, invRef *corev1.ObjectReference, req *v1alpha1.VolumeScaleRequest, pvc *corev1.PersistentVolumeClaim, usedGi, statusSizeGi float64, inProgress bool, now time.Time) error {
+	minFreeGi, _ := convertToGi(req.Spec.MinFreeSpace)
+	freeGi := statusSizeGi - usedGi
+	switch {
+	case !inProgress && freeGi >= minFreeGi:
+		return c.fulfillRequest(ctx, invRef, req, pvc, freeGi)
+	case deadlinePassed(req, now):
+		return c.failRequest(ctx, invRef, req, fmt.Sprintf("Only %.1fGi free at the deadline", freeGi))
+	case !inProgress:
+		return c.failRequest(ctx, invRef, req, fmt.Sprintf("Expansion finished with only %.1fGi free", freeGi))
+	}
+	return nil
+}
+
+// fulfillRequest marks an on-demand request Fulfilled.
+func (c *VolumeScalerController) fulfillRequest(ctx context.Context, invRef *corev1.ObjectReference, req *v1alpha1.VolumeScaleRequest, pvc *corev1.PersistentVolumeClaim, freeGi float64) error {
+	err := c.patchRequestStatus(ctx, req, map[string]interface{}{
+		"phase":       requestPhaseFulfilled,
+		"fulfilledAt": time.Now().UTC().Format(time.RFC3339),
+		"message":     fmt.Sprintf("%.1fGi free on PVC '%s'", freeGi, pvc.Name),
+	})
+	if err != nil {
+		return err
+	}
+	c.recorder.Eventf(invRef, corev1.EventTypeNormal, eventReasonScaleRequestFulfilled,
+		"VolumeScaleRequest '%s' fulfilled: %.1fGi free on PVC '%s/%s'", req.Name, freeGi, req.Namespace, pvc.Name)
+	return nil
+}
+
+// failRequest marks an on-demand request Failed with the given message.
+func (c *VolumeScalerController) failRequest(ctx context.Context, invRef *corev1.ObjectReference, req *v1alpha1.VolumeScaleRequest, message string) error {
+	err := c.patchRequestStatus(ctx, req, map[string]interface{}{"phase": requestPhaseFailed, "message": message})
+	if err != nil {
+		return err
+	}
+	c.recorder.Eventf(invRef, corev1.EventTypeWarning, eventReasonScaleRequestFailed,
+		"VolumeScaleRequest '%s' failed: %s", req.Name, message)
+	fmt.Printf("[WARNING] VolumeScaleRequest '%s/%s' failed: %s\n", req.Namespace, req.Name, message)
+	return nil
+}
+
+// setRequestMessage records why a request is waiting, keeping it Pending.
+func (c *VolumeScalerController) setRequestMessage(ctx context.Context, req *v1alpha1.VolumeScaleRequest, message string) error {
+	if req.Status.Message == message && req.Status.Phase != "" {
+		return nil
+	}
+	fields := map[string]interface{}{"message": message}
+	if req.Status.Phase == "" {
+		fields["phase"] = requestPhasePending
+	}
+	return c.patchRequestStatus(ctx, req, fields)
+}
+
+// deadlinePassed reports whether the request's deadline is in the past.
+// Requests without a parseable deadline never run out of time.
+func deadlinePassed(req *v1alpha1.VolumeScaleRequest, now time.Time) bool {
+	if req.Spec.Deadline == "" {
+		return false
+	}
+	deadline, err := time.Parse(time.RFC3339, req.Spec.Deadline)
+	if err != nil {
+		return false
+	}
+	return now.After(deadline)
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+// onDemandRequest asks for minFree free space on the fixture's PVC.
+func onDemandRequest(minFree, deadline string) *v1alpha1.VolumeScaleRequest {
+	return &v1alpha1.VolumeScaleRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly-import", Namespace: "default"},
+		Spec:       v1alpha1.VolumeScaleRequestSpec{VolumeScalerName: "data", MinFreeSpace: minFree, Deadline: deadline},
+	}
+}
+
+// inCooldown puts the fixture's VolumeScaler in its cooldown period.
+func inCooldown(vs *v1alpha1.VolumeScaler) {
+	vs.Spec.CooldownPeriod = "1h"
+	vs.Status.ScaledAt = time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+}
+
+func (f *scalerFixture) request(t *testing.T) *v1alpha1.VolumeScaleRequest {
+	t.Helper()
+	req, err := f.vsClient.AutoscalingV1alpha1().VolumeScaleRequests("default").Get(context.TODO(), "nightly-import", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get VolumeScaleRequest: %v", err)
+	}
+	return req
+}
+
+// finishResize sets the PVC's capacity to its requested size.
+func (f *scalerFixture) finishResize(t *testing.T) {
+	t.Helper()
+	pvc, err := f.clientset.CoreV1().PersistentVolumeClaims("default").Get(context.TODO(), "data", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get PVC: %v", err)
+	}
+	pvc.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: pvc.Spec.Resources.Requests[corev1.ResourceStorage]}
+	if _, err := f.clientset.CoreV1().PersistentVolumeClaims("default").Update(context.TODO(), pvc, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to update PVC: %v", err)
+	}
+}
+
+func TestScaleRequest_AppliedDuringCooldownAndFulfilled(t *testing.T) {
+	deadline := time.Now().Add(2 * time.Hour).UTC().Format(time.RFC3339)
+	f := newScalerFixture(t, inCooldown, onDemandRequest("4Gi", deadline))
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "8Gi" {
+		t.Fatalf("Expected the PVC to grow to used+minFreeSpace=8Gi, got %s", size)
+	}
+	req := f.request(t)
+	if req.Status.Phase != requestPhaseApplied || req.Status.TargetSize != "8Gi" {
+		t.Errorf("Expected an Applied request targeting 8Gi, got %+v", req.Status)
+	}
+
+	f.finishResize(t)
+	f.reconcile(t)
+	req = f.request(t)
+	if req.Status.Phase != requestPhaseFulfilled || req.Status.FulfilledAt == "" {
+		t.Errorf("Expected the request to be Fulfilled, got %+v", req.Status)
+	}
+}
+
+func TestScaleRequest_CompletionRecordedFirst(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) { vs.Status.ResizeInProgress = true }, onDemandRequest("4Gi", ""))
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "8Gi" {
+		t.Fatalf("Expected the request to expand the PVC to 8Gi, got %s", size)
+	}
+	if events := strings.Join(drainEvents(f.recorder), "\n"); !strings.Contains(events, eventReasonResizeComplete) {
+		t.Errorf("Expected the finished resize reported before the request, got %s", events)
+	}
+	if st := f.status(t); st.ScaledAt == "" {
+		t.Errorf("Expected scaledAt set by the completed resize, got %+v", st)
+	}
+}
+
+func TestScaleRequest_ClampedByMaxSizeFails(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) { vs.Spec.MaxSize = "6Gi" }, onDemandRequest("4Gi", ""))
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "6Gi" {
+		t.Fatalf("Expected the expansion to stop at maxSize 6Gi, got %s", size)
+	}
+	if req := f.request(t); !strings.Contains(req.Status.Message, "maxSize") {
+		t.Errorf("Expected the clamp to be reported, got %q", req.Status.Message)
+	}
+
+	f.finishResize(t)
+	f.reconcile(t)
+	if req := f.request(t); req.Status.Phase != requestPhaseFailed {
+		t.Errorf("Expected the request to fail with too little free space, got %+v", req.Status)
+	}
+}
+
+func TestScaleRequest_AlreadyEnoughFreeSpace(t *testing.T) {
+	f := newScalerFixture(t, inCooldown, onDemandRequest("512Mi", ""))
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "5Gi" {
+		t.Errorf("Expected no expansion, got %s", size)
+	}
+	if req := f.request(t); req.Status.Phase != requestPhaseFulfilled {
+		t.Errorf("Expected the request to be Fulfilled immediately, got %+v", req.Status)
+	}
+}
+
+func TestScaleRequest_DeadlinePassed(t *testing.T) {
+	deadline := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+	f := newScalerFixture(t, inCooldown, onDemandRequest("4Gi", deadline))
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "5Gi" {
+		t.Errorf("Expected no expansion, got %s", size)
+	}
+	if req := f.request(t); req.Status.Phase != requestPhaseFailed {
+		t.Errorf("Expected the request to fail, got %+v", req.Status)
+	}
+}
+
+func TestScaleRequest_WaitsInObserveMode(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) { vs.Spec.Mode = modeObserve }, onDemandRequest("4Gi", ""))
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "5Gi" {
+		t.Errorf("Expected no expansion in Observe mode, got %s", size)
+	}
+	if req := f.request(t); req.Status.Phase != requestPhasePending || !strings.Contains(req.Status.Message, modeObserve) {
+		t.Errorf("Expected a Pending request explaining the mode, got %+v", req.Status)
+	}
+}
+
+func TestScaleRequest_RespectsLimitMinCooldown(t *testing.T) {
+	limit := &v1alpha1.VolumeScalerLimit{
+		ObjectMeta: metav1.ObjectMeta{Name: "slow"},
+		Spec:       v1alpha1.VolumeScalerLimitSpec{MinCooldownPeriod: "30m"},
+	}
+	f := newScalerFixture(t, inCooldown, onDemandRequest("4Gi", ""), limit)
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "5Gi" {
+		t.Errorf("Expected the VolumeScalerLimit cooldown to hold the request, got %s", size)
+	}
+	if req := f.request(t); !strings.Contains(req.Status.Message, "slow") {
+		t.Errorf("Expected the message to name the limit, got %q", req.Status.Message)
+	}
+}
+
+func TestScaleRequest_NeedsApprovalAbovePolicy(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		inCooldown(vs)
+		vs.Spec.ApprovalPolicy = &v1alpha1.ApprovalPolicy{IncrementAbove: "2Gi"}
+	}, onDemandRequest("4Gi", ""))
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "5Gi" {
+		t.Fatalf("Expected the request to wait for approval, got %s", size)
+	}
+	req := f.request(t)
+	if !strings.Contains(req.Status.Message, "approval") {
+		t.Errorf("Expected an approval message, got %q", req.Status.Message)
+	}
+
+	req.Status.Phase = requestPhaseApproved
+	if _, err := f.vsClient.AutoscalingV1alpha1().VolumeScaleRequests("default").UpdateStatus(context.TODO(), req, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to approve: %v", err)
+	}
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "8Gi" {
+		t.Errorf("Expected the approved request to be applied, got %s", size)
+	}
+}
@@ -0,0 +1,356 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+const (
+	// Event reasons
+	eventReasonScheduledScale     = "ScheduledScale"
+	eventReasonScheduleBlocked    = "ScheduleBlocked"
+	eventReasonInvalidSchedule    = "InvalidSchedule"
+	eventReasonBlackoutActive     = "BlackoutActive"
+	eventReasonBlackoutOverridden = "BlackoutOverridden"
+)
+
+// parseCron parses a standard five-field cron expression. A leading
+// CRON_TZ=<zone> selects the time zone; otherwise the controller's local
+// time zone is used.
+func parseCron(expr string) (cron.Schedule, error) {
+	return cron.ParseStandard(expr)
+}
+
+// scheduleLabel names a schedule or blackout window in events and messages.
+func scheduleLabel(name, expr string) string {
+	if name != "" {
+		return name
+	}
+	return fmt.Sprintf("'%s'", expr)
+}
+
+// dueScheduleFloor returns the largest minSize among the schedules that fired
+// after last and up to now, together with their names. It returns 0 when no
+// schedule fired.
+func dueScheduleFloor(schedules []v1alpha1.ScaleSchedule, last, now time.Time) (float64, []string, error) {
+	var floorGi float64
+	var names []string
+	for _, s := range schedules {
+		sched, err := parseCron(s.Schedule)
+		if err != nil {
+			return 0, nil, fmt.Errorf("schedule '%s': %v", s.Schedule, err)
+		}
+		if sched.Next(last).After(now) {
+			continue
+		}
+		minGi, err := convertToGi(s.MinSize)
+		if err != nil {
+			return 0, nil, fmt.Errorf("schedule minSize '%s': %v", s.MinSize, err)
+		}
+		names = append(names, scheduleLabel(s.Name, s.Schedule))
+		if minGi > floorGi {
+			floorGi = minGi
+		}
+	}
+	return floorGi, names, nil
+}
+
+// nextScheduledRaise returns when the next schedule fires and the largest
+// minSize among the schedules firing at that time.
+func nextScheduledRaise(schedules []v1alpha1.ScaleSchedule, now time.Time) (time.Time, string, error) {
+	var next time.Time
+	var size string
+	var sizeGi float64
+	for _, s := range schedules {
+		sched, err := parseCron(s.Schedule)
+		if err != nil {
+			return time.Time{}, "", fmt.Errorf("schedule '%s': %v", s.Schedule, err)
+		}
+		minGi, err := convertToGi(s.MinSize)
+		if err != nil {
+			return time.Time{}, "", fmt.Errorf("schedule minSize '%s': %v", s.MinSize, err)
+		}
+		t := sched.Next(now)
+		switch {
+		case t.IsZero():
+		case next.IsZero() || t.Before(next):
+			next, size, sizeGi = t, s.MinSize, minGi
+		case t.Equal(next) && minGi > sizeGi:
+			size, sizeGi = s.MinSize, minGi
+		}
+	}
+	return next, size, nil
+}
+
+// blackoutState returns when the currently open blackout window ends, or the
+// zero time when none is open, and when the next window starts.
+func blackoutState(windows []v1alpha1.BlackoutWindow, now time.Time) (until, next time.Time, err error) {
+	for _, w := range windows {
+		sched, err := parseCron(w.Start)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("blackout window start '%s': %v", w.Start, err)
+		}
+		d, err := time.ParseDuration(w.Duration)
+		if err != nil || d <= 0 {
+			return time.Time{}, time.Time{}, fmt.Errorf("blackout window duration '%s' is not a positive duration", w.Duration)
+		}
+		// The window is open if it started within the last d
+		if start := sched.Next(now.Add(-d)); !start.IsZero() && !start.After(now) {
+			if end := start.Add(d); end.After(until) {
+				until = end
+			}
+		}
+		if t := sched.Next(now); !t.IsZero() && (next.IsZero() || t.Before(next)) {
+			next = t
+		}
+	}
+	return until, next, nil
+}
+
+// formatStatusTime formats t for the status, or returns "" for the zero time.
+func formatStatusTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// reconcileSchedules raises the PVC to the minSize of any schedule that fired
+// since the last loop and publishes the next schedule and blackout window in
+// the VolumeScaler status. It reports whether it expanded the PVC and, while a
+// blackout window is open, when that window ends.
+func (c *VolumeScalerController) reconcileSchedules(ctx context.Context, invRef *corev1.ObjectReference, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, pvc *corev1.PersistentVolumeClaim, specSizeGi, statusSizeGi, maxSizeGi float64) (bool, time.Time, error) {
+	now := time.Now().UTC()
+	status := map[string]interface{}{}
+	setField := func(key, current, desired string) {
+		if current == desired {
+			return
+		}
+		if desired == "" {
+			status[key] = nil
+		} else {
+			status[key] = desired
+		}
+	}
+
+	blackoutUntil, nextBlackout, err := blackoutState(vsObj.Spec.BlackoutWindows, now)
+	if err != nil {
+		c.recorder.Eventf(invRef, corev1.EventTypeWarning, eventReasonInvalidSchedule, "%v", err)
+		return false, time.Time{}, err
+	}
+	nextTime, nextSize, err := nextScheduledRaise(vsObj.Spec.Schedules, now)
+	if err != nil {
+		c.recorder.Eventf(invRef, corev1.EventTypeWarning, eventReasonInvalidSchedule, "%v", err)
+		return false, time.Time{}, err
+	}
+	setField("blackoutUntil", vsObj.Status.BlackoutUntil, formatStatusTime(blackoutUntil))
+	setField("nextBlackoutTime", vsObj.Status.NextBlackoutTime, formatStatusTime(nextBlackout))
+	setField("nextScheduleTime", vsObj.Status.NextScheduleTime, formatStatusTime(nextTime))
+	setField("nextScheduleSize", vsObj.Status.NextScheduleSize, nextSize)
+
+	expanded := false
+	if len(vsObj.Spec.Schedules) == 0 {
+		setField("lastScheduleTime", vsObj.Status.LastScheduleTime, "")
+	} else if last, err := time.Parse(time.RFC3339, vsObj.Status.LastScheduleTime); err != nil {
+		// Schedules only fire for times after the controller first saw them
+		setField("lastScheduleTime", vsObj.Status.LastScheduleTime, formatStatusTime(now))
+	} else {
+		floorGi, names, err := dueScheduleFloor(vsObj.Spec.Schedules, last, now)
+		if err != nil {
+			return false, time.Time{}, err
+		}
+		if floorGi > 0 {
+			done, fired, err := c.applySchedule(ctx, invRef, vsName, vsObj, pvc, specSizeGi, statusSizeGi, maxSizeGi, floorGi, strings.Join(names, ", "))
+			if err != nil {
+				return false, time.Time{}, err
+			}
+			if done {
+				setField("lastScheduleTime", vsObj.Status.LastScheduleTime, formatStatusTime(now))
+			}
+			expanded = fired
+		}
+	}
+
+	if len(status) > 0 {
+		patch, err := json.Marshal(map[string]interface{}{"status": status})
+		if err != nil {
+			return expanded, blackoutUntil, fmt.Errorf("encoding schedule status: %v", err)
+		}
+		_, err = c.vsClient.AutoscalingV1alpha1().VolumeScalers(vsName.Namespace).
+			Patch(ctx, vsName.Name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+		if err != nil {
+			return expanded, blackoutUntil, fmt.Errorf("patching schedule status: %v", err)
+		}
+	}
+	return expanded, blackoutUntil, nil
+}
+
+// applySchedule raises the PVC to floorGi for the schedules named in names.
+// done reports whether the schedules have been handled and lastScheduleTime
+// can move on; it is false while the raise waits for a running resize, a
+// minimum cooldown or an approval. expanded reports whether the PVC was
+// patched.
+func (c *VolumeScalerController) applySchedule(ctx context.Context, invRef *corev1.ObjectReference, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, pvc *corev1.PersistentVolumeClaim, specSizeGi, statusSizeGi, maxSizeGi, floorGi float64, names string) (done, expanded bool, err error) {
+	if specSizeGi >= floorGi {
+		return true, false, nil
+	}
+	mode := c.effectiveMode(vsObj)
+	if vsObj.Spec.Suspend || (mode != modeEnforce && mode != modeRecommend) {
+		fmt.Printf("[INFO] VolumeScaler '%s/%s' skipped schedule %s (suspended or %s mode).\n", vsName.Namespace, vsName.Name, names, mode)
+		return true, false, nil
+	}
+	if statusSizeGi < specSizeGi {
+		return false, false, nil
+	}
+
+	limits, err := c.loadEffectiveLimits(ctx, pvc)
+	if err != nil {
+		return false, false, fmt.Errorf("evaluating limits: %v", err)
+	}
+	if limits.MinCooldown > 0 {
+		okToScale, err := canScaleNow(vsObj.Status.ScaledAt, limits.MinCooldown)
+		if err != nil {
+			return false, false, fmt.Errorf("checking cooldown: %v", err)
+		}
+		if !okToScale {
+			return false, false, nil
+		}
+	}
+
+	var clamps []string
+	newSizeGi := floorGi
+	if limits.MaxSizeGi > 0 && limits.MaxSizeGi < maxSizeGi {
+		maxSizeGi = limits.MaxSizeGi
+	}
+	if newSizeGi > maxSizeGi {
+		clamps = append(clamps, fmt.Sprintf("minSize %.0fGi -> maxSize %.0fGi", newSizeGi, maxSizeGi))
+		newSizeGi = maxSizeGi
+	}
+	newSizeGi, sizeClamps, err := c.clampToLimits(ctx, limits, vsName.Namespace, specSizeGi, newSizeGi)
+	if err != nil {
+		return false, false, fmt.Errorf("applying limits: %v", err)
+	}
+	clamps = append(clamps, sizeClamps...)
+	// An exhausted growth budget delays the raise until the window rolls
+	newSizeGi, budgetClamps, exhausted, err := c.applyGrowthBudgets(ctx, invRef, vsName, vsObj, limits, specSizeGi, newSizeGi)
+	if err != nil || exhausted {
+		return false, false, err
+	}
+	clamps = append(clamps, budgetClamps...)
+	// So does a spend ceiling that blocks expansions
+	newSizeGi, _, blocked, err := c.applySpendBudget(ctx, invRef, vsName, vsObj, pvc, limits, specSizeGi, newSizeGi)
+	if err != nil || blocked {
+		return false, false, err
+	}
+	clampMsg := strings.Join(clamps, "; ")
+
+	if newSizeGi <= specSizeGi {
+		msg := fmt.Sprintf("Schedule %s could not raise PVC '%s/%s' above %.0fGi: %s", names, vsName.Namespace, pvc.Name, specSizeGi, clampMsg)
+		c.recorder.Event(invRef, corev1.EventTypeWarning, eventReasonScheduleBlocked, msg)
+		c.recordDecision(ctx, vsName, vsObj, pvc.Name, scaleDecision{
+			decision:    decisionBlocked,
+			trigger:     triggerSchedule,
+			currentSize: fmt.Sprintf("%.0fGi", specSizeGi),
+			clamps:      clampMsg,
+			message:     msg,
+		})
+		return true, false, nil
+	}
+
+	newSizeStr := fmt.Sprintf("%.0fGi", newSizeGi)
+	if mode == modeRecommend {
+		reason := fmt.Sprintf("schedule %s", names)
+		if clampMsg != "" {
+			reason += "; " + clampMsg
+		}
+		c.recordDecision(ctx, vsName, vsObj, pvc.Name, scaleDecision{
+			decision:     decisionRecommended,
+			trigger:      triggerSchedule,
+			currentSize:  fmt.Sprintf("%.0fGi", specSizeGi),
+			computedSize: newSizeStr,
+			clamps:       clampMsg,
+			message:      reason,
+		})
+		return true, false, c.recordRecommendation(ctx, invRef, vsName, vsObj, pvc, specSizeGi, newSizeStr, reason)
+	}
+
+	if vsObj.Spec.ApprovalPolicy != nil {
+		if err := limits.mergeApprovalPolicy(vsObj.Spec.ApprovalPolicy, "VolumeScaler"); err != nil {
+			return false, false, fmt.Errorf("invalid approval policy: %v", err)
+		}
+	}
+	var approvedReq *v1alpha1.VolumeScaleRequest
+	if reason := approvalReason(limits, specSizeGi, newSizeGi); reason != "" {
+		approvedReq, err = c.checkApproval(ctx, invRef, vsName, vsObj, pvc, limits, specSizeGi, newSizeGi, maxSizeGi, reason)
+		if err != nil {
+			return false, false, fmt.Errorf("checking approval: %v", err)
+		}
+		if approvedReq == nil {
+			return false, false, nil
+		}
+		newSizeStr = approvedReq.Spec.RequestedSize
+	}
+	if ready, err := c.ensurePreResizeSnapshot(ctx, invRef, vsName, vsObj, pvc); err != nil || !ready {
+		return false, false, err
+	}
+	if granted, err := c.acquireResizeSlot(ctx, invRef, vsName, vsObj, vsName.Namespace+"/"+pvc.Name); err != nil || !granted {
+		return false, false, err
+	}
+
+	if err := c.startExpansion(ctx, invRef, vsName, vsObj, pvc, specSizeGi, newSizeStr, clampMsg, triggerSchedule, fmt.Sprintf("schedule %s", names)); err != nil {
+		return false, false, err
+	}
+	msg := fmt.Sprintf("Schedule %s raised PVC '%s/%s' from %.0fGi -> %s", names, vsName.Namespace, pvc.Name, specSizeGi, newSizeStr)
+	if clampMsg != "" {
+		msg += " (limited: " + clampMsg + ")"
+	}
+	c.eventWithPVC(invRef, pvc, corev1.EventTypeNormal, eventReasonScheduledScale, msg)
+	fmt.Printf("[INFO] %s\n", msg)
+	c.recordDecision(ctx, vsName, vsObj, pvc.Name, scaleDecision{
+		decision:     decisionExpanded,
+		trigger:      triggerSchedule,
+		currentSize:  fmt.Sprintf("%.0fGi", specSizeGi),
+		computedSize: newSizeStr,
+		clamps:       clampMsg,
+		message:      msg,
+	})
+	if approvedReq != nil {
+		if err := c.markRequestApplied(ctx, approvedReq); err != nil {
+			fmt.Printf("[WARN] %v\n", err)
+		}
+		if err := c.clearCondition(ctx, vsName, vsObj, conditionTypeApprovalRequired); err != nil {
+			fmt.Printf("[WARN] %v\n", err)
+		}
+	}
+	return true, true, nil
+}
+
+// deferForBlackout reports whether a threshold-triggered expansion should
+// wait for the blackout window ending at until. Usage at or above the
+// criticalThreshold overrides the window.
+func (c *VolumeScalerController) deferForBlackout(invRef *corev1.ObjectReference, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, pvc *corev1.PersistentVolumeClaim, until time.Time, usagePercent int) bool {
+	if until.IsZero() {
+		return false
+	}
+	if criticalUsage(vsObj, usagePercent) {
+		c.stateEvent(invRef, corev1.EventTypeWarning, eventReasonBlackoutOverridden, fmt.Sprintf(
+			"PVC '%s/%s' usage=%d%% >= criticalThreshold=%s; expanding despite the blackout window until %s",
+			vsName.Namespace, pvc.Name, usagePercent, vsObj.Spec.CriticalThreshold, formatStatusTime(until)))
+		return false
+	}
+	msg := fmt.Sprintf("PVC '%s/%s' usage=%d%% >= threshold=%s, but a blackout window is open until %s. Deferring expansion.",
+		vsName.Namespace, pvc.Name, usagePercent, vsObj.Spec.Threshold, formatStatusTime(until))
+	fmt.Printf("[INFO] %s\n", msg)
+	c.stateEvent(invRef, corev1.EventTypeNormal, eventReasonBlackoutActive, msg)
+	return true
+}
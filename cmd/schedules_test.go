@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+func (f *scalerFixture) status(t *testing.T) v1alpha1.VolumeScalerStatus {
+	t.Helper()
+	vs, err := f.vsClient.AutoscalingV1alpha1().VolumeScalers("default").Get(context.TODO(), "data", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get VolumeScaler: %v", err)
+	}
+	return vs.Status
+}
+
+// withSchedule adds a schedule that fires every minute and was last
+// evaluated two minutes ago. The threshold is raised so only the schedule
+// can expand the PVC.
+func withSchedule(minSize string) func(*v1alpha1.VolumeScaler) {
+	return func(vs *v1alpha1.VolumeScaler) {
+		vs.Spec.Threshold = "90%"
+		vs.Spec.Schedules = []v1alpha1.ScaleSchedule{{Name: "batch", Schedule: "* * * * *", MinSize: minSize}}
+		vs.Status.LastScheduleTime = time.Now().Add(-2 * time.Minute).UTC().Format(time.RFC3339)
+	}
+}
+
+// inBlackout adds a blackout window that opened at the start of this minute.
+func inBlackout(vs *v1alpha1.VolumeScaler) {
+	vs.Spec.BlackoutWindows = []v1alpha1.BlackoutWindow{{Name: "peak", Start: "* * * * *", Duration: "1h"}}
+}
+
+func TestDueScheduleFloor(t *testing.T) {
+	schedules := []v1alpha1.ScaleSchedule{
+		{Name: "month-end", Schedule: "0 0 28 * *", MinSize: "800Gi"},
+		{Name: "weekly", Schedule: "0 0 * * 0", MinSize: "500Gi"},
+	}
+	last := time.Date(2026, 2, 27, 12, 0, 0, 0, time.Local)
+
+	floor, names, err := dueScheduleFloor(schedules, last, last.Add(13*time.Hour))
+	if err != nil {
+		t.Fatalf("dueScheduleFloor() error = %v", err)
+	}
+	if floor != 800 || len(names) != 1 || names[0] != "month-end" {
+		t.Errorf("dueScheduleFloor() = %v, %v; want 800 from month-end", floor, names)
+	}
+
+	if floor, _, _ := dueScheduleFloor(schedules, last, last.Add(time.Hour)); floor != 0 {
+		t.Errorf("Expected no schedule to be due, got %v", floor)
+	}
+}
+
+func TestBlackoutState(t *testing.T) {
+	windows := []v1alpha1.BlackoutWindow{{Start: "0 8 * * *", Duration: "4h"}}
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+
+	until, next, err := blackoutState(windows, day.Add(9*time.Hour))
+	if err != nil {
+		t.Fatalf("blackoutState() error = %v", err)
+	}
+	if !until.Equal(day.Add(12*time.Hour)) || !next.Equal(day.Add(32*time.Hour)) {
+		t.Errorf("blackoutState() = %v, %v; want open until 12:00 and next at 08:00 tomorrow", until, next)
+	}
+
+	if until, _, _ := blackoutState(windows, day.Add(13*time.Hour)); !until.IsZero() {
+		t.Errorf("Expected the window to be closed at 13:00, got until %v", until)
+	}
+}
+
+func TestSchedule_FirstLoopOnlyRecordsTime(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		withSchedule("8Gi")(vs)
+		vs.Status.LastScheduleTime = ""
+	})
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "5Gi" {
+		t.Errorf("Expected no expansion before the schedule fires, got %s", size)
+	}
+	st := f.status(t)
+	if st.LastScheduleTime == "" || st.NextScheduleTime == "" || st.NextScheduleSize != "8Gi" {
+		t.Errorf("Expected schedule times in the status, got %+v", st)
+	}
+}
+
+func TestSchedule_RaisesToMinSizeDuringCooldown(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		withSchedule("8Gi")(vs)
+		inCooldown(vs)
+	})
+	before := f.status(t).LastScheduleTime
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "8Gi" {
+		t.Fatalf("Expected the schedule to raise the PVC to 8Gi, got %s", size)
+	}
+	st := f.status(t)
+	if !st.ResizeInProgress || st.LastScheduleTime == before {
+		t.Errorf("Expected a resize in progress and lastScheduleTime to advance, got %+v", st)
+	}
+	if !strings.Contains(strings.Join(drainEvents(f.recorder), "\n"), eventReasonScheduledScale) {
+		t.Errorf("Expected a %s event", eventReasonScheduledScale)
+	}
+}
+
+func TestSchedule_ClampedToMaxSize(t *testing.T) {
+	f := newScalerFixture(t, withSchedule("20Gi"))
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "10Gi" {
+		t.Errorf("Expected the schedule to stop at maxSize 10Gi, got %s", size)
+	}
+}
+
+func TestSchedule_AlreadyLargeEnough(t *testing.T) {
+	f := newScalerFixture(t, withSchedule("4Gi"))
+	before := f.status(t).LastScheduleTime
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "5Gi" {
+		t.Errorf("Expected no expansion, got %s", size)
+	}
+	if st := f.status(t); st.LastScheduleTime == before {
+		t.Errorf("Expected lastScheduleTime to advance, got %q", st.LastScheduleTime)
+	}
+}
+
+func TestBlackout_DefersThresholdExpansion(t *testing.T) {
+	f := newScalerFixture(t, inBlackout)
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "5Gi" {
+		t.Errorf("Expected the blackout window to defer the expansion, got %s", size)
+	}
+	if st := f.status(t); st.BlackoutUntil == "" || st.NextBlackoutTime == "" {
+		t.Errorf("Expected the blackout window in the status, got %+v", st)
+	}
+	if !strings.Contains(strings.Join(drainEvents(f.recorder), "\n"), eventReasonBlackoutActive) {
+		t.Errorf("Expected a %s event", eventReasonBlackoutActive)
+	}
+}
+
+func TestBlackout_CriticalThresholdOverrides(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		inBlackout(vs)
+		vs.Spec.CriticalThreshold = "75%"
+	})
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "7Gi" {
+		t.Errorf("Expected usage above criticalThreshold to expand despite the blackout, got %s", size)
+	}
+	if !strings.Contains(strings.Join(drainEvents(f.recorder), "\n"), eventReasonBlackoutOverridden) {
+		t.Errorf("Expected a %s event", eventReasonBlackoutOverridden)
+	}
+
+	// Overrides on the following loops are rate-limited like other states
+	vs, _ := f.vsClient.AutoscalingV1alpha1().VolumeScalers("default").Get(context.TODO(), "data", metav1.GetOptions{})
+	pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default"}}
+	vsName := types.NamespacedName{Namespace: "default", Name: "data"}
+	f.controller.deferForBlackout(makeInvolvedObjectRef(vsName, vs), vsName, vs, pvc, time.Now().Add(time.Hour), 90)
+	if events := drainEvents(f.recorder); len(events) != 0 {
+		t.Errorf("Expected the repeated override not to be emitted again, got %v", events)
+	}
+}
+
+func TestBlackout_DoesNotBlockSchedules(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		withSchedule("8Gi")(vs)
+		inBlackout(vs)
+	})
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "8Gi" {
+		t.Errorf("Expected the schedule to run inside the blackout window, got %s", size)
+	}
+}
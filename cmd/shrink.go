@@ -0,0 +1,619 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+const (
+	// Phases of a shrink migration, in order
+	shrinkPhasePending     = "Pending"     // waiting for the maintenance window or for the workload to be at zero
+	shrinkPhaseScalingDown = "ScalingDown" // the controller scaled the workload to zero and waits for its pods to stop
+	shrinkPhaseCopying     = "Copying"     // the copy Job moves the data to the smaller PVC
+	shrinkPhaseSwapping    = "Swapping"    // the workload is pointed at the smaller PVC
+	shrinkPhaseVerifying   = "Verifying"   // the old PVC is kept until the shrink is verified
+	shrinkPhaseCompleted   = "Completed"
+	shrinkPhaseFailed      = "Failed"
+
+	defaultShrinkTargetUsage = 60.0
+	defaultCopyImage         = "busybox:1.36"
+
+	// annotationShrinkVerified on a VolumeScaler confirms the workload runs
+	// fine on the smaller PVC, so the old one can be deleted
+	annotationShrinkVerified = annotationPrefix + "shrink-verified"
+	// labelShrunkFrom marks a PVC created by a shrink with the PVC it replaces
+	labelShrunkFrom = annotationPrefix + "shrunk-from"
+
+	// Event reasons
+	eventReasonShrinkStarted     = "ShrinkStarted"
+	eventReasonShrinkProgress    = "ShrinkProgress"
+	eventReasonShrinkFailed      = "ShrinkFailed"
+	eventReasonShrinkCompleted   = "ShrinkCompleted"
+	eventReasonShrinkRecommended = "ShrinkRecommended"
+)
+
+// shrinkMigrating reports whether a shrink has taken the PVC out of service.
+// Expansions of the VolumeScaler wait until the workload runs on the new PVC.
+func shrinkMigrating(st *v1alpha1.ShrinkStatus) bool {
+	if st == nil {
+		return false
+	}
+	switch st.Phase {
+	case shrinkPhasePending, shrinkPhaseScalingDown, shrinkPhaseCopying, shrinkPhaseSwapping:
+		return true
+	}
+	return false
+}
+
+// shrinkTargetGi sizes the new PVC so usedGi fills targetUsage of it, but no
+// less than minSize.
+func shrinkTargetGi(policy *v1alpha1.ShrinkPolicy, usedGi float64) (float64, error) {
+	targetUsage := defaultShrinkTargetUsage
+	if policy.TargetUsage != "" {
+		v, err := Percentage(policy.TargetUsage).ToFloat()
+		if err != nil || v <= 0 || v > 100 {
+			return 0, fmt.Errorf("invalid shrink.targetUsage '%s'", policy.TargetUsage)
+		}
+		targetUsage = v
+	}
+	targetGi := math.Max(math.Ceil(usedGi/(targetUsage/100)), 1)
+	if policy.MinSize != "" {
+		minGi, err := convertToGi(policy.MinSize)
+		if err != nil {
+			return 0, fmt.Errorf("invalid shrink.minSize '%s': %v", policy.MinSize, err)
+		}
+		targetGi = math.Max(targetGi, minGi)
+	}
+	return targetGi, nil
+}
+
+// shrinkMode returns the mode shrinks of vsObj run in: its effective mode,
+// with Enforce turned into Recommend unless the controller runs with --shrink.
+func (c *VolumeScalerController) shrinkMode(vsObj *v1alpha1.VolumeScaler) string {
+	mode := c.effectiveMode(vsObj)
+	if mode == modeEnforce && !c.config.Shrink {
+		return modeRecommend
+	}
+	return mode
+}
+
+// shrinkEnforced reports whether a shrink of vsObj may touch its workload and
+// PVCs: the VolumeScaler is neither suspended nor in Observe or Recommend
+// mode, and the controller runs with --shrink.
+func (c *VolumeScalerController) shrinkEnforced(vsObj *v1alpha1.VolumeScaler) bool {
+	return !vsObj.Spec.Suspend && c.shrinkMode(vsObj) == modeEnforce
+}
+
+// trackLowUsage records since when usage has stayed below shrink.belowUsage
+// and starts a shrink once that lasted shrink.sustainedFor. The controller on
+// this node then drives the migration, since the PVC stops reporting usage
+// once the workload is down. A shrink still waiting to start is canceled when
+// usage rises again, or when the VolumeScaler stops enforcing. In Recommend
+// mode, or without --shrink, the intended shrink is only published in
+// status.shrink.
+func (c *VolumeScalerController) trackLowUsage(ctx context.Context, invRef *corev1.ObjectReference, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, pvc *corev1.PersistentVolumeClaim, usedGi, specSizeGi float64, usagePercent int) error {
+	policy := vsObj.Spec.Shrink
+	if policy == nil {
+		return nil
+	}
+	st := &v1alpha1.ShrinkStatus{}
+	if vsObj.Status.Shrink != nil {
+		switch vsObj.Status.Shrink.Phase {
+		case shrinkPhaseScalingDown, shrinkPhaseCopying, shrinkPhaseSwapping, shrinkPhaseVerifying:
+			return nil
+		}
+		st = vsObj.Status.Shrink.DeepCopy()
+	}
+	mode := c.shrinkMode(vsObj)
+	if !c.shrinkEnforced(vsObj) && st.Phase == shrinkPhasePending {
+		c.recorder.Eventf(invRef, corev1.EventTypeNormal, eventReasonShrinkProgress,
+			"Shrink of PVC '%s/%s' canceled: shrinks of the VolumeScaler are no longer enforced", vsName.Namespace, pvc.Name)
+		return c.patchShrinkStatus(ctx, vsName, vsObj, &v1alpha1.ShrinkStatus{LowUsageSince: st.LowUsageSince})
+	}
+	if vsObj.Spec.Suspend || (mode != modeEnforce && mode != modeRecommend) {
+		return nil
+	}
+	below, err := Percentage(policy.BelowUsage).ToFloat()
+	if err != nil {
+		return fmt.Errorf("invalid shrink.belowUsage '%s': %v", policy.BelowUsage, err)
+	}
+	sustainedFor, err := time.ParseDuration(policy.SustainedFor)
+	if err != nil {
+		return fmt.Errorf("invalid shrink.sustainedFor '%s': %v", policy.SustainedFor, err)
+	}
+
+	now := time.Now().UTC()
+	if float64(usagePercent) >= below {
+		if st.Phase == shrinkPhasePending {
+			st = &v1alpha1.ShrinkStatus{}
+			c.recorder.Eventf(invRef, corev1.EventTypeNormal, eventReasonShrinkProgress,
+				"Shrink of PVC '%s/%s' canceled: usage rose to %d%%", vsName.Namespace, pvc.Name, usagePercent)
+			return c.patchShrinkStatus(ctx, vsName, vsObj, st)
+		}
+		if st.LowUsageSince == "" {
+			return nil
+		}
+		if st.Phase == "" {
+			// Drop the shrink published in Recommend mode as well
+			st = &v1alpha1.ShrinkStatus{}
+		}
+		st.LowUsageSince = ""
+		return c.patchShrinkStatus(ctx, vsName, vsObj, st)
+	}
+	if st.Phase == shrinkPhasePending {
+		return nil
+	}
+	since, err := time.Parse(time.RFC3339, st.LowUsageSince)
+	if err != nil {
+		st.LowUsageSince = formatStatusTime(now)
+		return c.patchShrinkStatus(ctx, vsName, vsObj, st)
+	}
+	if now.Sub(since) < sustainedFor {
+		return nil
+	}
+
+	targetGi, err := shrinkTargetGi(policy, usedGi)
+	if err != nil {
+		return err
+	}
+	if targetGi >= specSizeGi {
+		return nil
+	}
+	if mode == modeRecommend {
+		return c.recommendShrink(ctx, invRef, vsName, vsObj, pvc, st, specSizeGi, targetGi, usagePercent, sustainedFor)
+	}
+	st = &v1alpha1.ShrinkStatus{
+		LowUsageSince: st.LowUsageSince,
+		Node:          os.Getenv("NODE_NAME_ENV"),
+		SourcePVC:     pvc.Name,
+		TargetSize:    fmt.Sprintf("%.0fGi", targetGi),
+	}
+	msg := fmt.Sprintf("PVC '%s/%s' used %d%% of %.0fGi for %s; shrinking it to %s",
+		vsName.Namespace, pvc.Name, usagePercent, specSizeGi, sustainedFor, st.TargetSize)
+	c.recorder.Event(invRef, corev1.EventTypeNormal, eventReasonShrinkStarted, msg)
+	fmt.Printf("[INFO] %s\n", msg)
+	return c.setShrinkPhase(ctx, invRef, vsName, vsObj, st, shrinkPhasePending, "Waiting for the workload to be scaled to zero or for the maintenance window")
+}
+
+// recommendShrink publishes the shrink the controller would start in
+// status.shrink, without a phase, with a ShrinkRecommended event. Both are
+// only written when the target size changes.
+func (c *VolumeScalerController) recommendShrink(ctx context.Context, invRef *corev1.ObjectReference, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, pvc *corev1.PersistentVolumeClaim, st *v1alpha1.ShrinkStatus, specSizeGi, targetGi float64, usagePercent int, sustainedFor time.Duration) error {
+	targetSize := fmt.Sprintf("%.0fGi", targetGi)
+	if st.Phase == "" && st.TargetSize == targetSize {
+		return nil
+	}
+	msg := fmt.Sprintf("Recommend shrinking PVC '%s/%s' from %.0fGi to %s: it used %d%% for %s",
+		vsName.Namespace, pvc.Name, specSizeGi, targetSize, usagePercent, sustainedFor)
+	c.recorder.Event(invRef, corev1.EventTypeNormal, eventReasonShrinkRecommended, msg)
+	fmt.Printf("[INFO] %s\n", msg)
+	c.recordDecision(ctx, vsName, vsObj, pvc.Name, scaleDecision{
+		decision:     decisionRecommended,
+		trigger:      triggerShrink,
+		currentSize:  fmt.Sprintf("%.0fGi", specSizeGi),
+		computedSize: targetSize,
+		message:      msg,
+	})
+	return c.patchShrinkStatus(ctx, vsName, vsObj, &v1alpha1.ShrinkStatus{
+		LowUsageSince: st.LowUsageSince,
+		SourcePVC:     pvc.Name,
+		TargetSize:    targetSize,
+		Message:       msg,
+	})
+}
+
+// reconcileShrinks advances the shrink migrations driven by this node. They
+// don't depend on usage data, which the PVC stops reporting once its workload
+// is down. A migration pauses while its shrinks are not enforced.
+func (c *VolumeScalerController) reconcileShrinks(ctx context.Context, nodeName string, vsList *v1alpha1.VolumeScalerList) {
+	for i := range vsList.Items {
+		vsObj := &vsList.Items[i]
+		st := vsObj.Status.Shrink
+		if st == nil || st.Node != nodeName || (!shrinkMigrating(st) && st.Phase != shrinkPhaseVerifying) {
+			continue
+		}
+		if !c.shrinkEnforced(vsObj) {
+			fmt.Printf("[INFO] Shrink of PVC '%s/%s' is paused in phase %s: shrinks of the VolumeScaler are not enforced.\n",
+				vsObj.Namespace, st.SourcePVC, st.Phase)
+			continue
+		}
+		if err := c.advanceShrink(ctx, vsObj); err != nil {
+			fmt.Printf("[ERROR] shrinking PVC '%s/%s': %v\n", vsObj.Namespace, st.SourcePVC, err)
+		}
+	}
+}
+
+// advanceShrink moves a shrink migration to its next phase once the current
+// one is done. It does nothing unless the VolumeScaler enforces.
+func (c *VolumeScalerController) advanceShrink(ctx context.Context, vsObj *v1alpha1.VolumeScaler) error {
+	if !c.shrinkEnforced(vsObj) {
+		return nil
+	}
+	vsName := types.NamespacedName{Namespace: vsObj.Namespace, Name: vsObj.Name}
+	invRef := makeInvolvedObjectRef(vsName, vsObj)
+	st := vsObj.Status.Shrink.DeepCopy()
+	policy := vsObj.Spec.Shrink
+	if policy == nil {
+		return c.failShrink(ctx, invRef, vsName, vsObj, st, nil, "spec.shrink was removed during the migration")
+	}
+	ref := policy.Workload
+
+	switch st.Phase {
+	case shrinkPhasePending:
+		podSpec, desired, current, err := c.getWorkload(ctx, vsName.Namespace, ref)
+		if err != nil {
+			return c.failShrink(ctx, invRef, vsName, vsObj, st, policy, err.Error())
+		}
+		if claimVolume(podSpec, st.SourcePVC) == nil {
+			return c.failShrink(ctx, invRef, vsName, vsObj, st, policy, fmt.Sprintf(
+				"%s '%s' doesn't mount PVC '%s' in its pod template; claims from volumeClaimTemplates can't be swapped",
+				ref.Kind, ref.Name, st.SourcePVC))
+		}
+		if desired == 0 && current == 0 {
+			return c.startCopy(ctx, invRef, vsName, vsObj, st, policy)
+		}
+		if policy.MaintenanceWindow == nil {
+			return nil
+		}
+		until, _, err := blackoutState([]v1alpha1.BlackoutWindow{*policy.MaintenanceWindow}, time.Now())
+		if err != nil {
+			return c.failShrink(ctx, invRef, vsName, vsObj, st, policy, "maintenance window: "+err.Error())
+		}
+		if until.IsZero() {
+			return nil
+		}
+		if err := c.scaleWorkload(ctx, vsName.Namespace, ref, 0); err != nil {
+			return err
+		}
+		st.OriginalReplicas = desired
+		return c.setShrinkPhase(ctx, invRef, vsName, vsObj, st, shrinkPhaseScalingDown,
+			fmt.Sprintf("Scaled %s '%s' from %d replicas to zero for the maintenance window", ref.Kind, ref.Name, desired))
+
+	case shrinkPhaseScalingDown:
+		_, _, current, err := c.getWorkload(ctx, vsName.Namespace, ref)
+		if err != nil {
+			return c.failShrink(ctx, invRef, vsName, vsObj, st, policy, err.Error())
+		}
+		if current > 0 {
+			return nil
+		}
+		return c.startCopy(ctx, invRef, vsName, vsObj, st, policy)
+
+	case shrinkPhaseCopying:
+		job, err := c.clientset.BatchV1().Jobs(vsName.Namespace).Get(ctx, st.CopyJob, metav1.GetOptions{})
+		if err != nil {
+			return c.failShrink(ctx, invRef, vsName, vsObj, st, policy, fmt.Sprintf("fetching copy Job '%s': %v", st.CopyJob, err))
+		}
+		for _, cond := range job.Status.Conditions {
+			if cond.Type == batchv1.JobFailed && cond.Status == corev1.ConditionTrue {
+				return c.failShrink(ctx, invRef, vsName, vsObj, st, policy, fmt.Sprintf("copy Job '%s' failed: %s", st.CopyJob, cond.Message))
+			}
+		}
+		if job.Status.Succeeded == 0 {
+			return nil
+		}
+		return c.setShrinkPhase(ctx, invRef, vsName, vsObj, st, shrinkPhaseSwapping,
+			fmt.Sprintf("Copied the data to PVC '%s'", st.TargetPVC))
+
+	case shrinkPhaseSwapping:
+		if err := c.swapWorkloadClaim(ctx, vsName.Namespace, ref, st.SourcePVC, st.TargetPVC); err != nil {
+			return c.failShrink(ctx, invRef, vsName, vsObj, st, policy, err.Error())
+		}
+		patch, _ := json.Marshal(map[string]interface{}{"spec": map[string]interface{}{"pvcName": st.TargetPVC}})
+		if _, err := c.vsClient.AutoscalingV1alpha1().VolumeScalers(vsName.Namespace).
+			Patch(ctx, vsName.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			return fmt.Errorf("pointing the VolumeScaler at PVC '%s': %v", st.TargetPVC, err)
+		}
+		if st.OriginalReplicas > 0 {
+			if err := c.scaleWorkload(ctx, vsName.Namespace, ref, st.OriginalReplicas); err != nil {
+				return err
+			}
+		}
+		return c.setShrinkPhase(ctx, invRef, vsName, vsObj, st, shrinkPhaseVerifying, fmt.Sprintf(
+			"%s '%s' now uses PVC '%s'. PVC '%s' is kept until the VolumeScaler is annotated with %s",
+			ref.Kind, ref.Name, st.TargetPVC, st.SourcePVC, annotationShrinkVerified))
+
+	case shrinkPhaseVerifying:
+		if _, ok := vsObj.Annotations[annotationShrinkVerified]; !ok {
+			return nil
+		}
+		err := c.clientset.CoreV1().PersistentVolumeClaims(vsName.Namespace).Delete(ctx, st.SourcePVC, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("deleting PVC '%s': %v", st.SourcePVC, err)
+		}
+		background := metav1.DeletePropagationBackground
+		err = c.clientset.BatchV1().Jobs(vsName.Namespace).Delete(ctx, st.CopyJob, metav1.DeleteOptions{PropagationPolicy: &background})
+		if err != nil && !apierrors.IsNotFound(err) {
+			fmt.Printf("[WARN] deleting copy Job '%s/%s': %v\n", vsName.Namespace, st.CopyJob, err)
+		}
+		patch, _ := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{"annotations": map[string]interface{}{annotationShrinkVerified: nil}},
+		})
+		if _, err := c.vsClient.AutoscalingV1alpha1().VolumeScalers(vsName.Namespace).
+			Patch(ctx, vsName.Name, types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			fmt.Printf("[WARN] removing %s: %v\n", annotationShrinkVerified, err)
+		}
+		msg := fmt.Sprintf("Shrink of PVC '%s/%s' to %s completed; deleted PVC '%s'", vsName.Namespace, st.TargetPVC, st.TargetSize, st.SourcePVC)
+		c.recorder.Event(invRef, corev1.EventTypeNormal, eventReasonShrinkCompleted, msg)
+		fmt.Printf("[INFO] %s\n", msg)
+		st.LowUsageSince = ""
+		st.Phase, st.Message, st.PhaseSince = shrinkPhaseCompleted, msg, formatStatusTime(time.Now())
+		return c.patchShrinkStatus(ctx, vsName, vsObj, st)
+	}
+	return nil
+}
+
+// shrinkTargetName names the PVC of a shrink attempt after the VolumeScaler
+// UID and the low-usage period that started the attempt. A startCopy retried
+// after a failed status patch, or run by two controllers, reuses the same PVC
+// and Job instead of leaving orphans.
+func shrinkTargetName(vsObj *v1alpha1.VolumeScaler, st *v1alpha1.ShrinkStatus) string {
+	h := fnv.New32a()
+	h.Write([]byte(string(vsObj.UID) + "/" + st.LowUsageSince))
+	return fmt.Sprintf("%s-shrunk-%08x", st.SourcePVC, h.Sum32())
+}
+
+// podsUsingClaim returns the running or pending pods of namespace that mount
+// claim, other than those of the Job named skipJob.
+func (c *VolumeScalerController) podsUsingClaim(ctx context.Context, namespace, claim, skipJob string) ([]string, error) {
+	pods, err := c.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing pods in '%s': %v", namespace, err)
+	}
+	var names []string
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed || pod.Labels["job-name"] == skipJob {
+			continue
+		}
+		if claimVolume(&pod.Spec, claim) != nil {
+			names = append(names, pod.Name)
+		}
+	}
+	return names, nil
+}
+
+// startCopy creates the smaller PVC and the Job copying the data into it.
+// Either may already exist from an earlier try of the same attempt. The copy
+// waits while any other pod, not only the workload's, still mounts the PVC.
+func (c *VolumeScalerController) startCopy(ctx context.Context, invRef *corev1.ObjectReference, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, st *v1alpha1.ShrinkStatus, policy *v1alpha1.ShrinkPolicy) error {
+	users, err := c.podsUsingClaim(ctx, vsName.Namespace, st.SourcePVC, shrinkTargetName(vsObj, st)+"-copy")
+	if err != nil {
+		return err
+	}
+	if len(users) > 0 {
+		msg := fmt.Sprintf("Shrink of PVC '%s/%s' waits to copy the data: still mounted by pods %s",
+			vsName.Namespace, st.SourcePVC, strings.Join(users, ", "))
+		fmt.Printf("[INFO] %s\n", msg)
+		c.stateEvent(invRef, corev1.EventTypeNormal, eventReasonShrinkProgress, msg)
+		return nil
+	}
+	source, err := c.clientset.CoreV1().PersistentVolumeClaims(vsName.Namespace).Get(ctx, st.SourcePVC, metav1.GetOptions{})
+	if err != nil {
+		return c.failShrink(ctx, invRef, vsName, vsObj, st, policy, fmt.Sprintf("fetching PVC '%s': %v", st.SourcePVC, err))
+	}
+	size, err := resource.ParseQuantity(st.TargetSize)
+	if err != nil {
+		return c.failShrink(ctx, invRef, vsName, vsObj, st, policy, fmt.Sprintf("invalid target size '%s'", st.TargetSize))
+	}
+
+	st.TargetPVC = shrinkTargetName(vsObj, st)
+	target := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      st.TargetPVC,
+			Namespace: vsName.Namespace,
+			Labels:    map[string]string{labelShrunkFrom: st.SourcePVC},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      source.Spec.AccessModes,
+			StorageClassName: source.Spec.StorageClassName,
+			VolumeMode:       source.Spec.VolumeMode,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+			},
+		},
+	}
+	_, err = c.clientset.CoreV1().PersistentVolumeClaims(vsName.Namespace).Create(ctx, target, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return c.failShrink(ctx, invRef, vsName, vsObj, st, policy, fmt.Sprintf("creating PVC '%s': %v", st.TargetPVC, err))
+	}
+
+	image := policy.CopyImage
+	if image == "" {
+		image = defaultCopyImage
+	}
+	st.CopyJob = st.TargetPVC + "-copy"
+	backoff := int32(2)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      st.CopyJob,
+			Namespace: vsName.Namespace,
+			Labels:    map[string]string{labelShrunkFrom: st.SourcePVC},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoff,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{{
+						Name:    "copy",
+						Image:   image,
+						Command: []string{"sh", "-c", "cp -a /source/. /target/"},
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "source", MountPath: "/source", ReadOnly: true},
+							{Name: "target", MountPath: "/target"},
+						},
+					}},
+					Volumes: []corev1.Volume{
+						{Name: "source", VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: st.SourcePVC, ReadOnly: true},
+						}},
+						{Name: "target", VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: st.TargetPVC},
+						}},
+					},
+				},
+			},
+		},
+	}
+	_, err = c.clientset.BatchV1().Jobs(vsName.Namespace).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return c.failShrink(ctx, invRef, vsName, vsObj, st, policy, fmt.Sprintf("creating copy Job '%s': %v", st.CopyJob, err))
+	}
+	return c.setShrinkPhase(ctx, invRef, vsName, vsObj, st, shrinkPhaseCopying,
+		fmt.Sprintf("Copying PVC '%s' to '%s' (%s) with Job '%s'", st.SourcePVC, st.TargetPVC, st.TargetSize, st.CopyJob))
+}
+
+// failShrink ends the migration. A workload the controller scaled down still
+// mounts the old PVC and is scaled back up; the new PVC and copy Job are kept
+// for inspection. Low usage has to last sustainedFor again before a retry.
+func (c *VolumeScalerController) failShrink(ctx context.Context, invRef *corev1.ObjectReference, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, st *v1alpha1.ShrinkStatus, policy *v1alpha1.ShrinkPolicy, reason string) error {
+	msg := fmt.Sprintf("Shrink of PVC '%s/%s' failed in phase %s: %s", vsName.Namespace, st.SourcePVC, st.Phase, reason)
+	decision := decisionFailed
+	if policy != nil && st.OriginalReplicas > 0 {
+		if err := c.scaleWorkload(ctx, vsName.Namespace, policy.Workload, st.OriginalReplicas); err != nil {
+			msg += fmt.Sprintf("; scaling %s '%s' back up failed: %v", policy.Workload.Kind, policy.Workload.Name, err)
+		} else {
+			decision = decisionRolledBack
+		}
+	}
+	c.recorder.Event(invRef, corev1.EventTypeWarning, eventReasonShrinkFailed, msg)
+	fmt.Printf("[WARNING] %s\n", msg)
+	c.recordDecision(ctx, vsName, vsObj, st.SourcePVC, scaleDecision{
+		decision:     decision,
+		trigger:      triggerShrink,
+		currentSize:  vsObj.Status.CurrentSizeGi,
+		computedSize: st.TargetSize,
+		message:      msg,
+	})
+	st.LowUsageSince = ""
+	st.Phase, st.Message, st.PhaseSince = shrinkPhaseFailed, msg, formatStatusTime(time.Now())
+	return c.patchShrinkStatus(ctx, vsName, vsObj, st)
+}
+
+// setShrinkPhase moves the migration to phase and records msg.
+func (c *VolumeScalerController) setShrinkPhase(ctx context.Context, invRef *corev1.ObjectReference, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, st *v1alpha1.ShrinkStatus, phase, msg string) error {
+	st.Phase, st.Message, st.PhaseSince = phase, msg, formatStatusTime(time.Now())
+	c.recorder.Eventf(invRef, corev1.EventTypeNormal, eventReasonShrinkProgress, "Shrink of PVC '%s/%s' is %s: %s",
+		vsName.Namespace, st.SourcePVC, phase, msg)
+	fmt.Printf("[INFO] Shrink of PVC '%s/%s' is %s: %s\n", vsName.Namespace, st.SourcePVC, phase, msg)
+	return c.patchShrinkStatus(ctx, vsName, vsObj, st)
+}
+
+// patchShrinkStatus replaces status.shrink with st.
+func (c *VolumeScalerController) patchShrinkStatus(ctx context.Context, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, st *v1alpha1.ShrinkStatus) error {
+	// A JSON patch replaces the whole object, so cleared fields don't linger
+	patch, err := json.Marshal([]map[string]interface{}{{"op": "add", "path": "/status/shrink", "value": st}})
+	if err != nil {
+		return fmt.Errorf("encoding shrink status patch: %v", err)
+	}
+	_, err = c.vsClient.AutoscalingV1alpha1().VolumeScalers(vsName.Namespace).
+		Patch(ctx, vsName.Name, types.JSONPatchType, patch, metav1.PatchOptions{}, "status")
+	if err != nil {
+		return fmt.Errorf("patching shrink status: %v", err)
+	}
+	vsObj.Status.Shrink = st
+	return nil
+}
+
+// claimVolume returns the volume of podSpec that mounts claim.
+func claimVolume(podSpec *corev1.PodSpec, claim string) *corev1.Volume {
+	for i := range podSpec.Volumes {
+		if pvc := podSpec.Volumes[i].PersistentVolumeClaim; pvc != nil && pvc.ClaimName == claim {
+			return &podSpec.Volumes[i]
+		}
+	}
+	return nil
+}
+
+// getWorkload returns the pod template of a Deployment or StatefulSet with
+// its desired and current replicas.
+func (c *VolumeScalerController) getWorkload(ctx context.Context, namespace string, ref v1alpha1.WorkloadReference) (*corev1.PodSpec, int32, int32, error) {
+	desired := func(replicas *int32) int32 {
+		if replicas == nil {
+			return 1
+		}
+		return *replicas
+	}
+	switch ref.Kind {
+	case "Deployment":
+		d, err := c.clientset.AppsV1().Deployments(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, 0, 0, fmt.Errorf("fetching Deployment '%s': %v", ref.Name, err)
+		}
+		return &d.Spec.Template.Spec, desired(d.Spec.Replicas), d.Status.Replicas, nil
+	case "StatefulSet":
+		s, err := c.clientset.AppsV1().StatefulSets(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, 0, 0, fmt.Errorf("fetching StatefulSet '%s': %v", ref.Name, err)
+		}
+		return &s.Spec.Template.Spec, desired(s.Spec.Replicas), s.Status.Replicas, nil
+	}
+	return nil, 0, 0, fmt.Errorf("unsupported workload kind '%s'; use Deployment or StatefulSet", ref.Kind)
+}
+
+// scaleWorkload sets the replicas of a Deployment or StatefulSet.
+func (c *VolumeScalerController) scaleWorkload(ctx context.Context, namespace string, ref v1alpha1.WorkloadReference, replicas int32) error {
+	patch := []byte(fmt.Sprintf(`{"spec":{"replicas":%d}}`, replicas))
+	var err error
+	switch ref.Kind {
+	case "Deployment":
+		_, err = c.clientset.AppsV1().Deployments(namespace).Patch(ctx, ref.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	case "StatefulSet":
+		_, err = c.clientset.AppsV1().StatefulSets(namespace).Patch(ctx, ref.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	default:
+		err = fmt.Errorf("unsupported workload kind '%s'", ref.Kind)
+	}
+	if err != nil {
+		return fmt.Errorf("scaling %s '%s' to %d: %v", ref.Kind, ref.Name, replicas, err)
+	}
+	return nil
+}
+
+// swapWorkloadClaim points the pod template volume mounting from at to.
+func (c *VolumeScalerController) swapWorkloadClaim(ctx context.Context, namespace string, ref v1alpha1.WorkloadReference, from, to string) error {
+	switch ref.Kind {
+	case "Deployment":
+		d, err := c.clientset.AppsV1().Deployments(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("fetching Deployment '%s': %v", ref.Name, err)
+		}
+		if v := claimVolume(&d.Spec.Template.Spec, from); v != nil {
+			v.PersistentVolumeClaim.ClaimName = to
+		}
+		_, err = c.clientset.AppsV1().Deployments(namespace).Update(ctx, d, metav1.UpdateOptions{})
+		if err != nil {
+			return fmt.Errorf("updating Deployment '%s': %v", ref.Name, err)
+		}
+	case "StatefulSet":
+		s, err := c.clientset.AppsV1().StatefulSets(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("fetching StatefulSet '%s': %v", ref.Name, err)
+		}
+		if v := claimVolume(&s.Spec.Template.Spec, from); v != nil {
+			v.PersistentVolumeClaim.ClaimName = to
+		}
+		_, err = c.clientset.AppsV1().StatefulSets(namespace).Update(ctx, s, metav1.UpdateOptions{})
+		if err != nil {
+			return fmt.Errorf("updating StatefulSet '%s': %v", ref.Name, err)
+		}
+	default:
+		return fmt.Errorf("unsupported workload kind '%s'", ref.Kind)
+	}
+	return nil
+}
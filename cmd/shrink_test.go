@@ -0,0 +1,402 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+// shrinkable enables shrinking the fixture PVC into Deployment "app" after
+// usage has been below 20% for an hour, which it has for two.
+func shrinkable(vs *v1alpha1.VolumeScaler) {
+	vs.Spec.Shrink = &v1alpha1.ShrinkPolicy{
+		BelowUsage: "20%", SustainedFor: "1h", TargetUsage: "50%",
+		Workload: v1alpha1.WorkloadReference{Kind: "Deployment", Name: "app"},
+	}
+	vs.Status.Shrink = &v1alpha1.ShrinkStatus{LowUsageSince: time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339)}
+}
+
+// newShrinkFixture returns a fixture, running with --shrink, whose 5Gi PVC
+// holds 0.5Gi and is mounted by Deployment "app" with replicas.
+func newShrinkFixture(t *testing.T, mutate func(*v1alpha1.VolumeScaler), replicas int32) *scalerFixture {
+	t.Helper()
+	t.Setenv("NODE_NAME_ENV", "node-1")
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		shrinkable(vs)
+		mutate(vs)
+	})
+	f.controller.config.Shrink = true
+	f.setUsedGi(0.5)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{{Name: "data", VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data"},
+				}}},
+			}},
+		},
+		Status: appsv1.DeploymentStatus{Replicas: replicas},
+	}
+	if _, err := f.clientset.AppsV1().Deployments("default").Create(context.TODO(), deployment, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create Deployment: %v", err)
+	}
+	return f
+}
+
+// advanceShrinks runs the shrink pass of node-1's reconcile loop.
+func (f *scalerFixture) advanceShrinks(t *testing.T) {
+	t.Helper()
+	vsList, err := f.vsClient.AutoscalingV1alpha1().VolumeScalers("").List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Failed to list VolumeScalers: %v", err)
+	}
+	f.controller.reconcileShrinks(context.TODO(), "node-1", vsList)
+}
+
+func (f *scalerFixture) shrinkPhase(t *testing.T) string {
+	t.Helper()
+	if st := f.status(t).Shrink; st != nil {
+		return st.Phase
+	}
+	return ""
+}
+
+func (f *scalerFixture) deployment(t *testing.T) *appsv1.Deployment {
+	t.Helper()
+	d, err := f.clientset.AppsV1().Deployments("default").Get(context.TODO(), "app", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get Deployment: %v", err)
+	}
+	return d
+}
+
+// setDeploymentReplicas sets the desired and running replicas of "app".
+func (f *scalerFixture) setDeploymentReplicas(t *testing.T, replicas int32) {
+	t.Helper()
+	d := f.deployment(t)
+	d.Spec.Replicas, d.Status.Replicas = &replicas, replicas
+	if _, err := f.clientset.AppsV1().Deployments("default").Update(context.TODO(), d, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to update Deployment: %v", err)
+	}
+}
+
+// finishCopy marks the copy Job as succeeded, or failed.
+func (f *scalerFixture) finishCopy(t *testing.T, succeeded bool) {
+	t.Helper()
+	name := f.status(t).Shrink.CopyJob
+	job, err := f.clientset.BatchV1().Jobs("default").Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get copy Job: %v", err)
+	}
+	if succeeded {
+		job.Status.Succeeded = 1
+	} else {
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"}}
+	}
+	if _, err := f.clientset.BatchV1().Jobs("default").Update(context.TODO(), job, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to update copy Job: %v", err)
+	}
+}
+
+func TestShrink_MigratesToSmallerPVC(t *testing.T) {
+	f := newShrinkFixture(t, func(vs *v1alpha1.VolumeScaler) {}, 1)
+
+	f.reconcile(t)
+	st := f.status(t).Shrink
+	if st == nil || st.Phase != shrinkPhasePending || st.TargetSize != "1Gi" || st.Node != "node-1" {
+		t.Fatalf("Expected a Pending shrink to 1Gi driven by node-1, got %+v", st)
+	}
+
+	f.advanceShrinks(t)
+	if phase := f.shrinkPhase(t); phase != shrinkPhasePending {
+		t.Fatalf("Expected the shrink to wait for the Deployment to be scaled to zero, got %s", phase)
+	}
+
+	f.setDeploymentReplicas(t, 0)
+	f.advanceShrinks(t)
+	st = f.status(t).Shrink
+	if st.Phase != shrinkPhaseCopying {
+		t.Fatalf("Expected the copy to start, got %+v", st)
+	}
+	target, err := f.clientset.CoreV1().PersistentVolumeClaims("default").Get(context.TODO(), st.TargetPVC, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected the smaller PVC to be created: %v", err)
+	}
+	if size := target.Spec.Resources.Requests[corev1.ResourceStorage]; size.String() != "1Gi" {
+		t.Errorf("Expected the new PVC to request 1Gi, got %s", size.String())
+	}
+	job, err := f.clientset.BatchV1().Jobs("default").Get(context.TODO(), st.CopyJob, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected the copy Job to be created: %v", err)
+	}
+	if vols := job.Spec.Template.Spec.Volumes; len(vols) != 2 || vols[0].PersistentVolumeClaim.ClaimName != "data" || vols[1].PersistentVolumeClaim.ClaimName != st.TargetPVC {
+		t.Errorf("Expected the Job to mount data and %s, got %+v", st.TargetPVC, vols)
+	}
+
+	f.advanceShrinks(t)
+	if phase := f.shrinkPhase(t); phase != shrinkPhaseCopying {
+		t.Fatalf("Expected the shrink to wait for the copy, got %s", phase)
+	}
+	f.finishCopy(t, true)
+	f.advanceShrinks(t)
+	f.advanceShrinks(t)
+	if phase := f.shrinkPhase(t); phase != shrinkPhaseVerifying {
+		t.Fatalf("Expected the shrink to wait for verification, got %s", phase)
+	}
+	if claim := f.deployment(t).Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ClaimName; claim != st.TargetPVC {
+		t.Errorf("Expected the Deployment to mount %s, got %s", st.TargetPVC, claim)
+	}
+	vs, _ := f.vsClient.AutoscalingV1alpha1().VolumeScalers("default").Get(context.TODO(), "data", metav1.GetOptions{})
+	if vs.Spec.PVCName != st.TargetPVC {
+		t.Errorf("Expected the VolumeScaler to target %s, got %s", st.TargetPVC, vs.Spec.PVCName)
+	}
+	if _, err := f.clientset.CoreV1().PersistentVolumeClaims("default").Get(context.TODO(), "data", metav1.GetOptions{}); err != nil {
+		t.Errorf("Expected the old PVC to be kept until verified: %v", err)
+	}
+
+	vs.Annotations = map[string]string{annotationShrinkVerified: "true"}
+	if _, err := f.vsClient.AutoscalingV1alpha1().VolumeScalers("default").Update(context.TODO(), vs, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to annotate VolumeScaler: %v", err)
+	}
+	f.advanceShrinks(t)
+	if phase := f.shrinkPhase(t); phase != shrinkPhaseCompleted {
+		t.Fatalf("Expected the shrink to complete, got %s", phase)
+	}
+	if _, err := f.clientset.CoreV1().PersistentVolumeClaims("default").Get(context.TODO(), "data", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("Expected the old PVC to be deleted, got %v", err)
+	}
+}
+
+func TestShrink_MaintenanceWindowScalesDownAndRestoresOnFailure(t *testing.T) {
+	f := newShrinkFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		vs.Spec.Shrink.MaintenanceWindow = &v1alpha1.BlackoutWindow{Start: "* * * * *", Duration: "1h"}
+	}, 3)
+
+	f.reconcile(t)
+	f.advanceShrinks(t)
+	if st := f.status(t).Shrink; st.Phase != shrinkPhaseScalingDown || st.OriginalReplicas != 3 {
+		t.Fatalf("Expected the controller to scale the Deployment down, got %+v", st)
+	}
+	if replicas := *f.deployment(t).Spec.Replicas; replicas != 0 {
+		t.Errorf("Expected the Deployment scaled to zero, got %d", replicas)
+	}
+
+	f.advanceShrinks(t)
+	if phase := f.shrinkPhase(t); phase != shrinkPhaseScalingDown {
+		t.Fatalf("Expected the shrink to wait for the pods to stop, got %s", phase)
+	}
+	f.setDeploymentReplicas(t, 0)
+	f.advanceShrinks(t)
+	if phase := f.shrinkPhase(t); phase != shrinkPhaseCopying {
+		t.Fatalf("Expected the copy to start, got %s", phase)
+	}
+
+	f.finishCopy(t, false)
+	f.advanceShrinks(t)
+	if phase := f.shrinkPhase(t); phase != shrinkPhaseFailed {
+		t.Fatalf("Expected the shrink to fail with its copy Job, got %s", phase)
+	}
+	if replicas := *f.deployment(t).Spec.Replicas; replicas != 3 {
+		t.Errorf("Expected the Deployment scaled back to 3 replicas, got %d", replicas)
+	}
+	if events := strings.Join(drainEvents(f.recorder), "\n"); !strings.Contains(events, eventReasonShrinkFailed) {
+		t.Errorf("Expected a %s event, got %s", eventReasonShrinkFailed, events)
+	}
+}
+
+func TestShrink_PendingCanceledWhenUsageRises(t *testing.T) {
+	f := newShrinkFixture(t, func(vs *v1alpha1.VolumeScaler) {}, 1)
+
+	f.reconcile(t)
+	if phase := f.shrinkPhase(t); phase != shrinkPhasePending {
+		t.Fatalf("Expected a Pending shrink, got %s", phase)
+	}
+
+	f.setUsedGi(4)
+	f.reconcile(t)
+	if phase := f.shrinkPhase(t); phase != "" {
+		t.Errorf("Expected the shrink to be canceled, got %s", phase)
+	}
+	if size := f.pvcSize(t); size != "7Gi" {
+		t.Errorf("Expected the PVC to expand once the shrink was canceled, got %s", size)
+	}
+}
+
+func TestShrink_ExpansionsWaitWhileMigrating(t *testing.T) {
+	f := newShrinkFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		vs.Status.Shrink = &v1alpha1.ShrinkStatus{Phase: shrinkPhaseCopying, Node: "node-1", SourcePVC: "data"}
+	}, 0)
+	f.setUsedGi(4)
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "5Gi" {
+		t.Errorf("Expected no expansion while the PVC is copied, got %s", size)
+	}
+}
+
+func TestShrink_CopyReusesExistingTarget(t *testing.T) {
+	f := newShrinkFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		vs.Status.Shrink = &v1alpha1.ShrinkStatus{
+			Phase: shrinkPhasePending, Node: "node-1", SourcePVC: "data", TargetSize: "1Gi",
+			LowUsageSince: time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339),
+		}
+	}, 0)
+	// An earlier try created the PVC and Job but failed to record them
+	vs, _ := f.vsClient.AutoscalingV1alpha1().VolumeScalers("default").Get(context.TODO(), "data", metav1.GetOptions{})
+	name := shrinkTargetName(vs, vs.Status.Shrink)
+	pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	if _, err := f.clientset.CoreV1().PersistentVolumeClaims("default").Create(context.TODO(), pvc, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create PVC: %v", err)
+	}
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: name + "-copy", Namespace: "default"}}
+	if _, err := f.clientset.BatchV1().Jobs("default").Create(context.TODO(), job, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create Job: %v", err)
+	}
+
+	f.advanceShrinks(t)
+	st := f.status(t).Shrink
+	if st.Phase != shrinkPhaseCopying || st.TargetPVC != name || st.CopyJob != name+"-copy" {
+		t.Errorf("Expected the copy to continue with %s, got %+v", name, st)
+	}
+}
+
+func TestShrink_OnlyWhenEnforcing(t *testing.T) {
+	for name, mutate := range map[string]func(vs *v1alpha1.VolumeScaler){
+		"suspended": func(vs *v1alpha1.VolumeScaler) { vs.Spec.Suspend = true },
+		"observe":   func(vs *v1alpha1.VolumeScaler) { vs.Spec.Mode = modeObserve },
+	} {
+		t.Run(name, func(t *testing.T) {
+			f := newShrinkFixture(t, mutate, 0)
+
+			f.reconcile(t)
+			if phase := f.shrinkPhase(t); phase != "" {
+				t.Fatalf("Expected no shrink to start, got %s", phase)
+			}
+			pvcs, err := f.clientset.CoreV1().PersistentVolumeClaims("default").List(context.TODO(), metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Failed to list PVCs: %v", err)
+			}
+			if len(pvcs.Items) != 1 {
+				t.Errorf("Expected only the original PVC, got %d PVCs", len(pvcs.Items))
+			}
+		})
+	}
+}
+
+func TestShrink_OnlyRecommendedWithoutShrinkFlag(t *testing.T) {
+	f := newShrinkFixture(t, func(vs *v1alpha1.VolumeScaler) {}, 0)
+	f.controller.config.Shrink = false
+
+	f.reconcile(t)
+	st := f.status(t).Shrink
+	if st == nil || st.Phase != "" || st.TargetSize != "1Gi" {
+		t.Fatalf("Expected the shrink only recommended without --shrink, got %+v", st)
+	}
+}
+
+func TestShrink_CopyWaitsWhileOtherPodsMountPVC(t *testing.T) {
+	f := newShrinkFixture(t, func(vs *v1alpha1.VolumeScaler) {}, 0)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default"},
+		Spec: corev1.PodSpec{Volumes: []corev1.Volume{{Name: "data", VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data"},
+		}}}},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	if _, err := f.clientset.CoreV1().Pods("default").Create(context.TODO(), pod, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create Pod: %v", err)
+	}
+
+	f.reconcile(t)
+	f.advanceShrinks(t)
+	if phase := f.shrinkPhase(t); phase != shrinkPhasePending {
+		t.Fatalf("Expected the copy to wait while pod backup mounts the PVC, got %s", phase)
+	}
+
+	if err := f.clientset.CoreV1().Pods("default").Delete(context.TODO(), "backup", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Failed to delete Pod: %v", err)
+	}
+	f.advanceShrinks(t)
+	if phase := f.shrinkPhase(t); phase != shrinkPhaseCopying {
+		t.Errorf("Expected the copy to start once no pod mounts the PVC, got %s", phase)
+	}
+}
+
+func TestShrink_MigrationPausesWhenSuspended(t *testing.T) {
+	f := newShrinkFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		vs.Spec.Suspend = true
+		vs.Status.Shrink = &v1alpha1.ShrinkStatus{Phase: shrinkPhaseScalingDown, Node: "node-1", SourcePVC: "data", TargetSize: "1Gi"}
+	}, 0)
+
+	f.advanceShrinks(t)
+	if phase := f.shrinkPhase(t); phase != shrinkPhaseScalingDown {
+		t.Errorf("Expected the migration to pause while suspended, got %s", phase)
+	}
+	jobs, err := f.clientset.BatchV1().Jobs("default").List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Failed to list Jobs: %v", err)
+	}
+	if len(jobs.Items) != 0 {
+		t.Errorf("Expected no copy Job while suspended, got %d", len(jobs.Items))
+	}
+}
+
+func TestShrink_RecommendModePublishesShrink(t *testing.T) {
+	f := newShrinkFixture(t, func(vs *v1alpha1.VolumeScaler) { vs.Spec.Mode = modeRecommend }, 0)
+
+	f.reconcile(t)
+	st := f.status(t).Shrink
+	if st == nil || st.Phase != "" || st.TargetSize != "1Gi" {
+		t.Fatalf("Expected a recommended shrink to 1Gi without a phase, got %+v", st)
+	}
+	if events := strings.Join(drainEvents(f.recorder), "\n"); !strings.Contains(events, eventReasonShrinkRecommended) {
+		t.Errorf("Expected a %s event, got %s", eventReasonShrinkRecommended, events)
+	}
+
+	f.advanceShrinks(t)
+	if phase := f.shrinkPhase(t); phase != "" {
+		t.Errorf("Expected Recommend mode not to migrate the PVC, got %s", phase)
+	}
+}
+
+func TestShrink_VolumeClaimTemplatesCannotBeSwapped(t *testing.T) {
+	f := newShrinkFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		vs.Spec.Shrink.Workload = v1alpha1.WorkloadReference{Kind: "StatefulSet", Name: "db"}
+	}, 1)
+	replicas := int32(1)
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default"},
+		Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+	}
+	if _, err := f.clientset.AppsV1().StatefulSets("default").Create(context.TODO(), sts, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create StatefulSet: %v", err)
+	}
+
+	f.reconcile(t)
+	f.advanceShrinks(t)
+	st := f.status(t).Shrink
+	if st.Phase != shrinkPhaseFailed || !strings.Contains(st.Message, "volumeClaimTemplates") {
+		t.Errorf("Expected the shrink to fail for a volumeClaimTemplates claim, got %+v", st)
+	}
+}
+
+func TestShrinkTargetGi(t *testing.T) {
+	policy := &v1alpha1.ShrinkPolicy{TargetUsage: "50%", MinSize: "10Gi"}
+	if got, _ := shrinkTargetGi(policy, 30); got != 60 {
+		t.Errorf("shrinkTargetGi(30Gi) = %v, want 60", got)
+	}
+	if got, _ := shrinkTargetGi(policy, 2); got != 10 {
+		t.Errorf("shrinkTargetGi(2Gi) = %v, want the 10Gi minSize", got)
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+const (
+	defaultSnapshotTimeout = 10 * time.Minute
+	defaultSnapshotMaxAge  = 30 * time.Minute
+	defaultSnapshotRetain  = 3
+
+	// labelSnapshotPVC marks the VolumeSnapshots taken before an expansion
+	// with the name of their PVC
+	labelSnapshotPVC = annotationPrefix + "pvc"
+
+	// Event reasons
+	eventReasonSnapshotCreated = "SnapshotCreated"
+	eventReasonSnapshotFailed  = "SnapshotFailed"
+	eventReasonSnapshotExpired = "SnapshotExpired"
+)
+
+// volumeSnapshotGVR is accessed through the dynamic client so the controller
+// doesn't depend on the external-snapshotter client.
+var volumeSnapshotGVR = schema.GroupVersionResource{Group: "snapshot.storage.k8s.io", Version: "v1", Resource: "volumesnapshots"}
+
+// snapshotTimeout returns how long to wait for a snapshot to become ready.
+func snapshotTimeout(policy *v1alpha1.SnapshotPolicy) (time.Duration, error) {
+	if policy.ReadyTimeout == "" {
+		return defaultSnapshotTimeout, nil
+	}
+	d, err := time.ParseDuration(policy.ReadyTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid snapshotBeforeResize.readyTimeout '%s': %v", policy.ReadyTimeout, err)
+	}
+	return d, nil
+}
+
+// snapshotMaxAge returns how long a ready snapshot stays usable as the
+// restore point of the next expansion.
+func snapshotMaxAge(policy *v1alpha1.SnapshotPolicy) (time.Duration, error) {
+	if policy.MaxAge == "" {
+		return defaultSnapshotMaxAge, nil
+	}
+	d, err := time.ParseDuration(policy.MaxAge)
+	if err != nil {
+		return 0, fmt.Errorf("invalid snapshotBeforeResize.maxAge '%s': %v", policy.MaxAge, err)
+	}
+	return d, nil
+}
+
+// snapshotTakenAt returns when the point-in-time snapshot was taken, or when
+// the VolumeSnapshot was created if the snapshot controller didn't say.
+func snapshotTakenAt(snapshot *unstructured.Unstructured) time.Time {
+	if s, _, _ := unstructured.NestedString(snapshot.Object, "status", "creationTime"); s != "" {
+		if t, err := time.Parse(time.RFC3339, s); err == nil {
+			return t
+		}
+	}
+	return snapshot.GetCreationTimestamp().Time
+}
+
+// retainSnapshots returns the snapshots to keep in the status once pending
+// joins them, and the older ones to delete.
+func retainSnapshots(vsObj *v1alpha1.VolumeScaler, pending string) (kept, pruned []string) {
+	retain := int(vsObj.Spec.SnapshotBeforeResize.Retain)
+	if retain <= 0 {
+		retain = defaultSnapshotRetain
+	}
+	all := append(append([]string{}, vsObj.Status.Snapshots...), pending)
+	if len(all) <= retain {
+		return all, nil
+	}
+	return all[len(all)-retain:], all[:len(all)-retain]
+}
+
+// ensurePreResizeSnapshot reports whether a ready VolumeSnapshot of the PVC
+// exists for the next expansion. The first call takes the snapshot; later
+// loops check it until it is readyToUse. A snapshot that fails or isn't ready
+// within readyTimeout is deleted and taken again, so the expansion never
+// proceeds without one. So is a ready snapshot older than maxAge, for example
+// one taken for a breach that ended or before a long wait for a resize slot.
+func (c *VolumeScalerController) ensurePreResizeSnapshot(ctx context.Context, invRef *corev1.ObjectReference, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, pvc *corev1.PersistentVolumeClaim) (bool, error) {
+	policy := vsObj.Spec.SnapshotBeforeResize
+	if policy == nil {
+		return true, nil
+	}
+	if c.dynamicClient == nil {
+		return false, fmt.Errorf("snapshotBeforeResize is set but no client for VolumeSnapshots is configured")
+	}
+	timeout, err := snapshotTimeout(policy)
+	if err != nil {
+		c.recorder.Eventf(invRef, corev1.EventTypeWarning, "InvalidSnapshotPolicy", "%v", err)
+		return false, err
+	}
+	maxAge, err := snapshotMaxAge(policy)
+	if err != nil {
+		c.recorder.Eventf(invRef, corev1.EventTypeWarning, "InvalidSnapshotPolicy", "%v", err)
+		return false, err
+	}
+	snapshots := c.dynamicClient.Resource(volumeSnapshotGVR).Namespace(vsName.Namespace)
+
+	pending := vsObj.Status.PendingSnapshot
+	if pending == "" {
+		name := fmt.Sprintf("%s-pre-resize-%d", pvc.Name, time.Now().Unix())
+		snapshot := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "snapshot.storage.k8s.io/v1",
+			"kind":       "VolumeSnapshot",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": vsName.Namespace,
+				"labels":    map[string]interface{}{labelSnapshotPVC: pvc.Name},
+			},
+			"spec": map[string]interface{}{
+				"volumeSnapshotClassName": policy.VolumeSnapshotClassName,
+				"source":                  map[string]interface{}{"persistentVolumeClaimName": pvc.Name},
+			},
+		}}
+		if _, err := snapshots.Create(ctx, snapshot, metav1.CreateOptions{}); err != nil {
+			c.recorder.Eventf(invRef, corev1.EventTypeWarning, eventReasonSnapshotFailed,
+				"Failed creating VolumeSnapshot of PVC '%s/%s' before expanding it: %v", vsName.Namespace, pvc.Name, err)
+			return false, fmt.Errorf("creating VolumeSnapshot: %v", err)
+		}
+		msg := fmt.Sprintf("Taking VolumeSnapshot '%s' of PVC '%s/%s' before expanding it", name, vsName.Namespace, pvc.Name)
+		c.recorder.Event(invRef, corev1.EventTypeNormal, eventReasonSnapshotCreated, msg)
+		fmt.Printf("[INFO] %s\n", msg)
+		return false, c.patchPendingSnapshot(ctx, vsName, vsObj, name)
+	}
+
+	snapshot, err := snapshots.Get(ctx, pending, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		fmt.Printf("[WARN] VolumeSnapshot '%s/%s' disappeared; taking a new one\n", vsName.Namespace, pending)
+		return false, c.patchPendingSnapshot(ctx, vsName, vsObj, "")
+	}
+	if err != nil {
+		return false, fmt.Errorf("fetching VolumeSnapshot '%s': %v", pending, err)
+	}
+	if ready, _, _ := unstructured.NestedBool(snapshot.Object, "status", "readyToUse"); ready {
+		taken := snapshotTakenAt(snapshot)
+		if taken.IsZero() || time.Since(taken) <= maxAge {
+			return true, nil
+		}
+		msg := fmt.Sprintf("VolumeSnapshot '%s' of PVC '%s/%s' was taken more than %s ago; taking a new one before expanding",
+			pending, vsName.Namespace, pvc.Name, maxAge)
+		c.recorder.Event(invRef, corev1.EventTypeNormal, eventReasonSnapshotExpired, msg)
+		fmt.Printf("[INFO] %s\n", msg)
+		return false, c.discardPendingSnapshot(ctx, vsName, vsObj)
+	}
+
+	var failure string
+	created := snapshot.GetCreationTimestamp()
+	if msg, _, _ := unstructured.NestedString(snapshot.Object, "status", "error", "message"); msg != "" {
+		failure = "failed: " + msg
+	} else if !created.IsZero() && time.Since(created.Time) > timeout {
+		failure = fmt.Sprintf("was not ready to use within %s", timeout)
+	} else {
+		fmt.Printf("[INFO] Waiting for VolumeSnapshot '%s/%s' before expanding PVC '%s'\n", vsName.Namespace, pending, pvc.Name)
+		return false, nil
+	}
+
+	msg := fmt.Sprintf("Expansion of PVC '%s/%s' is on hold: VolumeSnapshot '%s' %s; taking a new one",
+		vsName.Namespace, pvc.Name, pending, failure)
+	c.recorder.Event(invRef, corev1.EventTypeWarning, eventReasonSnapshotFailed, msg)
+	fmt.Printf("[WARNING] %s\n", msg)
+	return false, c.discardPendingSnapshot(ctx, vsName, vsObj)
+}
+
+// discardPendingSnapshot deletes the snapshot in status.pendingSnapshot and
+// clears it, so the next expansion takes a new one.
+func (c *VolumeScalerController) discardPendingSnapshot(ctx context.Context, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler) error {
+	pending := vsObj.Status.PendingSnapshot
+	if pending == "" || c.dynamicClient == nil {
+		return nil
+	}
+	err := c.dynamicClient.Resource(volumeSnapshotGVR).Namespace(vsName.Namespace).Delete(ctx, pending, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("deleting VolumeSnapshot '%s': %v", pending, err)
+	}
+	return c.patchPendingSnapshot(ctx, vsName, vsObj, "")
+}
+
+// pruneSnapshots deletes the pre-expansion VolumeSnapshots that fell out of
+// the retained set.
+func (c *VolumeScalerController) pruneSnapshots(ctx context.Context, namespace string, pruned []string) {
+	for _, name := range pruned {
+		err := c.dynamicClient.Resource(volumeSnapshotGVR).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			fmt.Printf("[WARN] deleting VolumeSnapshot '%s/%s': %v\n", namespace, name, err)
+		}
+	}
+}
+
+// patchPendingSnapshot records the snapshot being taken, or clears it.
+func (c *VolumeScalerController) patchPendingSnapshot(ctx context.Context, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, name string) error {
+	var value interface{}
+	if name != "" {
+		value = name
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{"pendingSnapshot": value},
+	})
+	if err != nil {
+		return fmt.Errorf("encoding pendingSnapshot patch: %v", err)
+	}
+	_, err = c.vsClient.AutoscalingV1alpha1().VolumeScalers(vsName.Namespace).
+		Patch(ctx, vsName.Name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+	if err != nil {
+		return fmt.Errorf("patching pendingSnapshot: %v", err)
+	}
+	vsObj.Status.PendingSnapshot = name
+	return nil
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+// withSnapshots enables snapshotBeforeResize and backs the fixture with a
+// fake client holding snapshots.
+func (f *scalerFixture) withSnapshots(snapshots ...runtime.Object) {
+	f.controller.dynamicClient = dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), snapshots...)
+}
+
+func (f *scalerFixture) snapshot(t *testing.T, name string) *unstructured.Unstructured {
+	t.Helper()
+	snapshot, err := f.controller.dynamicClient.Resource(volumeSnapshotGVR).Namespace("default").Get(context.TODO(), name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatalf("Failed to get VolumeSnapshot: %v", err)
+	}
+	return snapshot
+}
+
+// markSnapshotReady sets readyToUse on the stored snapshot, as the snapshot
+// controller would.
+func (f *scalerFixture) markSnapshotReady(t *testing.T, name string) {
+	t.Helper()
+	snapshot := f.snapshot(t, name)
+	if err := unstructured.SetNestedField(snapshot.Object, true, "status", "readyToUse"); err != nil {
+		t.Fatalf("Failed to set readyToUse: %v", err)
+	}
+	if _, err := f.controller.dynamicClient.Resource(volumeSnapshotGVR).Namespace("default").Update(context.TODO(), snapshot, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to update VolumeSnapshot: %v", err)
+	}
+}
+
+// volumeSnapshot builds a stored snapshot of the fixture PVC with status.
+func volumeSnapshot(name string, created time.Time, status map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "snapshot.storage.k8s.io/v1",
+		"kind":       "VolumeSnapshot",
+		"metadata": map[string]interface{}{
+			"name": name, "namespace": "default", "creationTimestamp": created.UTC().Format(time.RFC3339),
+		},
+		"spec":   map[string]interface{}{"source": map[string]interface{}{"persistentVolumeClaimName": "data"}},
+		"status": status,
+	}}
+}
+
+func snapshotPolicy(mutate func(*v1alpha1.VolumeScaler)) func(*v1alpha1.VolumeScaler) {
+	return func(vs *v1alpha1.VolumeScaler) {
+		vs.Spec.SnapshotBeforeResize = &v1alpha1.SnapshotPolicy{VolumeSnapshotClassName: "csi-snapclass", ReadyTimeout: "10m"}
+		mutate(vs)
+	}
+}
+
+func TestSnapshot_TakenBeforeExpansion(t *testing.T) {
+	f := newScalerFixture(t, snapshotPolicy(func(vs *v1alpha1.VolumeScaler) {}))
+	f.withSnapshots()
+
+	f.reconcile(t)
+	if got := f.pvcSize(t); got != "5Gi" {
+		t.Fatalf("PVC size = %s, want 5Gi until the snapshot is ready", got)
+	}
+	pending := f.status(t).PendingSnapshot
+	snapshot := f.snapshot(t, pending)
+	if snapshot == nil {
+		t.Fatalf("no VolumeSnapshot '%s' was created", pending)
+	}
+	if class, _, _ := unstructured.NestedString(snapshot.Object, "spec", "volumeSnapshotClassName"); class != "csi-snapclass" {
+		t.Errorf("volumeSnapshotClassName = %q, want csi-snapclass", class)
+	}
+	if source, _, _ := unstructured.NestedString(snapshot.Object, "spec", "source", "persistentVolumeClaimName"); source != "data" {
+		t.Errorf("source PVC = %q, want data", source)
+	}
+	if events := strings.Join(drainEvents(f.recorder), "\n"); !strings.Contains(events, eventReasonSnapshotCreated) {
+		t.Errorf("events = %q, want %s", events, eventReasonSnapshotCreated)
+	}
+
+	f.reconcile(t)
+	if got := f.pvcSize(t); got != "5Gi" {
+		t.Fatalf("PVC size = %s, want 5Gi while the snapshot is not ready", got)
+	}
+
+	f.markSnapshotReady(t, pending)
+	f.reconcile(t)
+	if got := f.pvcSize(t); got != "7Gi" {
+		t.Fatalf("PVC size = %s, want 7Gi once the snapshot is ready", got)
+	}
+	st := f.status(t)
+	if st.PendingSnapshot != "" || len(st.Snapshots) != 1 || st.Snapshots[0] != pending {
+		t.Errorf("pendingSnapshot = %q, snapshots = %v; want [%s] recorded", st.PendingSnapshot, st.Snapshots, pending)
+	}
+}
+
+func TestSnapshot_FailedSnapshotIsRetaken(t *testing.T) {
+	f := newScalerFixture(t, snapshotPolicy(func(vs *v1alpha1.VolumeScaler) {
+		vs.Status.PendingSnapshot = "data-pre-resize-1"
+	}))
+	f.withSnapshots(volumeSnapshot("data-pre-resize-1", time.Now(), map[string]interface{}{
+		"readyToUse": false, "error": map[string]interface{}{"message": "quota exceeded"},
+	}))
+
+	f.reconcile(t)
+	if got := f.pvcSize(t); got != "5Gi" {
+		t.Fatalf("PVC size = %s, want 5Gi after a failed snapshot", got)
+	}
+	if f.snapshot(t, "data-pre-resize-1") != nil {
+		t.Error("failed VolumeSnapshot was not deleted")
+	}
+	if got := f.status(t).PendingSnapshot; got != "" {
+		t.Errorf("pendingSnapshot = %q, want it cleared so a new one is taken", got)
+	}
+	events := strings.Join(drainEvents(f.recorder), "\n")
+	if !strings.Contains(events, eventReasonSnapshotFailed) || !strings.Contains(events, "quota exceeded") {
+		t.Errorf("events = %q, want %s naming the error", events, eventReasonSnapshotFailed)
+	}
+}
+
+func TestSnapshot_ReadyTimeout(t *testing.T) {
+	f := newScalerFixture(t, snapshotPolicy(func(vs *v1alpha1.VolumeScaler) {
+		vs.Status.PendingSnapshot = "data-pre-resize-1"
+	}))
+	f.withSnapshots(volumeSnapshot("data-pre-resize-1", time.Now().Add(-20*time.Minute), map[string]interface{}{"readyToUse": false}))
+
+	f.reconcile(t)
+	if got := f.pvcSize(t); got != "5Gi" {
+		t.Fatalf("PVC size = %s, want 5Gi after the snapshot timed out", got)
+	}
+	if f.snapshot(t, "data-pre-resize-1") != nil {
+		t.Error("timed out VolumeSnapshot was not deleted")
+	}
+	if events := strings.Join(drainEvents(f.recorder), "\n"); !strings.Contains(events, "within 10m") {
+		t.Errorf("events = %q, want the timeout reported", events)
+	}
+}
+
+func TestSnapshot_StaleReadySnapshotIsRetaken(t *testing.T) {
+	f := newScalerFixture(t, snapshotPolicy(func(vs *v1alpha1.VolumeScaler) {
+		vs.Status.PendingSnapshot = "data-pre-resize-1"
+	}))
+	taken := time.Now().Add(-2 * time.Hour)
+	f.withSnapshots(volumeSnapshot("data-pre-resize-1", taken, map[string]interface{}{
+		"readyToUse": true, "creationTime": taken.UTC().Format(time.RFC3339),
+	}))
+
+	f.reconcile(t)
+	if got := f.pvcSize(t); got != "5Gi" {
+		t.Fatalf("PVC size = %s, want 5Gi with a snapshot older than maxAge", got)
+	}
+	if f.snapshot(t, "data-pre-resize-1") != nil {
+		t.Error("stale VolumeSnapshot was not deleted")
+	}
+	if events := strings.Join(drainEvents(f.recorder), "\n"); !strings.Contains(events, eventReasonSnapshotExpired) {
+		t.Errorf("events = %q, want %s", events, eventReasonSnapshotExpired)
+	}
+}
+
+func TestSnapshot_PendingClearedBelowThreshold(t *testing.T) {
+	f := newScalerFixture(t, snapshotPolicy(func(vs *v1alpha1.VolumeScaler) {
+		vs.Status.PendingSnapshot = "data-pre-resize-1"
+	}))
+	f.withSnapshots(volumeSnapshot("data-pre-resize-1", time.Now(), map[string]interface{}{"readyToUse": true}))
+	f.setUsedGi(1)
+
+	f.reconcile(t)
+	if got := f.status(t).PendingSnapshot; got != "" {
+		t.Errorf("pendingSnapshot = %q, want it cleared once usage dropped below the threshold", got)
+	}
+	if f.snapshot(t, "data-pre-resize-1") != nil {
+		t.Error("unused VolumeSnapshot was not deleted")
+	}
+}
+
+func TestSnapshot_KeepsLastN(t *testing.T) {
+	f := newScalerFixture(t, snapshotPolicy(func(vs *v1alpha1.VolumeScaler) {
+		vs.Spec.SnapshotBeforeResize.Retain = 2
+		vs.Status.Snapshots = []string{"data-pre-resize-1", "data-pre-resize-2"}
+		vs.Status.PendingSnapshot = "data-pre-resize-3"
+	}))
+	f.withSnapshots(
+		volumeSnapshot("data-pre-resize-1", time.Now().Add(-48*time.Hour), map[string]interface{}{"readyToUse": true}),
+		volumeSnapshot("data-pre-resize-2", time.Now().Add(-24*time.Hour), map[string]interface{}{"readyToUse": true}),
+		volumeSnapshot("data-pre-resize-3", time.Now(), map[string]interface{}{"readyToUse": true}),
+	)
+
+	f.reconcile(t)
+	if got := f.pvcSize(t); got != "7Gi" {
+		t.Fatalf("PVC size = %s, want 7Gi", got)
+	}
+	if got := strings.Join(f.status(t).Snapshots, ","); got != "data-pre-resize-2,data-pre-resize-3" {
+		t.Errorf("snapshots = %s, want the last two", got)
+	}
+	if f.snapshot(t, "data-pre-resize-1") != nil {
+		t.Error("VolumeSnapshot beyond retain was not deleted")
+	}
+	if f.snapshot(t, "data-pre-resize-2") == nil {
+		t.Error("retained VolumeSnapshot was deleted")
+	}
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+const (
+	// Spend budget actions
+	spendActionBlock           = "Block"
+	spendActionRequireApproval = "RequireApproval"
+
+	conditionTypeBudgetExceeded = "BudgetExceeded"
+
+	// Event reasons
+	eventReasonBudgetExceeded = "BudgetExceeded"
+)
+
+// mergeSpendBudget folds a VolumeScalerLimit's spend budget into the
+// effective limits; the lowest ceilings win.
+func (eff *effectiveLimits) mergeSpendBudget(b *v1alpha1.SpendBudget, from string) error {
+	action := b.Action
+	switch action {
+	case "":
+		action = spendActionBlock
+	case spendActionBlock, spendActionRequireApproval:
+	default:
+		return fmt.Errorf("VolumeScalerLimit '%s' spendBudget.action '%s' must be '%s' or '%s'",
+			from, b.Action, spendActionBlock, spendActionRequireApproval)
+	}
+	parse := func(field, value string) (float64, error) {
+		v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || v <= 0 {
+			return 0, fmt.Errorf("VolumeScalerLimit '%s' spendBudget.%s '%s' is not a positive amount", from, field, value)
+		}
+		return v, nil
+	}
+	if b.NamespaceMonthlyLimit != "" {
+		v, err := parse("namespaceMonthlyLimit", b.NamespaceMonthlyLimit)
+		if err != nil {
+			return err
+		}
+		if eff.NamespaceSpendLimit == 0 || v < eff.NamespaceSpendLimit {
+			eff.NamespaceSpendLimit, eff.NamespaceSpendAction, eff.NamespaceSpendFrom = v, action, from
+		}
+	}
+	if b.ClusterMonthlyLimit != "" {
+		v, err := parse("clusterMonthlyLimit", b.ClusterMonthlyLimit)
+		if err != nil {
+			return err
+		}
+		if eff.ClusterSpendLimit == 0 || v < eff.ClusterSpendLimit {
+			eff.ClusterSpendLimit, eff.ClusterSpendAction, eff.ClusterSpendFrom = v, action, from
+		}
+	}
+	return nil
+}
+
+// formatSpend formats a monthly amount with the price table's currency.
+func (c *VolumeScalerController) formatSpend(v float64) string {
+	if c.prices == nil || c.prices.currency == "" {
+		return formatMoney(v)
+	}
+	return formatMoney(v) + " " + c.prices.currency
+}
+
+// managedMonthlySpend sums the monthly cost of every PVC targeted by a
+// VolumeScaler in namespace, or in the whole cluster for
+// metav1.NamespaceAll. PVCs without a price count as free.
+func (c *VolumeScalerController) managedMonthlySpend(ctx context.Context, namespace string) (float64, error) {
+	vsList, err := c.vsClient.AutoscalingV1alpha1().VolumeScalers(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("listing VolumeScalers: %v", err)
+	}
+	managed := make(map[string]bool)
+	for _, vs := range vsList.Items {
+		managed[vs.Namespace+"/"+vs.Spec.PVCName] = true
+	}
+
+	pvcList, err := c.clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("listing PVCs: %v", err)
+	}
+	classPrices := make(map[string]float64)
+	total := 0.0
+	for i := range pvcList.Items {
+		pvc := &pvcList.Items[i]
+		if !managed[pvc.Namespace+"/"+pvc.Name] {
+			continue
+		}
+		className := ""
+		if pvc.Spec.StorageClassName != nil {
+			className = *pvc.Spec.StorageClassName
+		}
+		price, ok := classPrices[className]
+		if !ok {
+			price, _ = c.priceForClass(ctx, className)
+			classPrices[className] = price
+		}
+		sizeGi, err := convertToGi(pvc.Spec.Resources.Requests.Storage().String())
+		if err != nil {
+			continue
+		}
+		total += sizeGi * price
+	}
+	return total, nil
+}
+
+// applySpendBudget checks the projected monthly spend of growing pvc from
+// currentGi to newSizeGi against the spend ceilings of its VolumeScalerLimits
+// and keeps the BudgetExceeded condition in step. It reports whether a Block
+// ceiling is exceeded, in which case newSizeGi is returned as currentGi along
+// with the exceeded ceilings. Exceeding a RequireApproval ceiling sets
+// limits.SpendApproval so approvalReason asks for approval.
+func (c *VolumeScalerController) applySpendBudget(ctx context.Context, invRef *corev1.ObjectReference, vsName types.NamespacedName, vsObj *v1alpha1.VolumeScaler, pvc *corev1.PersistentVolumeClaim, limits *effectiveLimits, currentGi, newSizeGi float64) (float64, []string, bool, error) {
+	if limits.NamespaceSpendLimit == 0 && limits.ClusterSpendLimit == 0 {
+		return newSizeGi, nil, false, c.clearCondition(ctx, vsName, vsObj, conditionTypeBudgetExceeded)
+	}
+	if newSizeGi <= currentGi {
+		return newSizeGi, nil, false, nil
+	}
+	price, ok := c.priceFor(ctx, pvc)
+	if !ok {
+		fmt.Printf("[WARN] PVC '%s/%s' has no price in the price table; spend budgets are not applied.\n", pvc.Namespace, pvc.Name)
+		return newSizeGi, nil, false, nil
+	}
+	added := (newSizeGi - currentGi) * price
+
+	var blocked, approval []string
+	check := func(scope, namespace string, limit float64, action, from string) error {
+		if limit == 0 {
+			return nil
+		}
+		spend, err := c.managedMonthlySpend(ctx, namespace)
+		if err != nil {
+			return err
+		}
+		if spend+added <= limit {
+			return nil
+		}
+		msg := fmt.Sprintf("projected %s spend %s/month exceeds the limit of %s (VolumeScalerLimit '%s'): %s now, expansion adds %s",
+			scope, c.formatSpend(spend+added), c.formatSpend(limit), from, c.formatSpend(spend), c.formatSpend(added))
+		if action == spendActionRequireApproval {
+			approval = append(approval, msg)
+		} else {
+			blocked = append(blocked, msg)
+		}
+		return nil
+	}
+	if err := check("namespace", vsName.Namespace, limits.NamespaceSpendLimit, limits.NamespaceSpendAction, limits.NamespaceSpendFrom); err != nil {
+		return 0, nil, false, err
+	}
+	if err := check("cluster", metav1.NamespaceAll, limits.ClusterSpendLimit, limits.ClusterSpendAction, limits.ClusterSpendFrom); err != nil {
+		return 0, nil, false, err
+	}
+
+	if len(blocked) == 0 && len(approval) == 0 {
+		return newSizeGi, nil, false, c.clearCondition(ctx, vsName, vsObj, conditionTypeBudgetExceeded)
+	}
+	reason, outcome, exceeded := "ApprovalRequired", "needs approval", approval
+	if len(blocked) > 0 {
+		reason, outcome, exceeded = "Blocked", "is blocked", append(blocked, approval...)
+	}
+	msg := strings.Join(exceeded, "; ")
+	if cond := meta.FindStatusCondition(vsObj.Status.Conditions, conditionTypeBudgetExceeded); cond == nil || cond.Reason != reason {
+		c.recorder.Eventf(invRef, corev1.EventTypeWarning, eventReasonBudgetExceeded,
+			"Expansion of PVC '%s/%s' from %.0fGi -> %.0fGi %s: %s", vsName.Namespace, pvc.Name, currentGi, newSizeGi, outcome, msg)
+	}
+	err := c.setCondition(ctx, vsName, vsObj, metav1.Condition{
+		Type:    conditionTypeBudgetExceeded,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: msg,
+	})
+	if len(blocked) > 0 {
+		return currentGi, exceeded, true, err
+	}
+	limits.SpendApproval = msg
+	return newSizeGi, nil, false, err
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+func spendLimit(budget *v1alpha1.SpendBudget) *v1alpha1.VolumeScalerLimit {
+	return &v1alpha1.VolumeScalerLimit{
+		ObjectMeta: metav1.ObjectMeta{Name: "finance"},
+		Spec:       v1alpha1.VolumeScalerLimitSpec{SpendBudget: budget},
+	}
+}
+
+func TestSpendBudget_BlocksOverNamespaceLimit(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {},
+		spendLimit(&v1alpha1.SpendBudget{NamespaceMonthlyLimit: "0.60"}))
+	f.withPrices(t, map[string]string{"default": "0.10", "currency": "USD"})
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "5Gi" {
+		t.Fatalf("Expected the spend budget to block the expansion, got %s", size)
+	}
+	cond := meta.FindStatusCondition(f.status(t).Conditions, conditionTypeBudgetExceeded)
+	if cond == nil || cond.Reason != "Blocked" ||
+		!strings.Contains(cond.Message, "projected namespace spend 0.70 USD/month exceeds the limit of 0.60 USD") ||
+		!strings.Contains(cond.Message, "0.50 USD now") {
+		t.Errorf("Expected a Blocked %s condition with spend and limit, got %+v", conditionTypeBudgetExceeded, cond)
+	}
+	if events := drainEvents(f.recorder); !strings.Contains(strings.Join(events, "\n"), eventReasonBudgetExceeded) {
+		t.Errorf("Expected a %s event, got %v", eventReasonBudgetExceeded, events)
+	}
+}
+
+func TestSpendBudget_WithinLimitClearsCondition(t *testing.T) {
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {
+		vs.Status.Conditions = []metav1.Condition{{Type: conditionTypeBudgetExceeded, Status: metav1.ConditionTrue, Reason: "Blocked"}}
+	}, spendLimit(&v1alpha1.SpendBudget{NamespaceMonthlyLimit: "1"}))
+	f.withPrices(t, map[string]string{"default": "0.10"})
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "7Gi" {
+		t.Fatalf("Expected the expansion within budget, got %s", size)
+	}
+	if cond := meta.FindStatusCondition(f.status(t).Conditions, conditionTypeBudgetExceeded); cond != nil {
+		t.Errorf("Expected the condition to be cleared, got %+v", cond)
+	}
+}
+
+func TestSpendBudget_ClusterLimitRequiresApproval(t *testing.T) {
+	other := managedBy("logs", "100Gi")
+	other.Namespace = "other"
+	f := newScalerFixture(t, func(vs *v1alpha1.VolumeScaler) {},
+		other, spendLimit(&v1alpha1.SpendBudget{ClusterMonthlyLimit: "1.60", Action: spendActionRequireApproval}))
+	pvc := sizedPVC("logs", "10Gi", nil)
+	pvc.Namespace = "other"
+	if _, err := f.clientset.CoreV1().PersistentVolumeClaims("other").Create(context.TODO(), pvc, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create PVC: %v", err)
+	}
+	f.withPrices(t, map[string]string{"default": "0.10"})
+
+	f.reconcile(t)
+	if size := f.pvcSize(t); size != "5Gi" {
+		t.Fatalf("Expected the expansion to wait for approval, got %s", size)
+	}
+	reqs := f.requests(t)
+	if len(reqs) != 1 || !strings.Contains(reqs[0].Spec.Reason, "projected cluster spend 1.70/month exceeds the limit of 1.60") {
+		t.Fatalf("Expected a VolumeScaleRequest citing the cluster spend, got %+v", reqs)
+	}
+	st := f.status(t)
+	if cond := meta.FindStatusCondition(st.Conditions, conditionTypeBudgetExceeded); cond == nil || cond.Reason != "ApprovalRequired" {
+		t.Errorf("Expected an ApprovalRequired %s condition, got %+v", conditionTypeBudgetExceeded, cond)
+	}
+	if cond := meta.FindStatusCondition(st.Conditions, conditionTypeApprovalRequired); cond == nil {
+		t.Errorf("Expected the %s condition", conditionTypeApprovalRequired)
+	}
+}
+
+func TestMergeSpendBudget(t *testing.T) {
+	limits, err := mergeLimits([]v1alpha1.VolumeScalerLimit{
+		*spendLimit(&v1alpha1.SpendBudget{NamespaceMonthlyLimit: "500", ClusterMonthlyLimit: "20000"}),
+		{ObjectMeta: metav1.ObjectMeta{Name: "team"}, Spec: v1alpha1.VolumeScalerLimitSpec{
+			SpendBudget: &v1alpha1.SpendBudget{NamespaceMonthlyLimit: "200", Action: spendActionRequireApproval},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("mergeLimits() error = %v", err)
+	}
+	if limits.NamespaceSpendLimit != 200 || limits.NamespaceSpendAction != spendActionRequireApproval || limits.NamespaceSpendFrom != "team" ||
+		limits.ClusterSpendLimit != 20000 || limits.ClusterSpendAction != spendActionBlock {
+		t.Errorf("Expected the lowest ceilings with their actions, got %+v", limits)
+	}
+
+	for _, b := range []*v1alpha1.SpendBudget{{NamespaceMonthlyLimit: "lots"}, {ClusterMonthlyLimit: "0"}, {Action: "Warn"}} {
+		if _, err := mergeLimits([]v1alpha1.VolumeScalerLimit{*spendLimit(b)}); err == nil {
+			t.Errorf("mergeLimits(%+v) expected an error", b)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+const (
+	// defaultCooldownPeriod is applied by the defaulting webhook when a
+	// VolumeScaler does not set cooldownPeriod
+	defaultCooldownPeriod = "10m"
+
+	minThresholdPercent = 1
+	maxThresholdPercent = 99
+)
+
+// inferScaleType derives the scale type from the scale value: "30%" is a
+// percentage increase, anything else a fixed size.
+func inferScaleType(scale string) string {
+	if strings.HasSuffix(strings.TrimSpace(scale), "%") {
+		return scaleTypePercentage
+	}
+	return scaleTypeFixed
+}
+
+// normalizeScaleType maps legacy and differently-cased scale types onto the
+// canonical "fixed" and "percentage" values. Unknown values are returned unchanged.
+func normalizeScaleType(scaleType string) string {
+	switch strings.ToLower(strings.TrimSpace(scaleType)) {
+	case scaleTypeFixed:
+		return scaleTypeFixed
+	case scaleTypePercentage, strings.ToLower(scaleTypeLegacyPercentage):
+		return scaleTypePercentage
+	default:
+		return scaleType
+	}
+}
+
+// defaultSpec fills in missing fields and normalizes legacy values in place.
+// It reports whether anything changed.
+func defaultSpec(spec *v1alpha1.VolumeScalerSpec) bool {
+	changed := false
+	if spec.CooldownPeriod == "" {
+		spec.CooldownPeriod = defaultCooldownPeriod
+		changed = true
+	}
+	scaleType := spec.ScaleType
+	if scaleType == "" {
+		scaleType = inferScaleType(spec.Scale)
+	} else {
+		scaleType = normalizeScaleType(scaleType)
+	}
+	if scaleType != spec.ScaleType {
+		spec.ScaleType = scaleType
+		changed = true
+	}
+	if spec.Threshold != "" && !strings.HasSuffix(spec.Threshold, "%") {
+		spec.Threshold += "%"
+		changed = true
+	}
+	return changed
+}
+
+// validateShrink checks a shrink policy. The usage after a shrink has to sit
+// between belowUsage and the threshold, or the PVC would be shrunk or expanded
+// again right away.
+func validateShrink(p *v1alpha1.ShrinkPolicy, threshold float64) []string {
+	var errs []string
+	below, err := Percentage(p.BelowUsage).ToFloat()
+	if err != nil || below <= 0 || (threshold > 0 && below >= threshold) {
+		errs = append(errs, fmt.Sprintf("spec.shrink.belowUsage '%s' must be a percentage between 0 and spec.threshold", p.BelowUsage))
+	}
+	if d, err := time.ParseDuration(p.SustainedFor); err != nil || d <= 0 {
+		errs = append(errs, fmt.Sprintf("spec.shrink.sustainedFor '%s' must be a positive duration", p.SustainedFor))
+	}
+	if p.TargetUsage != "" {
+		target, err := Percentage(p.TargetUsage).ToFloat()
+		if err != nil || target <= below || (threshold > 0 && target >= threshold) {
+			errs = append(errs, fmt.Sprintf("spec.shrink.targetUsage '%s' must be a percentage between spec.shrink.belowUsage and spec.threshold", p.TargetUsage))
+		}
+	}
+	if p.MinSize != "" {
+		if v, err := convertToGi(p.MinSize); err != nil || v <= 0 {
+			errs = append(errs, fmt.Sprintf("spec.shrink.minSize '%s' must be a positive size", p.MinSize))
+		}
+	}
+	if p.Workload.Kind != "Deployment" && p.Workload.Kind != "StatefulSet" {
+		errs = append(errs, fmt.Sprintf("spec.shrink.workload.kind '%s' must be Deployment or StatefulSet", p.Workload.Kind))
+	}
+	if p.Workload.Name == "" {
+		errs = append(errs, "spec.shrink.workload.name is required")
+	}
+	if w := p.MaintenanceWindow; w != nil {
+		if _, err := parseCron(w.Start); err != nil {
+			errs = append(errs, fmt.Sprintf("spec.shrink.maintenanceWindow.start '%s' is not a cron expression: %v", w.Start, err))
+		}
+		if d, err := time.ParseDuration(w.Duration); err != nil || d <= 0 {
+			errs = append(errs, fmt.Sprintf("spec.shrink.maintenanceWindow.duration '%s' must be a positive duration", w.Duration))
+		}
+	}
+	return errs
+}
+
+// validateSpec checks a VolumeScaler spec for values the controller would
+// otherwise only reject at reconcile time. currentSizeGi is the PVC's
+// requested size, or 0 when the PVC is unknown.
+func validateSpec(spec *v1alpha1.VolumeScalerSpec, currentSizeGi float64) []string {
+	var errs []string
+
+	if spec.PVCName == "" {
+		errs = append(errs, "spec.pvcName is required")
+	}
+
+	threshold, err := Percentage(spec.Threshold).ToFloat()
+	switch {
+	case err != nil:
+		errs = append(errs, fmt.Sprintf("spec.threshold '%s' is not a percentage", spec.Threshold))
+	case threshold < minThresholdPercent || threshold > maxThresholdPercent:
+		errs = append(errs, fmt.Sprintf("spec.threshold '%s' must be between %d%% and %d%%",
+			spec.Threshold, minThresholdPercent, maxThresholdPercent))
+	}
+
+	switch spec.ScaleType {
+	case scaleTypeFixed:
+		if inc, err := convertToGi(spec.Scale); err != nil || inc <= 0 {
+			errs = append(errs, fmt.Sprintf("spec.scale '%s' must be a positive size such as '2Gi' for scaleType 'fixed'", spec.Scale))
+		}
+	case scaleTypePercentage, scaleTypeLegacyPercentage:
+		if pct, err := Percentage(spec.Scale).ToFloat(); err != nil || pct <= 0 {
+			errs = append(errs, fmt.Sprintf("spec.scale '%s' must be a positive percentage such as '30%%' for scaleType 'percentage'", spec.Scale))
+		}
+	default:
+		errs = append(errs, fmt.Sprintf("spec.scaleType '%s' is not supported; use 'fixed' or 'percentage'", spec.ScaleType))
+	}
+
+	maxSizeGi, err := convertToGi(spec.MaxSize)
+	if err != nil {
+		errs = append(errs, fmt.Sprintf("spec.maxSize '%s' is not a valid size: %v", spec.MaxSize, err))
+	} else if currentSizeGi > 0 && maxSizeGi < currentSizeGi {
+		errs = append(errs, fmt.Sprintf("spec.maxSize '%s' is below the PVC's current size of %.0fGi", spec.MaxSize, currentSizeGi))
+	}
+
+	if _, err := parseCooldownDuration(spec.CooldownPeriod); err != nil {
+		errs = append(errs, fmt.Sprintf("spec.cooldownPeriod '%s' is not a valid duration", spec.CooldownPeriod))
+	}
+
+	switch spec.Mode {
+	case "", modeObserve, modeRecommend, modeEnforce:
+	default:
+		errs = append(errs, fmt.Sprintf("spec.mode '%s' is not supported; use 'Observe', 'Recommend' or 'Enforce'", spec.Mode))
+	}
+
+	if spec.ApprovalPolicy != nil {
+		if _, _, _, err := parseApprovalPolicy(spec.ApprovalPolicy); err != nil {
+			errs = append(errs, "spec."+err.Error())
+		}
+	}
+
+	for i, s := range spec.Schedules {
+		if _, err := parseCron(s.Schedule); err != nil {
+			errs = append(errs, fmt.Sprintf("spec.schedules[%d].schedule '%s' is not a cron expression: %v", i, s.Schedule, err))
+		}
+		if minGi, err := convertToGi(s.MinSize); err != nil || minGi <= 0 {
+			errs = append(errs, fmt.Sprintf("spec.schedules[%d].minSize '%s' must be a positive size", i, s.MinSize))
+		} else if maxSizeGi > 0 && minGi > maxSizeGi {
+			errs = append(errs, fmt.Sprintf("spec.schedules[%d].minSize '%s' exceeds spec.maxSize '%s'", i, s.MinSize, spec.MaxSize))
+		}
+	}
+	for i, w := range spec.BlackoutWindows {
+		if _, err := parseCron(w.Start); err != nil {
+			errs = append(errs, fmt.Sprintf("spec.blackoutWindows[%d].start '%s' is not a cron expression: %v", i, w.Start, err))
+		}
+		if d, err := time.ParseDuration(w.Duration); err != nil || d <= 0 {
+			errs = append(errs, fmt.Sprintf("spec.blackoutWindows[%d].duration '%s' must be a positive duration", i, w.Duration))
+		}
+	}
+	if spec.CriticalThreshold != "" {
+		critical, err := Percentage(spec.CriticalThreshold).ToFloat()
+		if err != nil || critical > 100 || (threshold > 0 && critical <= threshold) {
+			errs = append(errs, fmt.Sprintf("spec.criticalThreshold '%s' must be a percentage above spec.threshold and at most 100%%", spec.CriticalThreshold))
+		}
+	}
+	if spec.BreachDuration != "" {
+		if d, err := time.ParseDuration(spec.BreachDuration); err != nil || d < 0 {
+			errs = append(errs, fmt.Sprintf("spec.breachDuration '%s' is not a valid duration", spec.BreachDuration))
+		}
+	}
+	if spec.ResetThreshold != "" {
+		reset, err := Percentage(spec.ResetThreshold).ToFloat()
+		if err != nil || reset <= 0 || (threshold > 0 && reset > threshold) {
+			errs = append(errs, fmt.Sprintf("spec.resetThreshold '%s' must be a percentage at or below spec.threshold", spec.ResetThreshold))
+		}
+	}
+	if spec.GrowthAnomaly != nil {
+		if _, err := growthAnomaly(spec.GrowthAnomaly, 0, 0); err != nil {
+			errs = append(errs, "spec."+err.Error())
+		}
+		if spec.GrowthAnomaly.MaxGrowthPerHour == "" && spec.GrowthAnomaly.BaselineMultiple <= 0 {
+			errs = append(errs, "spec.growthAnomaly needs maxGrowthPerHour or a positive baselineMultiple")
+		}
+	}
+	if b := spec.GrowthBudget; b != nil {
+		if b.MaxExpansionsPerDay < 0 {
+			errs = append(errs, "spec.growthBudget.maxExpansionsPerDay must not be negative")
+		}
+		if b.MaxGrowthPerDay != "" {
+			if v, err := convertToGi(b.MaxGrowthPerDay); err != nil || v <= 0 {
+				errs = append(errs, fmt.Sprintf("spec.growthBudget.maxGrowthPerDay '%s' must be a positive size", b.MaxGrowthPerDay))
+			}
+		}
+	}
+	if p := spec.SnapshotBeforeResize; p != nil {
+		if p.VolumeSnapshotClassName == "" {
+			errs = append(errs, "spec.snapshotBeforeResize.volumeSnapshotClassName is required")
+		}
+		if p.ReadyTimeout != "" {
+			if d, err := time.ParseDuration(p.ReadyTimeout); err != nil || d <= 0 {
+				errs = append(errs, fmt.Sprintf("spec.snapshotBeforeResize.readyTimeout '%s' must be a positive duration", p.ReadyTimeout))
+			}
+		}
+		if p.MaxAge != "" {
+			if d, err := time.ParseDuration(p.MaxAge); err != nil || d <= 0 {
+				errs = append(errs, fmt.Sprintf("spec.snapshotBeforeResize.maxAge '%s' must be a positive duration", p.MaxAge))
+			}
+		}
+		if p.Retain < 0 {
+			errs = append(errs, "spec.snapshotBeforeResize.retain must not be negative")
+		}
+	}
+	if p := spec.Shrink; p != nil {
+		errs = append(errs, validateShrink(p, threshold)...)
+	}
+	if spec.CriticalScale != "" {
+		switch {
+		case spec.CriticalThreshold == "":
+			errs = append(errs, "spec.criticalScale requires spec.criticalThreshold")
+		case inferScaleType(spec.CriticalScale) == scaleTypePercentage:
+			if pct, err := Percentage(spec.CriticalScale).ToFloat(); err != nil || pct <= 0 {
+				errs = append(errs, fmt.Sprintf("spec.criticalScale '%s' must be a positive percentage or size", spec.CriticalScale))
+			}
+		default:
+			if inc, err := convertToGi(spec.CriticalScale); err != nil || inc <= 0 {
+				errs = append(errs, fmt.Sprintf("spec.criticalScale '%s' must be a positive percentage or size", spec.CriticalScale))
+			}
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+)
+
+func TestNormalizeScaleType(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "fixed", expected: "fixed"},
+		{input: "Fixed", expected: "fixed"},
+		{input: "percentage", expected: "percentage"},
+		{input: "VolumeScaler", expected: "percentage"},
+		{input: "bogus", expected: "bogus"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := normalizeScaleType(tt.input); got != tt.expected {
+				t.Errorf("normalizeScaleType(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDefaultSpec(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        v1alpha1.VolumeScalerSpec
+		expected    v1alpha1.VolumeScalerSpec
+		wantChanged bool
+	}{
+		{
+			name:        "missing cooldown and scale type",
+			spec:        v1alpha1.VolumeScalerSpec{PVCName: "data", Threshold: "70%", Scale: "30%", MaxSize: "10Gi"},
+			expected:    v1alpha1.VolumeScalerSpec{PVCName: "data", Threshold: "70%", Scale: "30%", ScaleType: "percentage", CooldownPeriod: defaultCooldownPeriod, MaxSize: "10Gi"},
+			wantChanged: true,
+		},
+		{
+			name:        "legacy scale type",
+			spec:        v1alpha1.VolumeScalerSpec{PVCName: "data", Threshold: "70", Scale: "30%", ScaleType: "VolumeScaler", CooldownPeriod: "5m", MaxSize: "10Gi"},
+			expected:    v1alpha1.VolumeScalerSpec{PVCName: "data", Threshold: "70%", Scale: "30%", ScaleType: "percentage", CooldownPeriod: "5m", MaxSize: "10Gi"},
+			wantChanged: true,
+		},
+		{
+			name:     "already complete",
+			spec:     v1alpha1.VolumeScalerSpec{PVCName: "data", Threshold: "70%", Scale: "2Gi", ScaleType: "fixed", CooldownPeriod: "5m", MaxSize: "10Gi"},
+			expected: v1alpha1.VolumeScalerSpec{PVCName: "data", Threshold: "70%", Scale: "2Gi", ScaleType: "fixed", CooldownPeriod: "5m", MaxSize: "10Gi"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := tt.spec
+			changed := defaultSpec(&spec)
+			if changed != tt.wantChanged {
+				t.Errorf("defaultSpec() changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if !reflect.DeepEqual(spec, tt.expected) {
+				t.Errorf("defaultSpec() = %+v, want %+v", spec, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateSpec(t *testing.T) {
+	valid := v1alpha1.VolumeScalerSpec{PVCName: "data", Threshold: "70%", Scale: "2Gi", ScaleType: "fixed", CooldownPeriod: "5m", MaxSize: "10Gi"}
+
+	tests := []struct {
+		name          string
+		mutate        func(*v1alpha1.VolumeScalerSpec)
+		currentSizeGi float64
+		wantErrs      int
+	}{
+		{name: "valid spec", mutate: func(s *v1alpha1.VolumeScalerSpec) {}},
+		{name: "threshold out of range", mutate: func(s *v1alpha1.VolumeScalerSpec) { s.Threshold = "100%" }, wantErrs: 1},
+		{name: "threshold not a number", mutate: func(s *v1alpha1.VolumeScalerSpec) { s.Threshold = "high" }, wantErrs: 1},
+		{name: "unparsable fixed scale", mutate: func(s *v1alpha1.VolumeScalerSpec) { s.Scale = "lots" }, wantErrs: 1},
+		{name: "unknown scale type", mutate: func(s *v1alpha1.VolumeScalerSpec) { s.ScaleType = "exponential" }, wantErrs: 1},
+		{name: "legacy scale type accepted", mutate: func(s *v1alpha1.VolumeScalerSpec) { s.ScaleType = "VolumeScaler"; s.Scale = "20%" }},
+		{name: "bad max size", mutate: func(s *v1alpha1.VolumeScalerSpec) { s.MaxSize = "10GB" }, wantErrs: 1},
+		{name: "bad cooldown", mutate: func(s *v1alpha1.VolumeScalerSpec) { s.CooldownPeriod = "soon" }, wantErrs: 1},
+		{name: "recommend mode", mutate: func(s *v1alpha1.VolumeScalerSpec) { s.Mode = modeRecommend }},
+		{name: "unknown mode", mutate: func(s *v1alpha1.VolumeScalerSpec) { s.Mode = "DryRun" }, wantErrs: 1},
+		{name: "approval policy", mutate: func(s *v1alpha1.VolumeScalerSpec) {
+			s.ApprovalPolicy = &v1alpha1.ApprovalPolicy{SizeAbove: "1Ti", IncrementAbove: "500Gi", Expiry: "24h"}
+		}},
+		{name: "bad approval expiry", mutate: func(s *v1alpha1.VolumeScalerSpec) {
+			s.ApprovalPolicy = &v1alpha1.ApprovalPolicy{SizeAbove: "1Ti", Expiry: "tomorrow"}
+		}, wantErrs: 1},
+		{name: "schedules and blackout windows", mutate: func(s *v1alpha1.VolumeScalerSpec) {
+			s.Schedules = []v1alpha1.ScaleSchedule{{Name: "month-end", Schedule: "0 0 28 * *", MinSize: "8Gi"}}
+			s.BlackoutWindows = []v1alpha1.BlackoutWindow{{Start: "CRON_TZ=Europe/Berlin 0 8 * * 1", Duration: "4h"}}
+			s.CriticalThreshold = "95%"
+		}},
+		{name: "bad schedule", mutate: func(s *v1alpha1.VolumeScalerSpec) {
+			s.Schedules = []v1alpha1.ScaleSchedule{{Schedule: "every month", MinSize: "20Gi"}}
+		}, wantErrs: 2},
+		{name: "bad blackout window", mutate: func(s *v1alpha1.VolumeScalerSpec) {
+			s.BlackoutWindows = []v1alpha1.BlackoutWindow{{Start: "0 8 * * 1", Duration: "-1h"}}
+		}, wantErrs: 1},
+		{name: "critical threshold below threshold", mutate: func(s *v1alpha1.VolumeScalerSpec) { s.CriticalThreshold = "60%" }, wantErrs: 1},
+		{name: "critical scale", mutate: func(s *v1alpha1.VolumeScalerSpec) { s.CriticalThreshold = "95%"; s.CriticalScale = "50%" }},
+		{name: "critical scale without threshold", mutate: func(s *v1alpha1.VolumeScalerSpec) { s.CriticalScale = "10Gi" }, wantErrs: 1},
+		{name: "bad critical scale", mutate: func(s *v1alpha1.VolumeScalerSpec) { s.CriticalThreshold = "95%"; s.CriticalScale = "lots" }, wantErrs: 1},
+		{name: "breach duration and reset threshold", mutate: func(s *v1alpha1.VolumeScalerSpec) { s.BreachDuration = "10m"; s.ResetThreshold = "65%" }},
+		{name: "bad breach duration", mutate: func(s *v1alpha1.VolumeScalerSpec) { s.BreachDuration = "a while" }, wantErrs: 1},
+		{name: "reset threshold above threshold", mutate: func(s *v1alpha1.VolumeScalerSpec) { s.ResetThreshold = "75%" }, wantErrs: 1},
+		{name: "growth anomaly policy", mutate: func(s *v1alpha1.VolumeScalerSpec) {
+			s.GrowthAnomaly = &v1alpha1.GrowthAnomalyPolicy{MaxGrowthPerHour: "50Gi", BaselineMultiple: 5}
+		}},
+		{name: "empty growth anomaly policy", mutate: func(s *v1alpha1.VolumeScalerSpec) { s.GrowthAnomaly = &v1alpha1.GrowthAnomalyPolicy{} }, wantErrs: 1},
+		{name: "bad growth rate", mutate: func(s *v1alpha1.VolumeScalerSpec) {
+			s.GrowthAnomaly = &v1alpha1.GrowthAnomalyPolicy{MaxGrowthPerHour: "fast"}
+		}, wantErrs: 1},
+		{name: "growth budget", mutate: func(s *v1alpha1.VolumeScalerSpec) {
+			s.GrowthBudget = &v1alpha1.GrowthBudget{MaxExpansionsPerDay: 4, MaxGrowthPerDay: "200Gi"}
+		}},
+		{name: "bad growth budget", mutate: func(s *v1alpha1.VolumeScalerSpec) {
+			s.GrowthBudget = &v1alpha1.GrowthBudget{MaxExpansionsPerDay: -1, MaxGrowthPerDay: "lots"}
+		}, wantErrs: 2},
+		{name: "snapshot before resize", mutate: func(s *v1alpha1.VolumeScalerSpec) {
+			s.SnapshotBeforeResize = &v1alpha1.SnapshotPolicy{VolumeSnapshotClassName: "csi-snapclass", ReadyTimeout: "5m", Retain: 2}
+		}},
+		{name: "bad snapshot policy", mutate: func(s *v1alpha1.VolumeScalerSpec) {
+			s.SnapshotBeforeResize = &v1alpha1.SnapshotPolicy{ReadyTimeout: "a bit", Retain: -1}
+		}, wantErrs: 3},
+		{name: "shrink", mutate: func(s *v1alpha1.VolumeScalerSpec) {
+			s.Shrink = &v1alpha1.ShrinkPolicy{
+				BelowUsage: "20%", SustainedFor: "72h", TargetUsage: "50%", MinSize: "1Gi",
+				Workload:          v1alpha1.WorkloadReference{Kind: "Deployment", Name: "app"},
+				MaintenanceWindow: &v1alpha1.BlackoutWindow{Start: "0 2 * * 0", Duration: "2h"},
+			}
+		}},
+		{name: "bad shrink", mutate: func(s *v1alpha1.VolumeScalerSpec) {
+			s.Shrink = &v1alpha1.ShrinkPolicy{
+				BelowUsage: "75%", SustainedFor: "soon", TargetUsage: "90%",
+				Workload: v1alpha1.WorkloadReference{Kind: "DaemonSet"},
+			}
+		}, wantErrs: 5},
+		{name: "max size below current size", mutate: func(s *v1alpha1.VolumeScalerSpec) {}, currentSizeGi: 20, wantErrs: 1},
+		{name: "several errors", mutate: func(s *v1alpha1.VolumeScalerSpec) { s.Threshold = "0%"; s.CooldownPeriod = "soon" }, wantErrs: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := valid
+			tt.mutate(&spec)
+			errs := validateSpec(&spec, tt.currentSizeGi)
+			if len(errs) != tt.wantErrs {
+				t.Errorf("validateSpec() = %v, want %d errors", errs, tt.wantErrs)
+			}
+		})
+	}
+}
@@ -0,0 +1,329 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	"github.com/zghanem/sample-volumeScaler/pkg/generated/clientset/versioned"
+)
+
+const (
+	// Webhook defaults and paths
+	defaultWebhookPort  = 9443
+	webhookValidatePath = "/validate-volumescaler"
+	webhookMutatePath   = "/mutate-volumescaler"
+	webhookApprovalPath = "/mutate-volumescalerequest"
+	webhookPVCPath      = "/mutate-persistentvolumeclaim"
+	webhookCertFile     = "tls.crt"
+	webhookKeyFile      = "tls.key"
+	maxAdmissionBody    = 1 << 20
+)
+
+// admitFunc decides a single admission request.
+type admitFunc func(ctx context.Context, req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse
+
+// WebhookServer serves admission and conversion webhooks for VolumeScaler
+// objects. It runs inside the controller binary so no extra deployment is needed.
+type WebhookServer struct {
+	config    *ControllerConfig
+	clientset kubernetes.Interface
+	vsClient  versioned.Interface
+}
+
+// NewWebhookServer creates a new instance of WebhookServer.
+func NewWebhookServer(config *ControllerConfig, clientset kubernetes.Interface, vsClient versioned.Interface) *WebhookServer {
+	return &WebhookServer{
+		config:    config,
+		clientset: clientset,
+		vsClient:  vsClient,
+	}
+}
+
+// Handler returns the HTTP handler with every webhook path registered.
+func (w *WebhookServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(webhookValidatePath, w.serveAdmission(w.validateVolumeScaler))
+	mux.HandleFunc(webhookMutatePath, w.serveAdmission(w.defaultVolumeScaler))
+	mux.HandleFunc(webhookApprovalPath, w.serveAdmission(w.authorizeApproval))
+	mux.HandleFunc(webhookPVCPath, w.serveAdmission(w.suggestInitialSize))
+	mux.HandleFunc(webhookConvertPath, w.serveConversion)
+	return mux
+}
+
+// Run serves the webhooks over TLS until the context is cancelled. The
+// certificate and key are read from WebhookCertDir.
+func (w *WebhookServer) Run(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", w.config.WebhookPort),
+		Handler:           w.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	certFile := filepath.Join(w.config.WebhookCertDir, webhookCertFile)
+	keyFile := filepath.Join(w.config.WebhookCertDir, webhookKeyFile)
+	fmt.Printf("[INFO] Serving admission webhooks on %s\n", srv.Addr)
+	if err := srv.ListenAndServeTLS(certFile, keyFile); err != nil && err != http.ErrServerClosed {
+		return fmt.Errorf("webhook server: %v", err)
+	}
+	return nil
+}
+
+// serveAdmission decodes an AdmissionReview, runs admit and writes the response.
+func (w *WebhookServer) serveAdmission(admit admitFunc) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxAdmissionBody))
+		if err != nil {
+			http.Error(rw, fmt.Sprintf("reading body: %v", err), http.StatusBadRequest)
+			return
+		}
+		review := &admissionv1.AdmissionReview{}
+		if err := json.Unmarshal(body, review); err != nil || review.Request == nil {
+			http.Error(rw, "malformed AdmissionReview", http.StatusBadRequest)
+			return
+		}
+
+		resp := admit(r.Context(), review.Request)
+		resp.UID = review.Request.UID
+		review.Response = resp
+		review.Request = nil
+
+		out, err := json.Marshal(review)
+		if err != nil {
+			http.Error(rw, fmt.Sprintf("encoding response: %v", err), http.StatusInternalServerError)
+			return
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		_, _ = rw.Write(out)
+	}
+}
+
+// allowed returns a response admitting the request.
+func allowed() *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{Allowed: true}
+}
+
+// denied returns a response rejecting the request with the given message.
+func denied(format string, args ...interface{}) *admissionv1.AdmissionResponse {
+	return &admissionv1.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: fmt.Sprintf(format, args...),
+			Reason:  metav1.StatusReasonInvalid,
+			Code:    http.StatusUnprocessableEntity,
+		},
+	}
+}
+
+// jsonPatchOp is a single RFC 6902 JSON patch operation.
+type jsonPatchOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// defaultVolumeScaler fills in missing spec fields and normalizes legacy
+// scaleType values through a JSON patch.
+func (w *WebhookServer) defaultVolumeScaler(ctx context.Context, req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return allowed()
+	}
+
+	vsObj := &v1alpha1.VolumeScaler{}
+	if err := json.Unmarshal(req.Object.Raw, vsObj); err != nil {
+		return denied("cannot decode VolumeScaler: %v", err)
+	}
+
+	orig := vsObj.Spec
+	if !defaultSpec(&vsObj.Spec) {
+		return allowed()
+	}
+
+	var ops []jsonPatchOp
+	addIfChanged := func(field, before, after string) {
+		if before != after {
+			ops = append(ops, jsonPatchOp{Op: "add", Path: "/spec/" + field, Value: after})
+		}
+	}
+	addIfChanged("threshold", orig.Threshold, vsObj.Spec.Threshold)
+	addIfChanged("scaleType", orig.ScaleType, vsObj.Spec.ScaleType)
+	addIfChanged("cooldownPeriod", orig.CooldownPeriod, vsObj.Spec.CooldownPeriod)
+
+	patch, err := json.Marshal(ops)
+	if err != nil {
+		return denied("cannot encode patch: %v", err)
+	}
+	patchType := admissionv1.PatchTypeJSONPatch
+	resp := allowed()
+	resp.Patch = patch
+	resp.PatchType = &patchType
+	return resp
+}
+
+// validateVolumeScaler rejects VolumeScalers with invalid specs, a maxSize
+// below the PVC's current size, or, on create or retarget, a PVC already
+// managed by another VolumeScaler in the same namespace.
+func (w *WebhookServer) validateVolumeScaler(ctx context.Context, req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return allowed()
+	}
+
+	vsObj := &v1alpha1.VolumeScaler{}
+	if err := json.Unmarshal(req.Object.Raw, vsObj); err != nil {
+		return denied("cannot decode VolumeScaler: %v", err)
+	}
+
+	currentSizeGi := 0.0
+	if vsObj.Spec.PVCName != "" {
+		pvc, err := w.clientset.CoreV1().PersistentVolumeClaims(req.Namespace).Get(ctx, vsObj.Spec.PVCName, metav1.GetOptions{})
+		if err == nil {
+			currentSizeGi, _ = convertToGi(pvc.Spec.Resources.Requests.Storage().String())
+		}
+	}
+	if errs := validateSpec(&vsObj.Spec, currentSizeGi); len(errs) > 0 {
+		return denied("invalid VolumeScaler: %s", strings.Join(errs, "; "))
+	}
+
+	// Existing duplicates are resolved by priority, so an UPDATE is only
+	// checked when it retargets the VolumeScaler; operators can still edit
+	// priority or suspend to settle a conflict.
+	if req.Operation == admissionv1.Update {
+		old := &v1alpha1.VolumeScaler{}
+		if err := json.Unmarshal(req.OldObject.Raw, old); err != nil {
+			return denied("cannot decode VolumeScaler: %v", err)
+		}
+		if old.Spec.PVCName == vsObj.Spec.PVCName {
+			return allowed()
+		}
+	}
+	if err := w.checkDuplicateTarget(ctx, req.Namespace, req.Name, vsObj.Spec.PVCName); err != nil {
+		return denied("%v", err)
+	}
+	return allowed()
+}
+
+// checkDuplicateTarget returns an error when another VolumeScaler in the
+// namespace already targets pvcName.
+func (w *WebhookServer) checkDuplicateTarget(ctx context.Context, namespace, name, pvcName string) error {
+	list, err := w.vsClient.AutoscalingV1alpha1().VolumeScalers(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		// Don't block users on a transient lookup failure; reconcileLoop still resolves conflicts.
+		fmt.Printf("[WARN] webhook: listing VolumeScalers in '%s': %v\n", namespace, err)
+		return nil
+	}
+	for _, other := range list.Items {
+		if other.Name == name {
+			continue
+		}
+		if other.Spec.PVCName == pvcName {
+			return fmt.Errorf("PVC '%s' is already targeted by VolumeScaler '%s'", pvcName, other.Name)
+		}
+	}
+	return nil
+}
+
+// authorizeApproval lets only users holding the "approve" verb on
+// volumescalerequests set the volumescaler.io/approval annotation on an
+// existing request, and records who did in volumescaler.io/approved-by; the
+// controller acts only on decisions carrying it.
+func (w *WebhookServer) authorizeApproval(ctx context.Context, req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return allowed()
+	}
+
+	obj := &v1alpha1.VolumeScaleRequest{}
+	if err := json.Unmarshal(req.Object.Raw, obj); err != nil {
+		return denied("cannot decode VolumeScaleRequest: %v", err)
+	}
+	old := &v1alpha1.VolumeScaleRequest{}
+	if len(req.OldObject.Raw) > 0 {
+		if err := json.Unmarshal(req.OldObject.Raw, old); err != nil {
+			return denied("cannot decode VolumeScaleRequest: %v", err)
+		}
+	}
+
+	// Decisions present at creation are never stamped, so the controller
+	// ignores them; a requester can't create a request already approved.
+	if req.Operation == admissionv1.Create {
+		if _, ok := obj.Annotations[annotationApprovedBy]; ok {
+			return denied("annotation %s is managed by the controller", annotationApprovedBy)
+		}
+		return allowed()
+	}
+
+	decision := obj.Annotations[annotationApproval]
+	if decision == old.Annotations[annotationApproval] {
+		if obj.Annotations[annotationApprovedBy] != old.Annotations[annotationApprovedBy] {
+			return denied("annotation %s is managed by the controller", annotationApprovedBy)
+		}
+		return allowed()
+	}
+	if decision == "" {
+		return allowed()
+	}
+
+	sar := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   req.UserInfo.Username,
+			UID:    req.UserInfo.UID,
+			Groups: req.UserInfo.Groups,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: req.Namespace,
+				Verb:      "approve",
+				Group:     v1alpha1.GroupName,
+				Resource:  "volumescalerequests",
+				Name:      req.Name,
+			},
+		},
+	}
+	if len(req.UserInfo.Extra) > 0 {
+		sar.Spec.Extra = make(map[string]authorizationv1.ExtraValue, len(req.UserInfo.Extra))
+		for k, v := range req.UserInfo.Extra {
+			sar.Spec.Extra[k] = authorizationv1.ExtraValue(v)
+		}
+	}
+	result, err := w.clientset.AuthorizationV1().SubjectAccessReviews().Create(ctx, sar, metav1.CreateOptions{})
+	if err != nil {
+		return denied("cannot authorize approval: %v", err)
+	}
+	if !result.Status.Allowed {
+		return denied("user '%s' may not approve VolumeScaleRequests in namespace '%s'", req.UserInfo.Username, req.Namespace)
+	}
+
+	op := "add"
+	if _, ok := obj.Annotations[annotationApprovedBy]; ok {
+		op = "replace"
+	}
+	ops := []jsonPatchOp{{
+		Op:    op,
+		Path:  "/metadata/annotations/" + strings.ReplaceAll(annotationApprovedBy, "/", "~1"),
+		Value: req.UserInfo.Username,
+	}}
+	patch, err := json.Marshal(ops)
+	if err != nil {
+		return denied("cannot encode patch: %v", err)
+	}
+	patchType := admissionv1.PatchTypeJSONPatch
+	resp := allowed()
+	resp.Patch = patch
+	resp.PatchType = &patchType
+	return resp
+}
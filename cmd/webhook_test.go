@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kfake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	vsfake "github.com/zghanem/sample-volumeScaler/pkg/generated/clientset/versioned/fake"
+)
+
+// sendAdmissionReview posts an AdmissionReview for obj to the webhook path and
+// returns the decoded response.
+func sendAdmissionReview(t *testing.T, handler http.Handler, path string, op admissionv1.Operation, obj interface{}) *admissionv1.AdmissionResponse {
+	t.Helper()
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("Failed to encode object: %v", err)
+	}
+	review := admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+		Request: &admissionv1.AdmissionRequest{
+			UID:       "req-1",
+			Operation: op,
+			Namespace: "default",
+			Name:      "new-vs",
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+	}
+	body, _ := json.Marshal(review)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Unexpected HTTP status %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var out admissionv1.AdmissionReview
+	if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if out.Response == nil || out.Response.UID != "req-1" {
+		t.Fatalf("Expected response for request req-1, got %+v", out.Response)
+	}
+	return out.Response
+}
+
+func TestWebhook_RejectsDuplicateTarget(t *testing.T) {
+	existing := &v1alpha1.VolumeScaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "existing-vs", Namespace: "default"},
+		Spec:       v1alpha1.VolumeScalerSpec{PVCName: "data", Threshold: "70%", Scale: "2Gi", ScaleType: "fixed", MaxSize: "10Gi"},
+	}
+	server := NewWebhookServer(NewDefaultConfig(), kfake.NewSimpleClientset(), vsfake.NewSimpleClientset(existing))
+
+	tests := []struct {
+		name    string
+		pvcName string
+		allowed bool
+	}{
+		{name: "duplicate target rejected", pvcName: "data", allowed: false},
+		{name: "different target allowed", pvcName: "other", allowed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vs := &v1alpha1.VolumeScaler{
+				ObjectMeta: metav1.ObjectMeta{Name: "new-vs", Namespace: "default"},
+				Spec:       v1alpha1.VolumeScalerSpec{PVCName: tt.pvcName, Threshold: "70%", Scale: "2Gi", ScaleType: "fixed", MaxSize: "10Gi"},
+			}
+			resp := sendAdmissionReview(t, server.Handler(), webhookValidatePath, admissionv1.Create, vs)
+			if resp.Allowed != tt.allowed {
+				t.Errorf("Allowed = %v, want %v (result: %+v)", resp.Allowed, tt.allowed, resp.Result)
+			}
+		})
+	}
+}
+
+func TestWebhook_DuplicateTargetOnlyCheckedWhenRetargeted(t *testing.T) {
+	spec := v1alpha1.VolumeScalerSpec{PVCName: "data", Threshold: "70%", Scale: "2Gi", ScaleType: "fixed", MaxSize: "10Gi"}
+	existing := &v1alpha1.VolumeScaler{ObjectMeta: metav1.ObjectMeta{Name: "existing-vs", Namespace: "default"}, Spec: spec}
+	server := NewWebhookServer(NewDefaultConfig(), kfake.NewSimpleClientset(), vsfake.NewSimpleClientset(existing))
+
+	update := func(oldPVC string) *admissionv1.AdmissionResponse {
+		t.Helper()
+		old := &v1alpha1.VolumeScaler{ObjectMeta: metav1.ObjectMeta{Name: "new-vs", Namespace: "default"}, Spec: spec}
+		old.Spec.PVCName = oldPVC
+		updated := old.DeepCopy()
+		updated.Spec.PVCName = "data"
+		updated.Spec.Priority = 10
+		oldRaw, _ := json.Marshal(old)
+		newRaw, _ := json.Marshal(updated)
+		body, _ := json.Marshal(admissionv1.AdmissionReview{
+			TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+			Request: &admissionv1.AdmissionRequest{
+				UID:       "req-1",
+				Operation: admissionv1.Update,
+				Namespace: "default",
+				Name:      "new-vs",
+				Object:    runtime.RawExtension{Raw: newRaw},
+				OldObject: runtime.RawExtension{Raw: oldRaw},
+			},
+		})
+		rec := httptest.NewRecorder()
+		server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, webhookValidatePath, bytes.NewReader(body)))
+		var out admissionv1.AdmissionReview
+		if err := json.Unmarshal(rec.Body.Bytes(), &out); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return out.Response
+	}
+
+	if resp := update("data"); !resp.Allowed {
+		t.Errorf("Expected an existing duplicate to stay editable, got %v", resp.Result)
+	}
+	if resp := update("other"); resp.Allowed {
+		t.Error("Expected retargeting onto another VolumeScaler's PVC to be rejected")
+	}
+}
+
+func TestWebhook_ValidatesSpec(t *testing.T) {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("20Gi")},
+			},
+		},
+	}
+	server := NewWebhookServer(NewDefaultConfig(), kfake.NewSimpleClientset(pvc), vsfake.NewSimpleClientset())
+
+	tests := []struct {
+		name    string
+		spec    v1alpha1.VolumeScalerSpec
+		allowed bool
+		message string
+	}{
+		{
+			name:    "valid",
+			spec:    v1alpha1.VolumeScalerSpec{PVCName: "data", Threshold: "70%", Scale: "2Gi", ScaleType: "fixed", CooldownPeriod: "5m", MaxSize: "50Gi"},
+			allowed: true,
+		},
+		{
+			name:    "max size below PVC size",
+			spec:    v1alpha1.VolumeScalerSpec{PVCName: "data", Threshold: "70%", Scale: "2Gi", ScaleType: "fixed", CooldownPeriod: "5m", MaxSize: "10Gi"},
+			message: "below the PVC's current size",
+		},
+		{
+			name:    "unknown scale type",
+			spec:    v1alpha1.VolumeScalerSpec{PVCName: "data", Threshold: "70%", Scale: "2Gi", ScaleType: "exponential", CooldownPeriod: "5m", MaxSize: "50Gi"},
+			message: "scaleType",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vs := &v1alpha1.VolumeScaler{ObjectMeta: metav1.ObjectMeta{Name: "new-vs", Namespace: "default"}, Spec: tt.spec}
+			resp := sendAdmissionReview(t, server.Handler(), webhookValidatePath, admissionv1.Create, vs)
+			if resp.Allowed != tt.allowed {
+				t.Fatalf("Allowed = %v, want %v (result: %+v)", resp.Allowed, tt.allowed, resp.Result)
+			}
+			if tt.message != "" && !strings.Contains(resp.Result.Message, tt.message) {
+				t.Errorf("Expected message to mention %q, got %q", tt.message, resp.Result.Message)
+			}
+		})
+	}
+}
+
+func TestWebhook_DefaultsSpec(t *testing.T) {
+	server := NewWebhookServer(NewDefaultConfig(), kfake.NewSimpleClientset(), vsfake.NewSimpleClientset())
+	vs := &v1alpha1.VolumeScaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "new-vs", Namespace: "default"},
+		Spec:       v1alpha1.VolumeScalerSpec{PVCName: "data", Threshold: "70%", Scale: "30%", ScaleType: "VolumeScaler", MaxSize: "50Gi"},
+	}
+
+	resp := sendAdmissionReview(t, server.Handler(), webhookMutatePath, admissionv1.Create, vs)
+	if !resp.Allowed {
+		t.Fatalf("Expected request to be allowed, got %+v", resp.Result)
+	}
+	if resp.PatchType == nil || *resp.PatchType != admissionv1.PatchTypeJSONPatch {
+		t.Fatalf("Expected a JSON patch, got %v", resp.PatchType)
+	}
+
+	var ops []jsonPatchOp
+	if err := json.Unmarshal(resp.Patch, &ops); err != nil {
+		t.Fatalf("Failed to decode patch: %v", err)
+	}
+	got := make(map[string]interface{})
+	for _, op := range ops {
+		got[op.Path] = op.Value
+	}
+	if got["/spec/scaleType"] != "percentage" {
+		t.Errorf("Expected scaleType to be normalized to percentage, got %v", got["/spec/scaleType"])
+	}
+	if got["/spec/cooldownPeriod"] != defaultCooldownPeriod {
+		t.Errorf("Expected cooldownPeriod to default to %s, got %v", defaultCooldownPeriod, got["/spec/cooldownPeriod"])
+	}
+	if _, ok := got["/spec/threshold"]; ok {
+		t.Error("Expected threshold to be left alone")
+	}
+}
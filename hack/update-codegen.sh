@@ -0,0 +1,95 @@
+#!/usr/bin/env bash
+
+# Regenerates deepcopy functions, the typed clientset, listers and informers
+# for the API packages under api/. Run from the repository root:
+#
+#   ./hack/update-codegen.sh
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+CODEGEN_VERSION=${CODEGEN_VERSION:-v0.28.2}
+MODULE=github.com/zghanem/sample-volumeScaler
+GROUP=autoscaling
+API_VERSIONS=(v1alpha1 v1beta1)
+OUTPUT_PKG=${MODULE}/pkg/generated
+
+REPO_ROOT=$(cd "$(dirname "${BASH_SOURCE[0]}")/.." && pwd)
+BOILERPLATE=${REPO_ROOT}/hack/boilerplate.go.txt
+TMP_DIR=$(mktemp -d)
+
+# client-gen, lister-gen and informer-gen derive package names from an
+# <group>/<version> directory layout, and treat a group directory called "api"
+# as the core group. Expose api/<version> under api/${GROUP}/<version> through
+# symlinks while generating, then point the imports back at api/<version>.
+GROUP_DIR=${REPO_ROOT}/api/${GROUP}
+cleanup() {
+  rm -rf "${TMP_DIR}" "${GROUP_DIR}"
+}
+trap cleanup EXIT
+
+# Set CODEGEN_BIN to a directory with prebuilt generators to skip the install.
+BIN=${CODEGEN_BIN:-${TMP_DIR}/bin}
+if [[ -z "${CODEGEN_BIN:-}" ]]; then
+  GOBIN=${BIN} go install \
+    "k8s.io/code-generator/cmd/deepcopy-gen@${CODEGEN_VERSION}" \
+    "k8s.io/code-generator/cmd/client-gen@${CODEGEN_VERSION}" \
+    "k8s.io/code-generator/cmd/lister-gen@${CODEGEN_VERSION}" \
+    "k8s.io/code-generator/cmd/informer-gen@${CODEGEN_VERSION}"
+fi
+
+mkdir -p "${GROUP_DIR}"
+INPUT_DIRS=""
+GROUP_INPUT_DIRS=""
+CLIENT_INPUTS=""
+for v in "${API_VERSIONS[@]}"; do
+  ln -s "../${v}" "${GROUP_DIR}/${v}"
+  INPUT_DIRS="${INPUT_DIRS:+${INPUT_DIRS},}${MODULE}/api/${v}"
+  GROUP_INPUT_DIRS="${GROUP_INPUT_DIRS:+${GROUP_INPUT_DIRS},}${MODULE}/api/${GROUP}/${v}"
+  CLIENT_INPUTS="${CLIENT_INPUTS:+${CLIENT_INPUTS},}${GROUP}/${v}"
+done
+
+# The generators write below <output-base>/<import path>; generate into a
+# scratch GOPATH-style tree and copy the results back into the module.
+OUT=${TMP_DIR}/src
+
+"${BIN}/deepcopy-gen" \
+  --input-dirs "${INPUT_DIRS}" \
+  --output-file-base zz_generated.deepcopy \
+  --bounding-dirs "${MODULE}/api" \
+  --go-header-file "${BOILERPLATE}" \
+  --output-base "${OUT}"
+
+"${BIN}/client-gen" \
+  --clientset-name versioned \
+  --input-base "${MODULE}/api" \
+  --input "${CLIENT_INPUTS}" \
+  --output-package "${OUTPUT_PKG}/clientset" \
+  --go-header-file "${BOILERPLATE}" \
+  --output-base "${OUT}"
+
+"${BIN}/lister-gen" \
+  --input-dirs "${GROUP_INPUT_DIRS}" \
+  --output-package "${OUTPUT_PKG}/listers" \
+  --go-header-file "${BOILERPLATE}" \
+  --output-base "${OUT}"
+
+"${BIN}/informer-gen" \
+  --input-dirs "${GROUP_INPUT_DIRS}" \
+  --versioned-clientset-package "${OUTPUT_PKG}/clientset/versioned" \
+  --listers-package "${OUTPUT_PKG}/listers" \
+  --output-package "${OUTPUT_PKG}/informers" \
+  --go-header-file "${BOILERPLATE}" \
+  --output-base "${OUT}"
+
+for v in "${API_VERSIONS[@]}"; do
+  cp "${OUT}/${MODULE}/api/${v}/zz_generated.deepcopy.go" "${REPO_ROOT}/api/${v}/"
+done
+rm -rf "${REPO_ROOT}/pkg/generated"
+mkdir -p "${REPO_ROOT}/pkg"
+cp -R "${OUT}/${OUTPUT_PKG}" "${REPO_ROOT}/pkg/generated"
+find "${REPO_ROOT}/pkg/generated" -name '*.go' -exec \
+  sed -i.bak "s|\"${MODULE}/api/${GROUP}/|\"${MODULE}/api/|" {} +
+find "${REPO_ROOT}/pkg/generated" -name '*.go.bak' -delete
+gofmt -w "${REPO_ROOT}/pkg/generated"
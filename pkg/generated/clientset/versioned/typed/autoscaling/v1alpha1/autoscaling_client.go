@@ -0,0 +1,116 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"net/http"
+
+	v1alpha1 "github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	"github.com/zghanem/sample-volumeScaler/pkg/generated/clientset/versioned/scheme"
+	rest "k8s.io/client-go/rest"
+)
+
+type AutoscalingV1alpha1Interface interface {
+	RESTClient() rest.Interface
+	VolumeRecommendationsGetter
+	VolumeScaleEventsGetter
+	VolumeScaleRequestsGetter
+	VolumeScalersGetter
+	VolumeScalerLimitsGetter
+	VolumeUsageHistoriesGetter
+}
+
+// AutoscalingV1alpha1Client is used to interact with features provided by the autoscaling.storage.k8s.io group.
+type AutoscalingV1alpha1Client struct {
+	restClient rest.Interface
+}
+
+func (c *AutoscalingV1alpha1Client) VolumeRecommendations(namespace string) VolumeRecommendationInterface {
+	return newVolumeRecommendations(c, namespace)
+}
+
+func (c *AutoscalingV1alpha1Client) VolumeScaleEvents(namespace string) VolumeScaleEventInterface {
+	return newVolumeScaleEvents(c, namespace)
+}
+
+func (c *AutoscalingV1alpha1Client) VolumeScaleRequests(namespace string) VolumeScaleRequestInterface {
+	return newVolumeScaleRequests(c, namespace)
+}
+
+func (c *AutoscalingV1alpha1Client) VolumeScalers(namespace string) VolumeScalerInterface {
+	return newVolumeScalers(c, namespace)
+}
+
+func (c *AutoscalingV1alpha1Client) VolumeScalerLimits() VolumeScalerLimitInterface {
+	return newVolumeScalerLimits(c)
+}
+
+func (c *AutoscalingV1alpha1Client) VolumeUsageHistories(namespace string) VolumeUsageHistoryInterface {
+	return newVolumeUsageHistories(c, namespace)
+}
+
+// NewForConfig creates a new AutoscalingV1alpha1Client for the given config.
+// NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
+// where httpClient was generated with rest.HTTPClientFor(c).
+func NewForConfig(c *rest.Config) (*AutoscalingV1alpha1Client, error) {
+	config := *c
+	if err := setConfigDefaults(&config); err != nil {
+		return nil, err
+	}
+	httpClient, err := rest.HTTPClientFor(&config)
+	if err != nil {
+		return nil, err
+	}
+	return NewForConfigAndClient(&config, httpClient)
+}
+
+// NewForConfigAndClient creates a new AutoscalingV1alpha1Client for the given config and http client.
+// Note the http client provided takes precedence over the configured transport values.
+func NewForConfigAndClient(c *rest.Config, h *http.Client) (*AutoscalingV1alpha1Client, error) {
+	config := *c
+	if err := setConfigDefaults(&config); err != nil {
+		return nil, err
+	}
+	client, err := rest.RESTClientForConfigAndClient(&config, h)
+	if err != nil {
+		return nil, err
+	}
+	return &AutoscalingV1alpha1Client{client}, nil
+}
+
+// NewForConfigOrDie creates a new AutoscalingV1alpha1Client for the given config and
+// panics if there is an error in the config.
+func NewForConfigOrDie(c *rest.Config) *AutoscalingV1alpha1Client {
+	client, err := NewForConfig(c)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// New creates a new AutoscalingV1alpha1Client for the given RESTClient.
+func New(c rest.Interface) *AutoscalingV1alpha1Client {
+	return &AutoscalingV1alpha1Client{c}
+}
+
+func setConfigDefaults(config *rest.Config) error {
+	gv := v1alpha1.SchemeGroupVersion
+	config.GroupVersion = &gv
+	config.APIPath = "/apis"
+	config.NegotiatedSerializer = scheme.Codecs.WithoutConversion()
+
+	if config.UserAgent == "" {
+		config.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+
+	return nil
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *AutoscalingV1alpha1Client) RESTClient() rest.Interface {
+	if c == nil {
+		return nil
+	}
+	return c.restClient
+}
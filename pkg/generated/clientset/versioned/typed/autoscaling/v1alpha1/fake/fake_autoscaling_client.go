@@ -0,0 +1,44 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1alpha1 "github.com/zghanem/sample-volumeScaler/pkg/generated/clientset/versioned/typed/autoscaling/v1alpha1"
+	rest "k8s.io/client-go/rest"
+	testing "k8s.io/client-go/testing"
+)
+
+type FakeAutoscalingV1alpha1 struct {
+	*testing.Fake
+}
+
+func (c *FakeAutoscalingV1alpha1) VolumeRecommendations(namespace string) v1alpha1.VolumeRecommendationInterface {
+	return &FakeVolumeRecommendations{c, namespace}
+}
+
+func (c *FakeAutoscalingV1alpha1) VolumeScaleEvents(namespace string) v1alpha1.VolumeScaleEventInterface {
+	return &FakeVolumeScaleEvents{c, namespace}
+}
+
+func (c *FakeAutoscalingV1alpha1) VolumeScaleRequests(namespace string) v1alpha1.VolumeScaleRequestInterface {
+	return &FakeVolumeScaleRequests{c, namespace}
+}
+
+func (c *FakeAutoscalingV1alpha1) VolumeScalers(namespace string) v1alpha1.VolumeScalerInterface {
+	return &FakeVolumeScalers{c, namespace}
+}
+
+func (c *FakeAutoscalingV1alpha1) VolumeScalerLimits() v1alpha1.VolumeScalerLimitInterface {
+	return &FakeVolumeScalerLimits{c}
+}
+
+func (c *FakeAutoscalingV1alpha1) VolumeUsageHistories(namespace string) v1alpha1.VolumeUsageHistoryInterface {
+	return &FakeVolumeUsageHistories{c, namespace}
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *FakeAutoscalingV1alpha1) RESTClient() rest.Interface {
+	var ret *rest.RESTClient
+	return ret
+}
@@ -0,0 +1,125 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeVolumeRecommendations implements VolumeRecommendationInterface
+type FakeVolumeRecommendations struct {
+	Fake *FakeAutoscalingV1alpha1
+	ns   string
+}
+
+var volumerecommendationsResource = v1alpha1.SchemeGroupVersion.WithResource("volumerecommendations")
+
+var volumerecommendationsKind = v1alpha1.SchemeGroupVersion.WithKind("VolumeRecommendation")
+
+// Get takes name of the volumeRecommendation, and returns the corresponding volumeRecommendation object, and an error if there is any.
+func (c *FakeVolumeRecommendations) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.VolumeRecommendation, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(volumerecommendationsResource, c.ns, name), &v1alpha1.VolumeRecommendation{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VolumeRecommendation), err
+}
+
+// List takes label and field selectors, and returns the list of VolumeRecommendations that match those selectors.
+func (c *FakeVolumeRecommendations) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.VolumeRecommendationList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(volumerecommendationsResource, volumerecommendationsKind, c.ns, opts), &v1alpha1.VolumeRecommendationList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.VolumeRecommendationList{ListMeta: obj.(*v1alpha1.VolumeRecommendationList).ListMeta}
+	for _, item := range obj.(*v1alpha1.VolumeRecommendationList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested volumeRecommendations.
+func (c *FakeVolumeRecommendations) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(volumerecommendationsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a volumeRecommendation and creates it.  Returns the server's representation of the volumeRecommendation, and an error, if there is any.
+func (c *FakeVolumeRecommendations) Create(ctx context.Context, volumeRecommendation *v1alpha1.VolumeRecommendation, opts v1.CreateOptions) (result *v1alpha1.VolumeRecommendation, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(volumerecommendationsResource, c.ns, volumeRecommendation), &v1alpha1.VolumeRecommendation{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VolumeRecommendation), err
+}
+
+// Update takes the representation of a volumeRecommendation and updates it. Returns the server's representation of the volumeRecommendation, and an error, if there is any.
+func (c *FakeVolumeRecommendations) Update(ctx context.Context, volumeRecommendation *v1alpha1.VolumeRecommendation, opts v1.UpdateOptions) (result *v1alpha1.VolumeRecommendation, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(volumerecommendationsResource, c.ns, volumeRecommendation), &v1alpha1.VolumeRecommendation{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VolumeRecommendation), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeVolumeRecommendations) UpdateStatus(ctx context.Context, volumeRecommendation *v1alpha1.VolumeRecommendation, opts v1.UpdateOptions) (*v1alpha1.VolumeRecommendation, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(volumerecommendationsResource, "status", c.ns, volumeRecommendation), &v1alpha1.VolumeRecommendation{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VolumeRecommendation), err
+}
+
+// Delete takes name of the volumeRecommendation and deletes it. Returns an error if one occurs.
+func (c *FakeVolumeRecommendations) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(volumerecommendationsResource, c.ns, name, opts), &v1alpha1.VolumeRecommendation{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeVolumeRecommendations) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(volumerecommendationsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.VolumeRecommendationList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched volumeRecommendation.
+func (c *FakeVolumeRecommendations) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.VolumeRecommendation, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(volumerecommendationsResource, c.ns, name, pt, data, subresources...), &v1alpha1.VolumeRecommendation{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VolumeRecommendation), err
+}
@@ -0,0 +1,113 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeVolumeScaleEvents implements VolumeScaleEventInterface
+type FakeVolumeScaleEvents struct {
+	Fake *FakeAutoscalingV1alpha1
+	ns   string
+}
+
+var volumescaleeventsResource = v1alpha1.SchemeGroupVersion.WithResource("volumescaleevents")
+
+var volumescaleeventsKind = v1alpha1.SchemeGroupVersion.WithKind("VolumeScaleEvent")
+
+// Get takes name of the volumeScaleEvent, and returns the corresponding volumeScaleEvent object, and an error if there is any.
+func (c *FakeVolumeScaleEvents) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.VolumeScaleEvent, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(volumescaleeventsResource, c.ns, name), &v1alpha1.VolumeScaleEvent{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VolumeScaleEvent), err
+}
+
+// List takes label and field selectors, and returns the list of VolumeScaleEvents that match those selectors.
+func (c *FakeVolumeScaleEvents) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.VolumeScaleEventList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(volumescaleeventsResource, volumescaleeventsKind, c.ns, opts), &v1alpha1.VolumeScaleEventList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.VolumeScaleEventList{ListMeta: obj.(*v1alpha1.VolumeScaleEventList).ListMeta}
+	for _, item := range obj.(*v1alpha1.VolumeScaleEventList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested volumeScaleEvents.
+func (c *FakeVolumeScaleEvents) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(volumescaleeventsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a volumeScaleEvent and creates it.  Returns the server's representation of the volumeScaleEvent, and an error, if there is any.
+func (c *FakeVolumeScaleEvents) Create(ctx context.Context, volumeScaleEvent *v1alpha1.VolumeScaleEvent, opts v1.CreateOptions) (result *v1alpha1.VolumeScaleEvent, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(volumescaleeventsResource, c.ns, volumeScaleEvent), &v1alpha1.VolumeScaleEvent{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VolumeScaleEvent), err
+}
+
+// Update takes the representation of a volumeScaleEvent and updates it. Returns the server's representation of the volumeScaleEvent, and an error, if there is any.
+func (c *FakeVolumeScaleEvents) Update(ctx context.Context, volumeScaleEvent *v1alpha1.VolumeScaleEvent, opts v1.UpdateOptions) (result *v1alpha1.VolumeScaleEvent, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(volumescaleeventsResource, c.ns, volumeScaleEvent), &v1alpha1.VolumeScaleEvent{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VolumeScaleEvent), err
+}
+
+// Delete takes name of the volumeScaleEvent and deletes it. Returns an error if one occurs.
+func (c *FakeVolumeScaleEvents) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(volumescaleeventsResource, c.ns, name, opts), &v1alpha1.VolumeScaleEvent{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeVolumeScaleEvents) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(volumescaleeventsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.VolumeScaleEventList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched volumeScaleEvent.
+func (c *FakeVolumeScaleEvents) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.VolumeScaleEvent, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(volumescaleeventsResource, c.ns, name, pt, data, subresources...), &v1alpha1.VolumeScaleEvent{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VolumeScaleEvent), err
+}
@@ -0,0 +1,125 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeVolumeScaleRequests implements VolumeScaleRequestInterface
+type FakeVolumeScaleRequests struct {
+	Fake *FakeAutoscalingV1alpha1
+	ns   string
+}
+
+var volumescalerequestsResource = v1alpha1.SchemeGroupVersion.WithResource("volumescalerequests")
+
+var volumescalerequestsKind = v1alpha1.SchemeGroupVersion.WithKind("VolumeScaleRequest")
+
+// Get takes name of the volumeScaleRequest, and returns the corresponding volumeScaleRequest object, and an error if there is any.
+func (c *FakeVolumeScaleRequests) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.VolumeScaleRequest, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(volumescalerequestsResource, c.ns, name), &v1alpha1.VolumeScaleRequest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VolumeScaleRequest), err
+}
+
+// List takes label and field selectors, and returns the list of VolumeScaleRequests that match those selectors.
+func (c *FakeVolumeScaleRequests) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.VolumeScaleRequestList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(volumescalerequestsResource, volumescalerequestsKind, c.ns, opts), &v1alpha1.VolumeScaleRequestList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.VolumeScaleRequestList{ListMeta: obj.(*v1alpha1.VolumeScaleRequestList).ListMeta}
+	for _, item := range obj.(*v1alpha1.VolumeScaleRequestList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested volumeScaleRequests.
+func (c *FakeVolumeScaleRequests) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(volumescalerequestsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a volumeScaleRequest and creates it.  Returns the server's representation of the volumeScaleRequest, and an error, if there is any.
+func (c *FakeVolumeScaleRequests) Create(ctx context.Context, volumeScaleRequest *v1alpha1.VolumeScaleRequest, opts v1.CreateOptions) (result *v1alpha1.VolumeScaleRequest, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(volumescalerequestsResource, c.ns, volumeScaleRequest), &v1alpha1.VolumeScaleRequest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VolumeScaleRequest), err
+}
+
+// Update takes the representation of a volumeScaleRequest and updates it. Returns the server's representation of the volumeScaleRequest, and an error, if there is any.
+func (c *FakeVolumeScaleRequests) Update(ctx context.Context, volumeScaleRequest *v1alpha1.VolumeScaleRequest, opts v1.UpdateOptions) (result *v1alpha1.VolumeScaleRequest, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(volumescalerequestsResource, c.ns, volumeScaleRequest), &v1alpha1.VolumeScaleRequest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VolumeScaleRequest), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeVolumeScaleRequests) UpdateStatus(ctx context.Context, volumeScaleRequest *v1alpha1.VolumeScaleRequest, opts v1.UpdateOptions) (*v1alpha1.VolumeScaleRequest, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(volumescalerequestsResource, "status", c.ns, volumeScaleRequest), &v1alpha1.VolumeScaleRequest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VolumeScaleRequest), err
+}
+
+// Delete takes name of the volumeScaleRequest and deletes it. Returns an error if one occurs.
+func (c *FakeVolumeScaleRequests) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(volumescalerequestsResource, c.ns, name, opts), &v1alpha1.VolumeScaleRequest{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeVolumeScaleRequests) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(volumescalerequestsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.VolumeScaleRequestList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched volumeScaleRequest.
+func (c *FakeVolumeScaleRequests) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.VolumeScaleRequest, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(volumescalerequestsResource, c.ns, name, pt, data, subresources...), &v1alpha1.VolumeScaleRequest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VolumeScaleRequest), err
+}
@@ -0,0 +1,105 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeVolumeScalerLimits implements VolumeScalerLimitInterface
+type FakeVolumeScalerLimits struct {
+	Fake *FakeAutoscalingV1alpha1
+}
+
+var volumescalerlimitsResource = v1alpha1.SchemeGroupVersion.WithResource("volumescalerlimits")
+
+var volumescalerlimitsKind = v1alpha1.SchemeGroupVersion.WithKind("VolumeScalerLimit")
+
+// Get takes name of the volumeScalerLimit, and returns the corresponding volumeScalerLimit object, and an error if there is any.
+func (c *FakeVolumeScalerLimits) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.VolumeScalerLimit, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(volumescalerlimitsResource, name), &v1alpha1.VolumeScalerLimit{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VolumeScalerLimit), err
+}
+
+// List takes label and field selectors, and returns the list of VolumeScalerLimits that match those selectors.
+func (c *FakeVolumeScalerLimits) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.VolumeScalerLimitList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(volumescalerlimitsResource, volumescalerlimitsKind, opts), &v1alpha1.VolumeScalerLimitList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.VolumeScalerLimitList{ListMeta: obj.(*v1alpha1.VolumeScalerLimitList).ListMeta}
+	for _, item := range obj.(*v1alpha1.VolumeScalerLimitList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested volumeScalerLimits.
+func (c *FakeVolumeScalerLimits) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(volumescalerlimitsResource, opts))
+}
+
+// Create takes the representation of a volumeScalerLimit and creates it.  Returns the server's representation of the volumeScalerLimit, and an error, if there is any.
+func (c *FakeVolumeScalerLimits) Create(ctx context.Context, volumeScalerLimit *v1alpha1.VolumeScalerLimit, opts v1.CreateOptions) (result *v1alpha1.VolumeScalerLimit, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(volumescalerlimitsResource, volumeScalerLimit), &v1alpha1.VolumeScalerLimit{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VolumeScalerLimit), err
+}
+
+// Update takes the representation of a volumeScalerLimit and updates it. Returns the server's representation of the volumeScalerLimit, and an error, if there is any.
+func (c *FakeVolumeScalerLimits) Update(ctx context.Context, volumeScalerLimit *v1alpha1.VolumeScalerLimit, opts v1.UpdateOptions) (result *v1alpha1.VolumeScalerLimit, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(volumescalerlimitsResource, volumeScalerLimit), &v1alpha1.VolumeScalerLimit{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VolumeScalerLimit), err
+}
+
+// Delete takes name of the volumeScalerLimit and deletes it. Returns an error if one occurs.
+func (c *FakeVolumeScalerLimits) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(volumescalerlimitsResource, name, opts), &v1alpha1.VolumeScalerLimit{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeVolumeScalerLimits) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(volumescalerlimitsResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.VolumeScalerLimitList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched volumeScalerLimit.
+func (c *FakeVolumeScalerLimits) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.VolumeScalerLimit, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(volumescalerlimitsResource, name, pt, data, subresources...), &v1alpha1.VolumeScalerLimit{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VolumeScalerLimit), err
+}
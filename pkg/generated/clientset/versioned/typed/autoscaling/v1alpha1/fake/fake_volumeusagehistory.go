@@ -0,0 +1,125 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeVolumeUsageHistories implements VolumeUsageHistoryInterface
+type FakeVolumeUsageHistories struct {
+	Fake *FakeAutoscalingV1alpha1
+	ns   string
+}
+
+var volumeusagehistoriesResource = v1alpha1.SchemeGroupVersion.WithResource("volumeusagehistories")
+
+var volumeusagehistoriesKind = v1alpha1.SchemeGroupVersion.WithKind("VolumeUsageHistory")
+
+// Get takes name of the volumeUsageHistory, and returns the corresponding volumeUsageHistory object, and an error if there is any.
+func (c *FakeVolumeUsageHistories) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.VolumeUsageHistory, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(volumeusagehistoriesResource, c.ns, name), &v1alpha1.VolumeUsageHistory{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VolumeUsageHistory), err
+}
+
+// List takes label and field selectors, and returns the list of VolumeUsageHistories that match those selectors.
+func (c *FakeVolumeUsageHistories) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.VolumeUsageHistoryList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(volumeusagehistoriesResource, volumeusagehistoriesKind, c.ns, opts), &v1alpha1.VolumeUsageHistoryList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.VolumeUsageHistoryList{ListMeta: obj.(*v1alpha1.VolumeUsageHistoryList).ListMeta}
+	for _, item := range obj.(*v1alpha1.VolumeUsageHistoryList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested volumeUsageHistories.
+func (c *FakeVolumeUsageHistories) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(volumeusagehistoriesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a volumeUsageHistory and creates it.  Returns the server's representation of the volumeUsageHistory, and an error, if there is any.
+func (c *FakeVolumeUsageHistories) Create(ctx context.Context, volumeUsageHistory *v1alpha1.VolumeUsageHistory, opts v1.CreateOptions) (result *v1alpha1.VolumeUsageHistory, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(volumeusagehistoriesResource, c.ns, volumeUsageHistory), &v1alpha1.VolumeUsageHistory{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VolumeUsageHistory), err
+}
+
+// Update takes the representation of a volumeUsageHistory and updates it. Returns the server's representation of the volumeUsageHistory, and an error, if there is any.
+func (c *FakeVolumeUsageHistories) Update(ctx context.Context, volumeUsageHistory *v1alpha1.VolumeUsageHistory, opts v1.UpdateOptions) (result *v1alpha1.VolumeUsageHistory, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(volumeusagehistoriesResource, c.ns, volumeUsageHistory), &v1alpha1.VolumeUsageHistory{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VolumeUsageHistory), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeVolumeUsageHistories) UpdateStatus(ctx context.Context, volumeUsageHistory *v1alpha1.VolumeUsageHistory, opts v1.UpdateOptions) (*v1alpha1.VolumeUsageHistory, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(volumeusagehistoriesResource, "status", c.ns, volumeUsageHistory), &v1alpha1.VolumeUsageHistory{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VolumeUsageHistory), err
+}
+
+// Delete takes name of the volumeUsageHistory and deletes it. Returns an error if one occurs.
+func (c *FakeVolumeUsageHistories) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(volumeusagehistoriesResource, c.ns, name, opts), &v1alpha1.VolumeUsageHistory{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeVolumeUsageHistories) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(volumeusagehistoriesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.VolumeUsageHistoryList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched volumeUsageHistory.
+func (c *FakeVolumeUsageHistories) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.VolumeUsageHistory, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(volumeusagehistoriesResource, c.ns, name, pt, data, subresources...), &v1alpha1.VolumeUsageHistory{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VolumeUsageHistory), err
+}
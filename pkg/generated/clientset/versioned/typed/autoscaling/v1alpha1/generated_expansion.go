@@ -0,0 +1,15 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+type VolumeRecommendationExpansion interface{}
+
+type VolumeScaleEventExpansion interface{}
+
+type VolumeScaleRequestExpansion interface{}
+
+type VolumeScalerExpansion interface{}
+
+type VolumeScalerLimitExpansion interface{}
+
+type VolumeUsageHistoryExpansion interface{}
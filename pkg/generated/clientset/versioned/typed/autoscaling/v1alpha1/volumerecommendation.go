@@ -0,0 +1,179 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	scheme "github.com/zghanem/sample-volumeScaler/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// VolumeRecommendationsGetter has a method to return a VolumeRecommendationInterface.
+// A group's client should implement this interface.
+type VolumeRecommendationsGetter interface {
+	VolumeRecommendations(namespace string) VolumeRecommendationInterface
+}
+
+// VolumeRecommendationInterface has methods to work with VolumeRecommendation resources.
+type VolumeRecommendationInterface interface {
+	Create(ctx context.Context, volumeRecommendation *v1alpha1.VolumeRecommendation, opts v1.CreateOptions) (*v1alpha1.VolumeRecommendation, error)
+	Update(ctx context.Context, volumeRecommendation *v1alpha1.VolumeRecommendation, opts v1.UpdateOptions) (*v1alpha1.VolumeRecommendation, error)
+	UpdateStatus(ctx context.Context, volumeRecommendation *v1alpha1.VolumeRecommendation, opts v1.UpdateOptions) (*v1alpha1.VolumeRecommendation, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.VolumeRecommendation, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.VolumeRecommendationList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.VolumeRecommendation, err error)
+	VolumeRecommendationExpansion
+}
+
+// volumeRecommendations implements VolumeRecommendationInterface
+type volumeRecommendations struct {
+	client rest.Interface
+	ns     string
+}
+
+// newVolumeRecommendations returns a VolumeRecommendations
+func newVolumeRecommendations(c *AutoscalingV1alpha1Client, namespace string) *volumeRecommendations {
+	return &volumeRecommendations{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the volumeRecommendation, and returns the corresponding volumeRecommendation object, and an error if there is any.
+func (c *volumeRecommendations) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.VolumeRecommendation, err error) {
+	result = &v1alpha1.VolumeRecommendation{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("volumerecommendations").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of VolumeRecommendations that match those selectors.
+func (c *volumeRecommendations) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.VolumeRecommendationList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.VolumeRecommendationList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("volumerecommendations").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested volumeRecommendations.
+func (c *volumeRecommendations) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("volumerecommendations").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a volumeRecommendation and creates it.  Returns the server's representation of the volumeRecommendation, and an error, if there is any.
+func (c *volumeRecommendations) Create(ctx context.Context, volumeRecommendation *v1alpha1.VolumeRecommendation, opts v1.CreateOptions) (result *v1alpha1.VolumeRecommendation, err error) {
+	result = &v1alpha1.VolumeRecommendation{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("volumerecommendations").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(volumeRecommendation).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a volumeRecommendation and updates it. Returns the server's representation of the volumeRecommendation, and an error, if there is any.
+func (c *volumeRecommendations) Update(ctx context.Context, volumeRecommendation *v1alpha1.VolumeRecommendation, opts v1.UpdateOptions) (result *v1alpha1.VolumeRecommendation, err error) {
+	result = &v1alpha1.VolumeRecommendation{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("volumerecommendations").
+		Name(volumeRecommendation.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(volumeRecommendation).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *volumeRecommendations) UpdateStatus(ctx context.Context, volumeRecommendation *v1alpha1.VolumeRecommendation, opts v1.UpdateOptions) (result *v1alpha1.VolumeRecommendation, err error) {
+	result = &v1alpha1.VolumeRecommendation{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("volumerecommendations").
+		Name(volumeRecommendation.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(volumeRecommendation).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the volumeRecommendation and deletes it. Returns an error if one occurs.
+func (c *volumeRecommendations) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("volumerecommendations").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *volumeRecommendations) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("volumerecommendations").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched volumeRecommendation.
+func (c *volumeRecommendations) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.VolumeRecommendation, err error) {
+	result = &v1alpha1.VolumeRecommendation{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("volumerecommendations").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,162 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	scheme "github.com/zghanem/sample-volumeScaler/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// VolumeScaleEventsGetter has a method to return a VolumeScaleEventInterface.
+// A group's client should implement this interface.
+type VolumeScaleEventsGetter interface {
+	VolumeScaleEvents(namespace string) VolumeScaleEventInterface
+}
+
+// VolumeScaleEventInterface has methods to work with VolumeScaleEvent resources.
+type VolumeScaleEventInterface interface {
+	Create(ctx context.Context, volumeScaleEvent *v1alpha1.VolumeScaleEvent, opts v1.CreateOptions) (*v1alpha1.VolumeScaleEvent, error)
+	Update(ctx context.Context, volumeScaleEvent *v1alpha1.VolumeScaleEvent, opts v1.UpdateOptions) (*v1alpha1.VolumeScaleEvent, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.VolumeScaleEvent, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.VolumeScaleEventList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.VolumeScaleEvent, err error)
+	VolumeScaleEventExpansion
+}
+
+// volumeScaleEvents implements VolumeScaleEventInterface
+type volumeScaleEvents struct {
+	client rest.Interface
+	ns     string
+}
+
+// newVolumeScaleEvents returns a VolumeScaleEvents
+func newVolumeScaleEvents(c *AutoscalingV1alpha1Client, namespace string) *volumeScaleEvents {
+	return &volumeScaleEvents{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the volumeScaleEvent, and returns the corresponding volumeScaleEvent object, and an error if there is any.
+func (c *volumeScaleEvents) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.VolumeScaleEvent, err error) {
+	result = &v1alpha1.VolumeScaleEvent{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("volumescaleevents").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of VolumeScaleEvents that match those selectors.
+func (c *volumeScaleEvents) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.VolumeScaleEventList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.VolumeScaleEventList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("volumescaleevents").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested volumeScaleEvents.
+func (c *volumeScaleEvents) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("volumescaleevents").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a volumeScaleEvent and creates it.  Returns the server's representation of the volumeScaleEvent, and an error, if there is any.
+func (c *volumeScaleEvents) Create(ctx context.Context, volumeScaleEvent *v1alpha1.VolumeScaleEvent, opts v1.CreateOptions) (result *v1alpha1.VolumeScaleEvent, err error) {
+	result = &v1alpha1.VolumeScaleEvent{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("volumescaleevents").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(volumeScaleEvent).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a volumeScaleEvent and updates it. Returns the server's representation of the volumeScaleEvent, and an error, if there is any.
+func (c *volumeScaleEvents) Update(ctx context.Context, volumeScaleEvent *v1alpha1.VolumeScaleEvent, opts v1.UpdateOptions) (result *v1alpha1.VolumeScaleEvent, err error) {
+	result = &v1alpha1.VolumeScaleEvent{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("volumescaleevents").
+		Name(volumeScaleEvent.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(volumeScaleEvent).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the volumeScaleEvent and deletes it. Returns an error if one occurs.
+func (c *volumeScaleEvents) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("volumescaleevents").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *volumeScaleEvents) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("volumescaleevents").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched volumeScaleEvent.
+func (c *volumeScaleEvents) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.VolumeScaleEvent, err error) {
+	result = &v1alpha1.VolumeScaleEvent{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("volumescaleevents").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
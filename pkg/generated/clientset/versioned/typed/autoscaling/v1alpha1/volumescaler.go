@@ -0,0 +1,179 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	scheme "github.com/zghanem/sample-volumeScaler/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// VolumeScalersGetter has a method to return a VolumeScalerInterface.
+// A group's client should implement this interface.
+type VolumeScalersGetter interface {
+	VolumeScalers(namespace string) VolumeScalerInterface
+}
+
+// VolumeScalerInterface has methods to work with VolumeScaler resources.
+type VolumeScalerInterface interface {
+	Create(ctx context.Context, volumeScaler *v1alpha1.VolumeScaler, opts v1.CreateOptions) (*v1alpha1.VolumeScaler, error)
+	Update(ctx context.Context, volumeScaler *v1alpha1.VolumeScaler, opts v1.UpdateOptions) (*v1alpha1.VolumeScaler, error)
+	UpdateStatus(ctx context.Context, volumeScaler *v1alpha1.VolumeScaler, opts v1.UpdateOptions) (*v1alpha1.VolumeScaler, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.VolumeScaler, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.VolumeScalerList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.VolumeScaler, err error)
+	VolumeScalerExpansion
+}
+
+// volumeScalers implements VolumeScalerInterface
+type volumeScalers struct {
+	client rest.Interface
+	ns     string
+}
+
+// newVolumeScalers returns a VolumeScalers
+func newVolumeScalers(c *AutoscalingV1alpha1Client, namespace string) *volumeScalers {
+	return &volumeScalers{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the volumeScaler, and returns the corresponding volumeScaler object, and an error if there is any.
+func (c *volumeScalers) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.VolumeScaler, err error) {
+	result = &v1alpha1.VolumeScaler{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("volumescalers").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of VolumeScalers that match those selectors.
+func (c *volumeScalers) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.VolumeScalerList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.VolumeScalerList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("volumescalers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested volumeScalers.
+func (c *volumeScalers) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("volumescalers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a volumeScaler and creates it.  Returns the server's representation of the volumeScaler, and an error, if there is any.
+func (c *volumeScalers) Create(ctx context.Context, volumeScaler *v1alpha1.VolumeScaler, opts v1.CreateOptions) (result *v1alpha1.VolumeScaler, err error) {
+	result = &v1alpha1.VolumeScaler{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("volumescalers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(volumeScaler).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a volumeScaler and updates it. Returns the server's representation of the volumeScaler, and an error, if there is any.
+func (c *volumeScalers) Update(ctx context.Context, volumeScaler *v1alpha1.VolumeScaler, opts v1.UpdateOptions) (result *v1alpha1.VolumeScaler, err error) {
+	result = &v1alpha1.VolumeScaler{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("volumescalers").
+		Name(volumeScaler.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(volumeScaler).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *volumeScalers) UpdateStatus(ctx context.Context, volumeScaler *v1alpha1.VolumeScaler, opts v1.UpdateOptions) (result *v1alpha1.VolumeScaler, err error) {
+	result = &v1alpha1.VolumeScaler{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("volumescalers").
+		Name(volumeScaler.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(volumeScaler).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the volumeScaler and deletes it. Returns an error if one occurs.
+func (c *volumeScalers) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("volumescalers").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *volumeScalers) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("volumescalers").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched volumeScaler.
+func (c *volumeScalers) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.VolumeScaler, err error) {
+	result = &v1alpha1.VolumeScaler{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("volumescalers").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,179 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	scheme "github.com/zghanem/sample-volumeScaler/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// VolumeScaleRequestsGetter has a method to return a VolumeScaleRequestInterface.
+// A group's client should implement this interface.
+type VolumeScaleRequestsGetter interface {
+	VolumeScaleRequests(namespace string) VolumeScaleRequestInterface
+}
+
+// VolumeScaleRequestInterface has methods to work with VolumeScaleRequest resources.
+type VolumeScaleRequestInterface interface {
+	Create(ctx context.Context, volumeScaleRequest *v1alpha1.VolumeScaleRequest, opts v1.CreateOptions) (*v1alpha1.VolumeScaleRequest, error)
+	Update(ctx context.Context, volumeScaleRequest *v1alpha1.VolumeScaleRequest, opts v1.UpdateOptions) (*v1alpha1.VolumeScaleRequest, error)
+	UpdateStatus(ctx context.Context, volumeScaleRequest *v1alpha1.VolumeScaleRequest, opts v1.UpdateOptions) (*v1alpha1.VolumeScaleRequest, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.VolumeScaleRequest, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.VolumeScaleRequestList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.VolumeScaleRequest, err error)
+	VolumeScaleRequestExpansion
+}
+
+// volumeScaleRequests implements VolumeScaleRequestInterface
+type volumeScaleRequests struct {
+	client rest.Interface
+	ns     string
+}
+
+// newVolumeScaleRequests returns a VolumeScaleRequests
+func newVolumeScaleRequests(c *AutoscalingV1alpha1Client, namespace string) *volumeScaleRequests {
+	return &volumeScaleRequests{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the volumeScaleRequest, and returns the corresponding volumeScaleRequest object, and an error if there is any.
+func (c *volumeScaleRequests) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.VolumeScaleRequest, err error) {
+	result = &v1alpha1.VolumeScaleRequest{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("volumescalerequests").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of VolumeScaleRequests that match those selectors.
+func (c *volumeScaleRequests) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.VolumeScaleRequestList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.VolumeScaleRequestList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("volumescalerequests").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested volumeScaleRequests.
+func (c *volumeScaleRequests) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("volumescalerequests").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a volumeScaleRequest and creates it.  Returns the server's representation of the volumeScaleRequest, and an error, if there is any.
+func (c *volumeScaleRequests) Create(ctx context.Context, volumeScaleRequest *v1alpha1.VolumeScaleRequest, opts v1.CreateOptions) (result *v1alpha1.VolumeScaleRequest, err error) {
+	result = &v1alpha1.VolumeScaleRequest{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("volumescalerequests").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(volumeScaleRequest).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a volumeScaleRequest and updates it. Returns the server's representation of the volumeScaleRequest, and an error, if there is any.
+func (c *volumeScaleRequests) Update(ctx context.Context, volumeScaleRequest *v1alpha1.VolumeScaleRequest, opts v1.UpdateOptions) (result *v1alpha1.VolumeScaleRequest, err error) {
+	result = &v1alpha1.VolumeScaleRequest{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("volumescalerequests").
+		Name(volumeScaleRequest.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(volumeScaleRequest).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *volumeScaleRequests) UpdateStatus(ctx context.Context, volumeScaleRequest *v1alpha1.VolumeScaleRequest, opts v1.UpdateOptions) (result *v1alpha1.VolumeScaleRequest, err error) {
+	result = &v1alpha1.VolumeScaleRequest{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("volumescalerequests").
+		Name(volumeScaleRequest.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(volumeScaleRequest).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the volumeScaleRequest and deletes it. Returns an error if one occurs.
+func (c *volumeScaleRequests) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("volumescalerequests").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *volumeScaleRequests) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("volumescalerequests").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched volumeScaleRequest.
+func (c *volumeScaleRequests) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.VolumeScaleRequest, err error) {
+	result = &v1alpha1.VolumeScaleRequest{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("volumescalerequests").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,152 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	scheme "github.com/zghanem/sample-volumeScaler/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// VolumeScalerLimitsGetter has a method to return a VolumeScalerLimitInterface.
+// A group's client should implement this interface.
+type VolumeScalerLimitsGetter interface {
+	VolumeScalerLimits() VolumeScalerLimitInterface
+}
+
+// VolumeScalerLimitInterface has methods to work with VolumeScalerLimit resources.
+type VolumeScalerLimitInterface interface {
+	Create(ctx context.Context, volumeScalerLimit *v1alpha1.VolumeScalerLimit, opts v1.CreateOptions) (*v1alpha1.VolumeScalerLimit, error)
+	Update(ctx context.Context, volumeScalerLimit *v1alpha1.VolumeScalerLimit, opts v1.UpdateOptions) (*v1alpha1.VolumeScalerLimit, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.VolumeScalerLimit, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.VolumeScalerLimitList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.VolumeScalerLimit, err error)
+	VolumeScalerLimitExpansion
+}
+
+// volumeScalerLimits implements VolumeScalerLimitInterface
+type volumeScalerLimits struct {
+	client rest.Interface
+}
+
+// newVolumeScalerLimits returns a VolumeScalerLimits
+func newVolumeScalerLimits(c *AutoscalingV1alpha1Client) *volumeScalerLimits {
+	return &volumeScalerLimits{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the volumeScalerLimit, and returns the corresponding volumeScalerLimit object, and an error if there is any.
+func (c *volumeScalerLimits) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.VolumeScalerLimit, err error) {
+	result = &v1alpha1.VolumeScalerLimit{}
+	err = c.client.Get().
+		Resource("volumescalerlimits").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of VolumeScalerLimits that match those selectors.
+func (c *volumeScalerLimits) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.VolumeScalerLimitList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.VolumeScalerLimitList{}
+	err = c.client.Get().
+		Resource("volumescalerlimits").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested volumeScalerLimits.
+func (c *volumeScalerLimits) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("volumescalerlimits").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a volumeScalerLimit and creates it.  Returns the server's representation of the volumeScalerLimit, and an error, if there is any.
+func (c *volumeScalerLimits) Create(ctx context.Context, volumeScalerLimit *v1alpha1.VolumeScalerLimit, opts v1.CreateOptions) (result *v1alpha1.VolumeScalerLimit, err error) {
+	result = &v1alpha1.VolumeScalerLimit{}
+	err = c.client.Post().
+		Resource("volumescalerlimits").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(volumeScalerLimit).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a volumeScalerLimit and updates it. Returns the server's representation of the volumeScalerLimit, and an error, if there is any.
+func (c *volumeScalerLimits) Update(ctx context.Context, volumeScalerLimit *v1alpha1.VolumeScalerLimit, opts v1.UpdateOptions) (result *v1alpha1.VolumeScalerLimit, err error) {
+	result = &v1alpha1.VolumeScalerLimit{}
+	err = c.client.Put().
+		Resource("volumescalerlimits").
+		Name(volumeScalerLimit.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(volumeScalerLimit).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the volumeScalerLimit and deletes it. Returns an error if one occurs.
+func (c *volumeScalerLimits) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("volumescalerlimits").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *volumeScalerLimits) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("volumescalerlimits").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched volumeScalerLimit.
+func (c *volumeScalerLimits) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.VolumeScalerLimit, err error) {
+	result = &v1alpha1.VolumeScalerLimit{}
+	err = c.client.Patch(pt).
+		Resource("volumescalerlimits").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
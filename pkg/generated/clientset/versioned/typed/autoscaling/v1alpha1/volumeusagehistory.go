@@ -0,0 +1,179 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	scheme "github.com/zghanem/sample-volumeScaler/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// VolumeUsageHistoriesGetter has a method to return a VolumeUsageHistoryInterface.
+// A group's client should implement this interface.
+type VolumeUsageHistoriesGetter interface {
+	VolumeUsageHistories(namespace string) VolumeUsageHistoryInterface
+}
+
+// VolumeUsageHistoryInterface has methods to work with VolumeUsageHistory resources.
+type VolumeUsageHistoryInterface interface {
+	Create(ctx context.Context, volumeUsageHistory *v1alpha1.VolumeUsageHistory, opts v1.CreateOptions) (*v1alpha1.VolumeUsageHistory, error)
+	Update(ctx context.Context, volumeUsageHistory *v1alpha1.VolumeUsageHistory, opts v1.UpdateOptions) (*v1alpha1.VolumeUsageHistory, error)
+	UpdateStatus(ctx context.Context, volumeUsageHistory *v1alpha1.VolumeUsageHistory, opts v1.UpdateOptions) (*v1alpha1.VolumeUsageHistory, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.VolumeUsageHistory, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.VolumeUsageHistoryList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.VolumeUsageHistory, err error)
+	VolumeUsageHistoryExpansion
+}
+
+// volumeUsageHistories implements VolumeUsageHistoryInterface
+type volumeUsageHistories struct {
+	client rest.Interface
+	ns     string
+}
+
+// newVolumeUsageHistories returns a VolumeUsageHistories
+func newVolumeUsageHistories(c *AutoscalingV1alpha1Client, namespace string) *volumeUsageHistories {
+	return &volumeUsageHistories{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the volumeUsageHistory, and returns the corresponding volumeUsageHistory object, and an error if there is any.
+func (c *volumeUsageHistories) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.VolumeUsageHistory, err error) {
+	result = &v1alpha1.VolumeUsageHistory{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("volumeusagehistories").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of VolumeUsageHistories that match those selectors.
+func (c *volumeUsageHistories) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.VolumeUsageHistoryList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.VolumeUsageHistoryList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("volumeusagehistories").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested volumeUsageHistories.
+func (c *volumeUsageHistories) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("volumeusagehistories").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a volumeUsageHistory and creates it.  Returns the server's representation of the volumeUsageHistory, and an error, if there is any.
+func (c *volumeUsageHistories) Create(ctx context.Context, volumeUsageHistory *v1alpha1.VolumeUsageHistory, opts v1.CreateOptions) (result *v1alpha1.VolumeUsageHistory, err error) {
+	result = &v1alpha1.VolumeUsageHistory{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("volumeusagehistories").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(volumeUsageHistory).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a volumeUsageHistory and updates it. Returns the server's representation of the volumeUsageHistory, and an error, if there is any.
+func (c *volumeUsageHistories) Update(ctx context.Context, volumeUsageHistory *v1alpha1.VolumeUsageHistory, opts v1.UpdateOptions) (result *v1alpha1.VolumeUsageHistory, err error) {
+	result = &v1alpha1.VolumeUsageHistory{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("volumeusagehistories").
+		Name(volumeUsageHistory.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(volumeUsageHistory).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *volumeUsageHistories) UpdateStatus(ctx context.Context, volumeUsageHistory *v1alpha1.VolumeUsageHistory, opts v1.UpdateOptions) (result *v1alpha1.VolumeUsageHistory, err error) {
+	result = &v1alpha1.VolumeUsageHistory{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("volumeusagehistories").
+		Name(volumeUsageHistory.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(volumeUsageHistory).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the volumeUsageHistory and deletes it. Returns an error if one occurs.
+func (c *volumeUsageHistories) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("volumeusagehistories").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *volumeUsageHistories) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("volumeusagehistories").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched volumeUsageHistory.
+func (c *volumeUsageHistories) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.VolumeUsageHistory, err error) {
+	result = &v1alpha1.VolumeUsageHistory{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("volumeusagehistories").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,24 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	v1beta1 "github.com/zghanem/sample-volumeScaler/pkg/generated/clientset/versioned/typed/autoscaling/v1beta1"
+	rest "k8s.io/client-go/rest"
+	testing "k8s.io/client-go/testing"
+)
+
+type FakeAutoscalingV1beta1 struct {
+	*testing.Fake
+}
+
+func (c *FakeAutoscalingV1beta1) VolumeScalers(namespace string) v1beta1.VolumeScalerInterface {
+	return &FakeVolumeScalers{c, namespace}
+}
+
+// RESTClient returns a RESTClient that is used to communicate
+// with API server by this client implementation.
+func (c *FakeAutoscalingV1beta1) RESTClient() rest.Interface {
+	var ret *rest.RESTClient
+	return ret
+}
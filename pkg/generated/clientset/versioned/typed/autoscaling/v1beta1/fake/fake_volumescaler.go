@@ -0,0 +1,125 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1beta1 "github.com/zghanem/sample-volumeScaler/api/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeVolumeScalers implements VolumeScalerInterface
+type FakeVolumeScalers struct {
+	Fake *FakeAutoscalingV1beta1
+	ns   string
+}
+
+var volumescalersResource = v1beta1.SchemeGroupVersion.WithResource("volumescalers")
+
+var volumescalersKind = v1beta1.SchemeGroupVersion.WithKind("VolumeScaler")
+
+// Get takes name of the volumeScaler, and returns the corresponding volumeScaler object, and an error if there is any.
+func (c *FakeVolumeScalers) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1beta1.VolumeScaler, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(volumescalersResource, c.ns, name), &v1beta1.VolumeScaler{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VolumeScaler), err
+}
+
+// List takes label and field selectors, and returns the list of VolumeScalers that match those selectors.
+func (c *FakeVolumeScalers) List(ctx context.Context, opts v1.ListOptions) (result *v1beta1.VolumeScalerList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(volumescalersResource, volumescalersKind, c.ns, opts), &v1beta1.VolumeScalerList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1beta1.VolumeScalerList{ListMeta: obj.(*v1beta1.VolumeScalerList).ListMeta}
+	for _, item := range obj.(*v1beta1.VolumeScalerList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested volumeScalers.
+func (c *FakeVolumeScalers) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(volumescalersResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a volumeScaler and creates it.  Returns the server's representation of the volumeScaler, and an error, if there is any.
+func (c *FakeVolumeScalers) Create(ctx context.Context, volumeScaler *v1beta1.VolumeScaler, opts v1.CreateOptions) (result *v1beta1.VolumeScaler, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(volumescalersResource, c.ns, volumeScaler), &v1beta1.VolumeScaler{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VolumeScaler), err
+}
+
+// Update takes the representation of a volumeScaler and updates it. Returns the server's representation of the volumeScaler, and an error, if there is any.
+func (c *FakeVolumeScalers) Update(ctx context.Context, volumeScaler *v1beta1.VolumeScaler, opts v1.UpdateOptions) (result *v1beta1.VolumeScaler, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(volumescalersResource, c.ns, volumeScaler), &v1beta1.VolumeScaler{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VolumeScaler), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeVolumeScalers) UpdateStatus(ctx context.Context, volumeScaler *v1beta1.VolumeScaler, opts v1.UpdateOptions) (*v1beta1.VolumeScaler, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(volumescalersResource, "status", c.ns, volumeScaler), &v1beta1.VolumeScaler{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VolumeScaler), err
+}
+
+// Delete takes name of the volumeScaler and deletes it. Returns an error if one occurs.
+func (c *FakeVolumeScalers) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(volumescalersResource, c.ns, name, opts), &v1beta1.VolumeScaler{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeVolumeScalers) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(volumescalersResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1beta1.VolumeScalerList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched volumeScaler.
+func (c *FakeVolumeScalers) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.VolumeScaler, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(volumescalersResource, c.ns, name, pt, data, subresources...), &v1beta1.VolumeScaler{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.VolumeScaler), err
+}
@@ -0,0 +1,64 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	internalinterfaces "github.com/zghanem/sample-volumeScaler/pkg/generated/informers/externalversions/internalinterfaces"
+)
+
+// Interface provides access to all the informers in this group version.
+type Interface interface {
+	// VolumeRecommendations returns a VolumeRecommendationInformer.
+	VolumeRecommendations() VolumeRecommendationInformer
+	// VolumeScaleEvents returns a VolumeScaleEventInformer.
+	VolumeScaleEvents() VolumeScaleEventInformer
+	// VolumeScaleRequests returns a VolumeScaleRequestInformer.
+	VolumeScaleRequests() VolumeScaleRequestInformer
+	// VolumeScalers returns a VolumeScalerInformer.
+	VolumeScalers() VolumeScalerInformer
+	// VolumeScalerLimits returns a VolumeScalerLimitInformer.
+	VolumeScalerLimits() VolumeScalerLimitInformer
+	// VolumeUsageHistories returns a VolumeUsageHistoryInformer.
+	VolumeUsageHistories() VolumeUsageHistoryInformer
+}
+
+type version struct {
+	factory          internalinterfaces.SharedInformerFactory
+	namespace        string
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// New returns a new Interface.
+func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakListOptions internalinterfaces.TweakListOptionsFunc) Interface {
+	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
+}
+
+// VolumeRecommendations returns a VolumeRecommendationInformer.
+func (v *version) VolumeRecommendations() VolumeRecommendationInformer {
+	return &volumeRecommendationInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// VolumeScaleEvents returns a VolumeScaleEventInformer.
+func (v *version) VolumeScaleEvents() VolumeScaleEventInformer {
+	return &volumeScaleEventInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// VolumeScaleRequests returns a VolumeScaleRequestInformer.
+func (v *version) VolumeScaleRequests() VolumeScaleRequestInformer {
+	return &volumeScaleRequestInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// VolumeScalers returns a VolumeScalerInformer.
+func (v *version) VolumeScalers() VolumeScalerInformer {
+	return &volumeScalerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// VolumeScalerLimits returns a VolumeScalerLimitInformer.
+func (v *version) VolumeScalerLimits() VolumeScalerLimitInformer {
+	return &volumeScalerLimitInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// VolumeUsageHistories returns a VolumeUsageHistoryInformer.
+func (v *version) VolumeUsageHistories() VolumeUsageHistoryInformer {
+	return &volumeUsageHistoryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
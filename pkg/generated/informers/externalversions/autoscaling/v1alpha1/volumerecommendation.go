@@ -0,0 +1,74 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	autoscalingv1alpha1 "github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	versioned "github.com/zghanem/sample-volumeScaler/pkg/generated/clientset/versioned"
+	internalinterfaces "github.com/zghanem/sample-volumeScaler/pkg/generated/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/zghanem/sample-volumeScaler/pkg/generated/listers/autoscaling/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// VolumeRecommendationInformer provides access to a shared informer and lister for
+// VolumeRecommendations.
+type VolumeRecommendationInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.VolumeRecommendationLister
+}
+
+type volumeRecommendationInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewVolumeRecommendationInformer constructs a new informer for VolumeRecommendation type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewVolumeRecommendationInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredVolumeRecommendationInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredVolumeRecommendationInformer constructs a new informer for VolumeRecommendation type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredVolumeRecommendationInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AutoscalingV1alpha1().VolumeRecommendations(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AutoscalingV1alpha1().VolumeRecommendations(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&autoscalingv1alpha1.VolumeRecommendation{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *volumeRecommendationInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredVolumeRecommendationInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *volumeRecommendationInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&autoscalingv1alpha1.VolumeRecommendation{}, f.defaultInformer)
+}
+
+func (f *volumeRecommendationInformer) Lister() v1alpha1.VolumeRecommendationLister {
+	return v1alpha1.NewVolumeRecommendationLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,74 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	autoscalingv1alpha1 "github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	versioned "github.com/zghanem/sample-volumeScaler/pkg/generated/clientset/versioned"
+	internalinterfaces "github.com/zghanem/sample-volumeScaler/pkg/generated/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/zghanem/sample-volumeScaler/pkg/generated/listers/autoscaling/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// VolumeScalerInformer provides access to a shared informer and lister for
+// VolumeScalers.
+type VolumeScalerInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.VolumeScalerLister
+}
+
+type volumeScalerInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewVolumeScalerInformer constructs a new informer for VolumeScaler type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewVolumeScalerInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredVolumeScalerInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredVolumeScalerInformer constructs a new informer for VolumeScaler type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredVolumeScalerInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AutoscalingV1alpha1().VolumeScalers(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AutoscalingV1alpha1().VolumeScalers(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&autoscalingv1alpha1.VolumeScaler{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *volumeScalerInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredVolumeScalerInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *volumeScalerInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&autoscalingv1alpha1.VolumeScaler{}, f.defaultInformer)
+}
+
+func (f *volumeScalerInformer) Lister() v1alpha1.VolumeScalerLister {
+	return v1alpha1.NewVolumeScalerLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,74 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	autoscalingv1alpha1 "github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	versioned "github.com/zghanem/sample-volumeScaler/pkg/generated/clientset/versioned"
+	internalinterfaces "github.com/zghanem/sample-volumeScaler/pkg/generated/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/zghanem/sample-volumeScaler/pkg/generated/listers/autoscaling/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// VolumeScaleRequestInformer provides access to a shared informer and lister for
+// VolumeScaleRequests.
+type VolumeScaleRequestInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.VolumeScaleRequestLister
+}
+
+type volumeScaleRequestInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewVolumeScaleRequestInformer constructs a new informer for VolumeScaleRequest type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewVolumeScaleRequestInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredVolumeScaleRequestInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredVolumeScaleRequestInformer constructs a new informer for VolumeScaleRequest type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredVolumeScaleRequestInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AutoscalingV1alpha1().VolumeScaleRequests(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AutoscalingV1alpha1().VolumeScaleRequests(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&autoscalingv1alpha1.VolumeScaleRequest{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *volumeScaleRequestInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredVolumeScaleRequestInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *volumeScaleRequestInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&autoscalingv1alpha1.VolumeScaleRequest{}, f.defaultInformer)
+}
+
+func (f *volumeScaleRequestInformer) Lister() v1alpha1.VolumeScaleRequestLister {
+	return v1alpha1.NewVolumeScaleRequestLister(f.Informer().GetIndexer())
+}
@@ -0,0 +1,73 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	autoscalingv1alpha1 "github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	versioned "github.com/zghanem/sample-volumeScaler/pkg/generated/clientset/versioned"
+	internalinterfaces "github.com/zghanem/sample-volumeScaler/pkg/generated/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/zghanem/sample-volumeScaler/pkg/generated/listers/autoscaling/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// VolumeScalerLimitInformer provides access to a shared informer and lister for
+// VolumeScalerLimits.
+type VolumeScalerLimitInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.VolumeScalerLimitLister
+}
+
+type volumeScalerLimitInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewVolumeScalerLimitInformer constructs a new informer for VolumeScalerLimit type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewVolumeScalerLimitInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredVolumeScalerLimitInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredVolumeScalerLimitInformer constructs a new informer for VolumeScalerLimit type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredVolumeScalerLimitInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AutoscalingV1alpha1().VolumeScalerLimits().List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.AutoscalingV1alpha1().VolumeScalerLimits().Watch(context.TODO(), options)
+			},
+		},
+		&autoscalingv1alpha1.VolumeScalerLimit{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *volumeScalerLimitInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredVolumeScalerLimitInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *volumeScalerLimitInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&autoscalingv1alpha1.VolumeScalerLimit{}, f.defaultInformer)
+}
+
+func (f *volumeScalerLimitInformer) Lister() v1alpha1.VolumeScalerLimitLister {
+	return v1alpha1.NewVolumeScalerLimitLister(f.Informer().GetIndexer())
+}
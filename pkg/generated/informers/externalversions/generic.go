@@ -0,0 +1,61 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package externalversions
+
+import (
+	"fmt"
+
+	v1alpha1 "github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	v1beta1 "github.com/zghanem/sample-volumeScaler/api/v1beta1"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// GenericInformer is type of SharedIndexInformer which will locate and delegate to other
+// sharedInformers based on type
+type GenericInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() cache.GenericLister
+}
+
+type genericInformer struct {
+	informer cache.SharedIndexInformer
+	resource schema.GroupResource
+}
+
+// Informer returns the SharedIndexInformer.
+func (f *genericInformer) Informer() cache.SharedIndexInformer {
+	return f.informer
+}
+
+// Lister returns the GenericLister.
+func (f *genericInformer) Lister() cache.GenericLister {
+	return cache.NewGenericLister(f.Informer().GetIndexer(), f.resource)
+}
+
+// ForResource gives generic access to a shared informer of the matching type
+// TODO extend this to unknown resources with a client pool
+func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
+	switch resource {
+	// Group=autoscaling.storage.k8s.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("volumerecommendations"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Autoscaling().V1alpha1().VolumeRecommendations().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("volumescaleevents"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Autoscaling().V1alpha1().VolumeScaleEvents().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("volumescalerequests"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Autoscaling().V1alpha1().VolumeScaleRequests().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("volumescalers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Autoscaling().V1alpha1().VolumeScalers().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("volumescalerlimits"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Autoscaling().V1alpha1().VolumeScalerLimits().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("volumeusagehistories"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Autoscaling().V1alpha1().VolumeUsageHistories().Informer()}, nil
+
+		// Group=autoscaling.storage.k8s.io, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithResource("volumescalers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Autoscaling().V1beta1().VolumeScalers().Informer()}, nil
+
+	}
+
+	return nil, fmt.Errorf("no informer found for %v", resource)
+}
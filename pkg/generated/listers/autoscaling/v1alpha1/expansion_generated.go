@@ -0,0 +1,47 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// VolumeRecommendationListerExpansion allows custom methods to be added to
+// VolumeRecommendationLister.
+type VolumeRecommendationListerExpansion interface{}
+
+// VolumeRecommendationNamespaceListerExpansion allows custom methods to be added to
+// VolumeRecommendationNamespaceLister.
+type VolumeRecommendationNamespaceListerExpansion interface{}
+
+// VolumeScaleEventListerExpansion allows custom methods to be added to
+// VolumeScaleEventLister.
+type VolumeScaleEventListerExpansion interface{}
+
+// VolumeScaleEventNamespaceListerExpansion allows custom methods to be added to
+// VolumeScaleEventNamespaceLister.
+type VolumeScaleEventNamespaceListerExpansion interface{}
+
+// VolumeScaleRequestListerExpansion allows custom methods to be added to
+// VolumeScaleRequestLister.
+type VolumeScaleRequestListerExpansion interface{}
+
+// VolumeScaleRequestNamespaceListerExpansion allows custom methods to be added to
+// VolumeScaleRequestNamespaceLister.
+type VolumeScaleRequestNamespaceListerExpansion interface{}
+
+// VolumeScalerListerExpansion allows custom methods to be added to
+// VolumeScalerLister.
+type VolumeScalerListerExpansion interface{}
+
+// VolumeScalerNamespaceListerExpansion allows custom methods to be added to
+// VolumeScalerNamespaceLister.
+type VolumeScalerNamespaceListerExpansion interface{}
+
+// VolumeScalerLimitListerExpansion allows custom methods to be added to
+// VolumeScalerLimitLister.
+type VolumeScalerLimitListerExpansion interface{}
+
+// VolumeUsageHistoryListerExpansion allows custom methods to be added to
+// VolumeUsageHistoryLister.
+type VolumeUsageHistoryListerExpansion interface{}
+
+// VolumeUsageHistoryNamespaceListerExpansion allows custom methods to be added to
+// VolumeUsageHistoryNamespaceLister.
+type VolumeUsageHistoryNamespaceListerExpansion interface{}
@@ -0,0 +1,83 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// VolumeRecommendationLister helps list VolumeRecommendations.
+// All objects returned here must be treated as read-only.
+type VolumeRecommendationLister interface {
+	// List lists all VolumeRecommendations in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.VolumeRecommendation, err error)
+	// VolumeRecommendations returns an object that can list and get VolumeRecommendations.
+	VolumeRecommendations(namespace string) VolumeRecommendationNamespaceLister
+	VolumeRecommendationListerExpansion
+}
+
+// volumeRecommendationLister implements the VolumeRecommendationLister interface.
+type volumeRecommendationLister struct {
+	indexer cache.Indexer
+}
+
+// NewVolumeRecommendationLister returns a new VolumeRecommendationLister.
+func NewVolumeRecommendationLister(indexer cache.Indexer) VolumeRecommendationLister {
+	return &volumeRecommendationLister{indexer: indexer}
+}
+
+// List lists all VolumeRecommendations in the indexer.
+func (s *volumeRecommendationLister) List(selector labels.Selector) (ret []*v1alpha1.VolumeRecommendation, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.VolumeRecommendation))
+	})
+	return ret, err
+}
+
+// VolumeRecommendations returns an object that can list and get VolumeRecommendations.
+func (s *volumeRecommendationLister) VolumeRecommendations(namespace string) VolumeRecommendationNamespaceLister {
+	return volumeRecommendationNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// VolumeRecommendationNamespaceLister helps list and get VolumeRecommendations.
+// All objects returned here must be treated as read-only.
+type VolumeRecommendationNamespaceLister interface {
+	// List lists all VolumeRecommendations in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.VolumeRecommendation, err error)
+	// Get retrieves the VolumeRecommendation from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.VolumeRecommendation, error)
+	VolumeRecommendationNamespaceListerExpansion
+}
+
+// volumeRecommendationNamespaceLister implements the VolumeRecommendationNamespaceLister
+// interface.
+type volumeRecommendationNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all VolumeRecommendations in the indexer for a given namespace.
+func (s volumeRecommendationNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.VolumeRecommendation, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.VolumeRecommendation))
+	})
+	return ret, err
+}
+
+// Get retrieves the VolumeRecommendation from the indexer for a given namespace and name.
+func (s volumeRecommendationNamespaceLister) Get(name string) (*v1alpha1.VolumeRecommendation, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("volumerecommendation"), name)
+	}
+	return obj.(*v1alpha1.VolumeRecommendation), nil
+}
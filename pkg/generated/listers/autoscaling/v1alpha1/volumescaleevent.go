@@ -0,0 +1,83 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// VolumeScaleEventLister helps list VolumeScaleEvents.
+// All objects returned here must be treated as read-only.
+type VolumeScaleEventLister interface {
+	// List lists all VolumeScaleEvents in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.VolumeScaleEvent, err error)
+	// VolumeScaleEvents returns an object that can list and get VolumeScaleEvents.
+	VolumeScaleEvents(namespace string) VolumeScaleEventNamespaceLister
+	VolumeScaleEventListerExpansion
+}
+
+// volumeScaleEventLister implements the VolumeScaleEventLister interface.
+type volumeScaleEventLister struct {
+	indexer cache.Indexer
+}
+
+// NewVolumeScaleEventLister returns a new VolumeScaleEventLister.
+func NewVolumeScaleEventLister(indexer cache.Indexer) VolumeScaleEventLister {
+	return &volumeScaleEventLister{indexer: indexer}
+}
+
+// List lists all VolumeScaleEvents in the indexer.
+func (s *volumeScaleEventLister) List(selector labels.Selector) (ret []*v1alpha1.VolumeScaleEvent, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.VolumeScaleEvent))
+	})
+	return ret, err
+}
+
+// VolumeScaleEvents returns an object that can list and get VolumeScaleEvents.
+func (s *volumeScaleEventLister) VolumeScaleEvents(namespace string) VolumeScaleEventNamespaceLister {
+	return volumeScaleEventNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// VolumeScaleEventNamespaceLister helps list and get VolumeScaleEvents.
+// All objects returned here must be treated as read-only.
+type VolumeScaleEventNamespaceLister interface {
+	// List lists all VolumeScaleEvents in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.VolumeScaleEvent, err error)
+	// Get retrieves the VolumeScaleEvent from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.VolumeScaleEvent, error)
+	VolumeScaleEventNamespaceListerExpansion
+}
+
+// volumeScaleEventNamespaceLister implements the VolumeScaleEventNamespaceLister
+// interface.
+type volumeScaleEventNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all VolumeScaleEvents in the indexer for a given namespace.
+func (s volumeScaleEventNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.VolumeScaleEvent, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.VolumeScaleEvent))
+	})
+	return ret, err
+}
+
+// Get retrieves the VolumeScaleEvent from the indexer for a given namespace and name.
+func (s volumeScaleEventNamespaceLister) Get(name string) (*v1alpha1.VolumeScaleEvent, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("volumescaleevent"), name)
+	}
+	return obj.(*v1alpha1.VolumeScaleEvent), nil
+}
@@ -0,0 +1,83 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// VolumeScaleRequestLister helps list VolumeScaleRequests.
+// All objects returned here must be treated as read-only.
+type VolumeScaleRequestLister interface {
+	// List lists all VolumeScaleRequests in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.VolumeScaleRequest, err error)
+	// VolumeScaleRequests returns an object that can list and get VolumeScaleRequests.
+	VolumeScaleRequests(namespace string) VolumeScaleRequestNamespaceLister
+	VolumeScaleRequestListerExpansion
+}
+
+// volumeScaleRequestLister implements the VolumeScaleRequestLister interface.
+type volumeScaleRequestLister struct {
+	indexer cache.Indexer
+}
+
+// NewVolumeScaleRequestLister returns a new VolumeScaleRequestLister.
+func NewVolumeScaleRequestLister(indexer cache.Indexer) VolumeScaleRequestLister {
+	return &volumeScaleRequestLister{indexer: indexer}
+}
+
+// List lists all VolumeScaleRequests in the indexer.
+func (s *volumeScaleRequestLister) List(selector labels.Selector) (ret []*v1alpha1.VolumeScaleRequest, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.VolumeScaleRequest))
+	})
+	return ret, err
+}
+
+// VolumeScaleRequests returns an object that can list and get VolumeScaleRequests.
+func (s *volumeScaleRequestLister) VolumeScaleRequests(namespace string) VolumeScaleRequestNamespaceLister {
+	return volumeScaleRequestNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// VolumeScaleRequestNamespaceLister helps list and get VolumeScaleRequests.
+// All objects returned here must be treated as read-only.
+type VolumeScaleRequestNamespaceLister interface {
+	// List lists all VolumeScaleRequests in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.VolumeScaleRequest, err error)
+	// Get retrieves the VolumeScaleRequest from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.VolumeScaleRequest, error)
+	VolumeScaleRequestNamespaceListerExpansion
+}
+
+// volumeScaleRequestNamespaceLister implements the VolumeScaleRequestNamespaceLister
+// interface.
+type volumeScaleRequestNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all VolumeScaleRequests in the indexer for a given namespace.
+func (s volumeScaleRequestNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.VolumeScaleRequest, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.VolumeScaleRequest))
+	})
+	return ret, err
+}
+
+// Get retrieves the VolumeScaleRequest from the indexer for a given namespace and name.
+func (s volumeScaleRequestNamespaceLister) Get(name string) (*v1alpha1.VolumeScaleRequest, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("volumescalerequest"), name)
+	}
+	return obj.(*v1alpha1.VolumeScaleRequest), nil
+}
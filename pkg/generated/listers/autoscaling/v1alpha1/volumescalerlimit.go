@@ -0,0 +1,52 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// VolumeScalerLimitLister helps list VolumeScalerLimits.
+// All objects returned here must be treated as read-only.
+type VolumeScalerLimitLister interface {
+	// List lists all VolumeScalerLimits in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.VolumeScalerLimit, err error)
+	// Get retrieves the VolumeScalerLimit from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.VolumeScalerLimit, error)
+	VolumeScalerLimitListerExpansion
+}
+
+// volumeScalerLimitLister implements the VolumeScalerLimitLister interface.
+type volumeScalerLimitLister struct {
+	indexer cache.Indexer
+}
+
+// NewVolumeScalerLimitLister returns a new VolumeScalerLimitLister.
+func NewVolumeScalerLimitLister(indexer cache.Indexer) VolumeScalerLimitLister {
+	return &volumeScalerLimitLister{indexer: indexer}
+}
+
+// List lists all VolumeScalerLimits in the indexer.
+func (s *volumeScalerLimitLister) List(selector labels.Selector) (ret []*v1alpha1.VolumeScalerLimit, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.VolumeScalerLimit))
+	})
+	return ret, err
+}
+
+// Get retrieves the VolumeScalerLimit from the index for a given name.
+func (s *volumeScalerLimitLister) Get(name string) (*v1alpha1.VolumeScalerLimit, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("volumescalerlimit"), name)
+	}
+	return obj.(*v1alpha1.VolumeScalerLimit), nil
+}
@@ -0,0 +1,83 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/zghanem/sample-volumeScaler/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// VolumeUsageHistoryLister helps list VolumeUsageHistories.
+// All objects returned here must be treated as read-only.
+type VolumeUsageHistoryLister interface {
+	// List lists all VolumeUsageHistories in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.VolumeUsageHistory, err error)
+	// VolumeUsageHistories returns an object that can list and get VolumeUsageHistories.
+	VolumeUsageHistories(namespace string) VolumeUsageHistoryNamespaceLister
+	VolumeUsageHistoryListerExpansion
+}
+
+// volumeUsageHistoryLister implements the VolumeUsageHistoryLister interface.
+type volumeUsageHistoryLister struct {
+	indexer cache.Indexer
+}
+
+// NewVolumeUsageHistoryLister returns a new VolumeUsageHistoryLister.
+func NewVolumeUsageHistoryLister(indexer cache.Indexer) VolumeUsageHistoryLister {
+	return &volumeUsageHistoryLister{indexer: indexer}
+}
+
+// List lists all VolumeUsageHistories in the indexer.
+func (s *volumeUsageHistoryLister) List(selector labels.Selector) (ret []*v1alpha1.VolumeUsageHistory, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.VolumeUsageHistory))
+	})
+	return ret, err
+}
+
+// VolumeUsageHistories returns an object that can list and get VolumeUsageHistories.
+func (s *volumeUsageHistoryLister) VolumeUsageHistories(namespace string) VolumeUsageHistoryNamespaceLister {
+	return volumeUsageHistoryNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// VolumeUsageHistoryNamespaceLister helps list and get VolumeUsageHistories.
+// All objects returned here must be treated as read-only.
+type VolumeUsageHistoryNamespaceLister interface {
+	// List lists all VolumeUsageHistories in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.VolumeUsageHistory, err error)
+	// Get retrieves the VolumeUsageHistory from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.VolumeUsageHistory, error)
+	VolumeUsageHistoryNamespaceListerExpansion
+}
+
+// volumeUsageHistoryNamespaceLister implements the VolumeUsageHistoryNamespaceLister
+// interface.
+type volumeUsageHistoryNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all VolumeUsageHistories in the indexer for a given namespace.
+func (s volumeUsageHistoryNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.VolumeUsageHistory, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.VolumeUsageHistory))
+	})
+	return ret, err
+}
+
+// Get retrieves the VolumeUsageHistory from the indexer for a given namespace and name.
+func (s volumeUsageHistoryNamespaceLister) Get(name string) (*v1alpha1.VolumeUsageHistory, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("volumeusagehistory"), name)
+	}
+	return obj.(*v1alpha1.VolumeUsageHistory), nil
+}
@@ -0,0 +1,11 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1beta1
+
+// VolumeScalerListerExpansion allows custom methods to be added to
+// VolumeScalerLister.
+type VolumeScalerListerExpansion interface{}
+
+// VolumeScalerNamespaceListerExpansion allows custom methods to be added to
+// VolumeScalerNamespaceLister.
+type VolumeScalerNamespaceListerExpansion interface{}
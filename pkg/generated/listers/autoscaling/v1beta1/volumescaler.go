@@ -0,0 +1,83 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/zghanem/sample-volumeScaler/api/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// VolumeScalerLister helps list VolumeScalers.
+// All objects returned here must be treated as read-only.
+type VolumeScalerLister interface {
+	// List lists all VolumeScalers in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.VolumeScaler, err error)
+	// VolumeScalers returns an object that can list and get VolumeScalers.
+	VolumeScalers(namespace string) VolumeScalerNamespaceLister
+	VolumeScalerListerExpansion
+}
+
+// volumeScalerLister implements the VolumeScalerLister interface.
+type volumeScalerLister struct {
+	indexer cache.Indexer
+}
+
+// NewVolumeScalerLister returns a new VolumeScalerLister.
+func NewVolumeScalerLister(indexer cache.Indexer) VolumeScalerLister {
+	return &volumeScalerLister{indexer: indexer}
+}
+
+// List lists all VolumeScalers in the indexer.
+func (s *volumeScalerLister) List(selector labels.Selector) (ret []*v1beta1.VolumeScaler, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.VolumeScaler))
+	})
+	return ret, err
+}
+
+// VolumeScalers returns an object that can list and get VolumeScalers.
+func (s *volumeScalerLister) VolumeScalers(namespace string) VolumeScalerNamespaceLister {
+	return volumeScalerNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// VolumeScalerNamespaceLister helps list and get VolumeScalers.
+// All objects returned here must be treated as read-only.
+type VolumeScalerNamespaceLister interface {
+	// List lists all VolumeScalers in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1beta1.VolumeScaler, err error)
+	// Get retrieves the VolumeScaler from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1beta1.VolumeScaler, error)
+	VolumeScalerNamespaceListerExpansion
+}
+
+// volumeScalerNamespaceLister implements the VolumeScalerNamespaceLister
+// interface.
+type volumeScalerNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all VolumeScalers in the indexer for a given namespace.
+func (s volumeScalerNamespaceLister) List(selector labels.Selector) (ret []*v1beta1.VolumeScaler, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1beta1.VolumeScaler))
+	})
+	return ret, err
+}
+
+// Get retrieves the VolumeScaler from the indexer for a given namespace and name.
+func (s volumeScalerNamespaceLister) Get(name string) (*v1beta1.VolumeScaler, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1beta1.Resource("volumescaler"), name)
+	}
+	return obj.(*v1beta1.VolumeScaler), nil
+}